@@ -13,6 +13,7 @@ import (
 	"strconv"
 
 	"github.com/spf13/cobra"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -23,21 +24,113 @@ var (
 	drainMaxAbsolute           int
 	drainMaxFraction           float64
 	drainStepRules             string
+	drainTargetAllocateRate    int
+	drainAllocateResources     string
 	drainSafetyMaxAllocateRate int
 	drainSafetyQueries         string
+	drainSafetyChecks          string
+	drainSafetyChecksFile      string
 	drainSafetyFailClosed      bool
 	drainProgressive           bool
 
-	podEvictionMode        string
-	podForce               bool
-	podForceProblemPods    bool
-	podPDBToken            bool
-	podPDBTokenMaxInFlight int
-	podMaxConcurrent       int
-	podMaxRetries          int
-	podRetryBackoff        string
-	podDeletionTimeout     string
-	podCheckInterval       string
+	drainMaxPerZone         int
+	drainMaxFractionPerZone float64
+	drainZoneInterleave     bool
+
+	drainCapacityTypeOrder               string
+	drainMaxSpot                         int
+	drainMaxOnDemand                     int
+	drainProtectOnDemandWhenSpotUnstable bool
+	drainSpotUnstableWindow              string
+	drainSpotUnstableThreshold           int
+
+	nodeSelector        string
+	excludeNodeSelector string
+	instanceTypes       string
+	excludeNodes        string
+	nodeNames           string
+
+	drainScoreWeights string
+	drainScorePrices  string
+
+	drainFeasibilityCheck bool
+
+	drainBudgetMaxNodes  int
+	drainBudgetWindow    string
+	drainBudgetNamespace string
+	drainBudgetConfigMap string
+
+	drainHonorKarpenterBudgets  bool
+	drainKarpenterBudgetReasons string
+
+	allocateRateWindow       string
+	allocateRateAggregation  string
+	drainHysteresisThreshold int
+	drainHysteresisWindow    string
+
+	drainForecastHorizon  string
+	drainForecastPeriod   string
+	drainForecastLookback int
+
+	drainSafetyMaxPendingPods            int
+	drainSafetyMaxUnavailableWorkloads   int
+	drainSafetyMaxNotReadyNodes          int
+	drainSafetyMaxFailedSchedulingEvents int
+	drainSafetyFailedSchedulingWindow    string
+
+	drainWaitWorkloadRecovery       bool
+	drainWorkloadRecoveryTimeout    string
+	drainWorkloadRecoveryInterval   string
+	drainWorkloadRecoveryFailPolicy string
+
+	drainNodeConcurrency int
+
+	drainCanary              bool
+	drainCanarySoak          string
+	drainCanaryCheckInterval string
+
+	drainSurge              bool
+	drainSurgeNamespace     string
+	drainSurgePriorityClass string
+	drainSurgeImage         string
+	drainSurgeTimeout       string
+	drainSurgeInterval      string
+
+	drainBreakerMaxEvictionFailureRate float64
+	drainBreakerMinEvictions           int
+	drainBreakerMaxForceDeletes        int
+	drainBreakerMaxFailedNodes         int
+
+	drainAdaptivePacing       bool
+	drainPacingMinDelay       string
+	drainPacingMaxDelay       string
+	drainPacingInterval       string
+	drainPacingMaxPendingPods int
+
+	drainUnhealthy                      bool
+	drainUnhealthyConditions            string
+	drainUnhealthyFlapWindow            string
+	drainUnhealthyFlapThreshold         int
+	drainUnhealthyPodEvictionMode       string
+	drainUnhealthyForce                 bool
+	drainUnhealthyPodMaxRetries         int
+	drainUnhealthyPodDeletionTimeout    string
+	drainUnhealthySkipPostEvictionDelay bool
+
+	podEvictionMode          string
+	podForce                 bool
+	podForceOnPDBBlock       bool
+	podForceProblemPods      bool
+	podPDBToken              bool
+	podPDBTokenMaxInFlight   int
+	podMaxConcurrent         int
+	podMaxRetries            int
+	podRetryBackoff          string
+	podDeletionTimeout       string
+	podCheckInterval         string
+	podGracePeriodMin        string
+	podGracePeriodMax        string
+	podGracePeriodNamespaces string
 )
 
 var drainCmd = &cobra.Command{
@@ -51,14 +144,116 @@ var drainCmd = &cobra.Command{
 		_ = os.Setenv("DRAIN_MAX_ABSOLUTE", strconv.Itoa(drainMaxAbsolute))
 		_ = os.Setenv("DRAIN_MAX_FRACTION", strconv.FormatFloat(drainMaxFraction, 'f', -1, 64))
 		_ = os.Setenv("DRAIN_STEP_RULES", drainStepRules)
+		_ = os.Setenv("DRAIN_TARGET_ALLOCATE_RATE", strconv.Itoa(drainTargetAllocateRate))
+		setAllocateResourcesEnv()
 		_ = os.Setenv("DRAIN_SAFETY_MAX_ALLOCATE_RATE", strconv.Itoa(drainSafetyMaxAllocateRate))
 		_ = os.Setenv("DRAIN_SAFETY_QUERIES", drainSafetyQueries)
+		_ = os.Setenv("DRAIN_SAFETY_CHECKS", drainSafetyChecks)
+		_ = os.Setenv("DRAIN_SAFETY_CHECKS_FILE", drainSafetyChecksFile)
 		_ = os.Setenv("DRAIN_SAFETY_FAIL_CLOSED", strconv.FormatBool(drainSafetyFailClosed))
 		_ = os.Setenv("DRAIN_PROGRESSIVE", strconv.FormatBool(drainProgressive))
 
+		// 존 분산 플래그 -> env 주입
+		_ = os.Setenv("DRAIN_MAX_PER_ZONE", strconv.Itoa(drainMaxPerZone))
+		_ = os.Setenv("DRAIN_MAX_FRACTION_PER_ZONE", strconv.FormatFloat(drainMaxFractionPerZone, 'f', -1, 64))
+		_ = os.Setenv("DRAIN_ZONE_INTERLEAVE", strconv.FormatBool(drainZoneInterleave))
+
+		// capacity-type(spot/on-demand) 플래그 -> env 주입
+		_ = os.Setenv("DRAIN_CAPACITY_TYPE_ORDER", drainCapacityTypeOrder)
+		_ = os.Setenv("DRAIN_MAX_SPOT", strconv.Itoa(drainMaxSpot))
+		_ = os.Setenv("DRAIN_MAX_ON_DEMAND", strconv.Itoa(drainMaxOnDemand))
+		_ = os.Setenv("DRAIN_PROTECT_ON_DEMAND_WHEN_SPOT_UNSTABLE", strconv.FormatBool(drainProtectOnDemandWhenSpotUnstable))
+		_ = os.Setenv("DRAIN_SPOT_UNSTABLE_WINDOW", drainSpotUnstableWindow)
+		_ = os.Setenv("DRAIN_SPOT_UNSTABLE_THRESHOLD", strconv.Itoa(drainSpotUnstableThreshold))
+
+		// 노드 필터 플래그 -> env 주입
+		_ = os.Setenv("DRAIN_NODE_SELECTOR", nodeSelector)
+		_ = os.Setenv("DRAIN_EXCLUDE_NODE_SELECTOR", excludeNodeSelector)
+		_ = os.Setenv("DRAIN_INSTANCE_TYPES", instanceTypes)
+		_ = os.Setenv("DRAIN_EXCLUDE_NODES", excludeNodes)
+		_ = os.Setenv("DRAIN_NODES", nodeNames)
+
+		// 노드 점수 플래그 -> env 주입
+		_ = os.Setenv("DRAIN_SCORE_WEIGHTS", drainScoreWeights)
+		_ = os.Setenv("DRAIN_SCORE_PRICES", drainScorePrices)
+
+		// 배치 가능성 시뮬레이션 플래그 -> env 주입
+		_ = os.Setenv("DRAIN_FEASIBILITY_CHECK", strconv.FormatBool(drainFeasibilityCheck))
+
+		// 드레인 예산 플래그 -> env 주입
+		setDrainBudgetEnv()
+
+		// Karpenter disruption budget 플래그 -> env 주입
+		_ = os.Setenv("DRAIN_HONOR_KARPENTER_BUDGETS", strconv.FormatBool(drainHonorKarpenterBudgets))
+		_ = os.Setenv("DRAIN_KARPENTER_BUDGET_REASONS", drainKarpenterBudgetReasons)
+
+		// 기간 집계 allocate rate / hysteresis 플래그 -> env 주입
+		setAllocateRateWindowEnv()
+		_ = os.Setenv("DRAIN_HYSTERESIS_THRESHOLD", strconv.Itoa(drainHysteresisThreshold))
+		_ = os.Setenv("DRAIN_HYSTERESIS_WINDOW", drainHysteresisWindow)
+
+		// forecast 정책 플래그 -> env 주입
+		_ = os.Setenv("DRAIN_FORECAST_HORIZON", drainForecastHorizon)
+		_ = os.Setenv("DRAIN_FORECAST_PERIOD", drainForecastPeriod)
+		_ = os.Setenv("DRAIN_FORECAST_LOOKBACK", strconv.Itoa(drainForecastLookback))
+
+		// 클러스터 안전 조건 플래그 -> env 주입
+		_ = os.Setenv("DRAIN_SAFETY_MAX_PENDING_PODS", strconv.Itoa(drainSafetyMaxPendingPods))
+		_ = os.Setenv("DRAIN_SAFETY_MAX_UNAVAILABLE_WORKLOADS", strconv.Itoa(drainSafetyMaxUnavailableWorkloads))
+		_ = os.Setenv("DRAIN_SAFETY_MAX_NOT_READY_NODES", strconv.Itoa(drainSafetyMaxNotReadyNodes))
+		_ = os.Setenv("DRAIN_SAFETY_MAX_FAILED_SCHEDULING_EVENTS", strconv.Itoa(drainSafetyMaxFailedSchedulingEvents))
+		_ = os.Setenv("DRAIN_SAFETY_FAILED_SCHEDULING_WINDOW", drainSafetyFailedSchedulingWindow)
+
+		// 워크로드 복구 대기 플래그 -> env 주입
+		_ = os.Setenv("DRAIN_WAIT_WORKLOAD_RECOVERY", strconv.FormatBool(drainWaitWorkloadRecovery))
+		_ = os.Setenv("DRAIN_WORKLOAD_RECOVERY_TIMEOUT", drainWorkloadRecoveryTimeout)
+		_ = os.Setenv("DRAIN_WORKLOAD_RECOVERY_INTERVAL", drainWorkloadRecoveryInterval)
+		_ = os.Setenv("DRAIN_WORKLOAD_RECOVERY_FAIL_POLICY", drainWorkloadRecoveryFailPolicy)
+
+		// 노드 동시 드레인 플래그 -> env 주입
+		_ = os.Setenv("DRAIN_NODE_CONCURRENCY", strconv.Itoa(drainNodeConcurrency))
+
+		// canary 드레인 플래그 -> env 주입
+		_ = os.Setenv("DRAIN_CANARY", strconv.FormatBool(drainCanary))
+		_ = os.Setenv("DRAIN_CANARY_SOAK", drainCanarySoak)
+		_ = os.Setenv("DRAIN_CANARY_CHECK_INTERVAL", drainCanaryCheckInterval)
+
+		// surge 용량 확보 플래그 -> env 주입
+		_ = os.Setenv("DRAIN_SURGE", strconv.FormatBool(drainSurge))
+		_ = os.Setenv("DRAIN_SURGE_NAMESPACE", drainSurgeNamespace)
+		_ = os.Setenv("DRAIN_SURGE_PRIORITY_CLASS", drainSurgePriorityClass)
+		_ = os.Setenv("DRAIN_SURGE_IMAGE", drainSurgeImage)
+		_ = os.Setenv("DRAIN_SURGE_TIMEOUT", drainSurgeTimeout)
+		_ = os.Setenv("DRAIN_SURGE_INTERVAL", drainSurgeInterval)
+
+		// circuit breaker 플래그 -> env 주입
+		_ = os.Setenv("DRAIN_BREAKER_MAX_EVICTION_FAILURE_RATE", strconv.FormatFloat(drainBreakerMaxEvictionFailureRate, 'f', -1, 64))
+		_ = os.Setenv("DRAIN_BREAKER_MIN_EVICTIONS", strconv.Itoa(drainBreakerMinEvictions))
+		_ = os.Setenv("DRAIN_BREAKER_MAX_FORCE_DELETES", strconv.Itoa(drainBreakerMaxForceDeletes))
+		_ = os.Setenv("DRAIN_BREAKER_MAX_FAILED_NODES", strconv.Itoa(drainBreakerMaxFailedNodes))
+
+		// 적응형 pacing 플래그 -> env 주입
+		_ = os.Setenv("DRAIN_ADAPTIVE_PACING", strconv.FormatBool(drainAdaptivePacing))
+		_ = os.Setenv("DRAIN_PACING_MIN_DELAY", drainPacingMinDelay)
+		_ = os.Setenv("DRAIN_PACING_MAX_DELAY", drainPacingMaxDelay)
+		_ = os.Setenv("DRAIN_PACING_INTERVAL", drainPacingInterval)
+		_ = os.Setenv("DRAIN_PACING_MAX_PENDING_PODS", strconv.Itoa(drainPacingMaxPendingPods))
+
+		// 비정상 노드 우선 드레인 플래그 -> env 주입
+		_ = os.Setenv("DRAIN_UNHEALTHY", strconv.FormatBool(drainUnhealthy))
+		_ = os.Setenv("DRAIN_UNHEALTHY_CONDITIONS", drainUnhealthyConditions)
+		_ = os.Setenv("DRAIN_UNHEALTHY_FLAP_WINDOW", drainUnhealthyFlapWindow)
+		_ = os.Setenv("DRAIN_UNHEALTHY_FLAP_THRESHOLD", strconv.Itoa(drainUnhealthyFlapThreshold))
+		_ = os.Setenv("DRAIN_UNHEALTHY_POD_EVICTION_MODE", drainUnhealthyPodEvictionMode)
+		_ = os.Setenv("DRAIN_UNHEALTHY_FORCE", strconv.FormatBool(drainUnhealthyForce))
+		_ = os.Setenv("DRAIN_UNHEALTHY_POD_MAX_RETRIES", strconv.Itoa(drainUnhealthyPodMaxRetries))
+		_ = os.Setenv("DRAIN_UNHEALTHY_POD_DELETION_TIMEOUT", drainUnhealthyPodDeletionTimeout)
+		_ = os.Setenv("DRAIN_UNHEALTHY_SKIP_POST_EVICTION_DELAY", strconv.FormatBool(drainUnhealthySkipPostEvictionDelay))
+
 		// pod 제거 정책 플래그 -> env 주입
 		_ = os.Setenv("POD_EVICTION_MODE", podEvictionMode)
 		_ = os.Setenv("POD_FORCE", strconv.FormatBool(podForce))
+		_ = os.Setenv("POD_FORCE_ON_PDB_BLOCK", strconv.FormatBool(podForceOnPDBBlock))
 		_ = os.Setenv("POD_FORCE_PROBLEM_PODS", strconv.FormatBool(podForceProblemPods))
 		_ = os.Setenv("POD_PDB_TOKEN", strconv.FormatBool(podPDBToken))
 		_ = os.Setenv("POD_PDB_TOKEN_MAX_IN_FLIGHT", strconv.Itoa(podPDBTokenMaxInFlight))
@@ -67,6 +262,9 @@ var drainCmd = &cobra.Command{
 		_ = os.Setenv("POD_RETRY_BACKOFF", podRetryBackoff)
 		_ = os.Setenv("POD_DELETION_TIMEOUT", podDeletionTimeout)
 		_ = os.Setenv("POD_CHECK_INTERVAL", podCheckInterval)
+		_ = os.Setenv("POD_GRACE_PERIOD_MIN", podGracePeriodMin)
+		_ = os.Setenv("POD_GRACE_PERIOD_MAX", podGracePeriodMax)
+		_ = os.Setenv("POD_GRACE_PERIOD_NAMESPACES", podGracePeriodNamespaces)
 
 		ctx := command.Context()
 		if ctx == nil {
@@ -82,11 +280,17 @@ var drainCmd = &cobra.Command{
 			return fmt.Errorf("쿠버네티스 클라이언트 생성 실패: %w", err)
 		}
 
-		return handleNodeDrain(ctx, clientSet)
+		dynamicClient, err := config.GetKubeDynamicClient(kubeConfigMode, kubeConfigPath)
+		if err != nil {
+			slog.Error("쿠버네티스 dynamic 클라이언트 생성 실패", "error", err)
+			return fmt.Errorf("쿠버네티스 dynamic 클라이언트 생성 실패: %w", err)
+		}
+
+		return handleNodeDrain(ctx, clientSet, dynamicClient)
 	},
 }
 
-func handleNodeDrain(ctx context.Context, clientSet kubernetes.Interface) error {
+func handleNodeDrain(ctx context.Context, clientSet kubernetes.Interface, dynamicClient dynamic.Interface) error {
 	slog.Info("노드 드레인 커맨드를 실행합니다.")
 
 	prometheusClient, err := config.CreatePrometheusClient()
@@ -97,7 +301,7 @@ func handleNodeDrain(ctx context.Context, clientSet kubernetes.Interface) error
 
 	nodepool := os.Getenv("NODEPOOL_NAME")
 	metricsQuerier := karpenter.NewPrometheusQuerier(prometheusClient)
-	karpenterClient := karpenter.NewClient(nodepool, metricsQuerier)
+	karpenterClient := karpenter.NewClient(nodepool, metricsQuerier).WithWindow(karpenter.GetAllocateRateWindowFromEnv())
 	notifier := notification.NewSlackNotifier(notification.SlackConfig{
 		WebhookURL:   os.Getenv("SLACK_WEBHOOK_URL"),
 		ClusterName:  os.Getenv("CLUSTER_NAME"),
@@ -107,19 +311,21 @@ func handleNodeDrain(ctx context.Context, clientSet kubernetes.Interface) error
 	drainConfig := node.DefaultDrainConfig(nodepool)
 	drainConfig.Eviction = pod.GetEvictionConfigFromEnv()
 
-	results, err := node.NodeDrain(ctx, clientSet, node.DrainDependencies{
+	results, summary, err := node.NodeDrainWithSummary(ctx, clientSet, node.DrainDependencies{
 		AllocateRateProvider: karpenterClient,
 		Notifier:             notifier,
+		DynamicClient:        dynamicClient,
+		SafetyQuerier:        metricsQuerier,
 	}, drainConfig)
 	if err != nil {
 		slog.Error("노드 드레인 실패", "error", err)
-		if notifyErr := notifier.SendNodeDrainError(ctx, err); notifyErr != nil {
+		if notifyErr := notifier.SendNodeDrainErrorWithSummary(ctx, err, summary); notifyErr != nil {
 			slog.Error("슬랙 알림 전송 실패", "error", notifyErr)
 		}
 		return err
 	}
 
-	if err = notifier.SendNodeDrainComplete(ctx, results); err != nil {
+	if err = notifier.SendNodeDrainCompleteWithSummary(ctx, results, summary); err != nil {
 		slog.Error("슬랙 알림 전송 실패", "error", err)
 	}
 	return nil
@@ -128,19 +334,104 @@ func handleNodeDrain(ctx context.Context, clientSet kubernetes.Interface) error
 func init() {
 	rootCmd.AddCommand(drainCmd)
 
-	drainCmd.Flags().StringVar(&drainPolicy, "drain-policy", "formula", "드레인 정책 (formula|step)")
+	drainCmd.Flags().StringVar(&drainPolicy, "drain-policy", "formula", "드레인 정책 (formula|step|target|forecast)")
 	drainCmd.Flags().StringVar(&drainRounding, "drain-rounding", "floor", "드레인 계산 라운딩 (floor|round|ceil)")
 	drainCmd.Flags().IntVar(&drainMin, "drain-min", 0, "드레인 최소 노드 수 (0이면 비활성)")
 	drainCmd.Flags().IntVar(&drainMaxAbsolute, "drain-max-absolute", 0, "드레인 최대 노드 수(절대값, 0이면 비활성)")
 	drainCmd.Flags().Float64Var(&drainMaxFraction, "drain-max-fraction", 0, "드레인 최대 비율(예: 0.2=최대 20%, 0이면 비활성)")
 	drainCmd.Flags().StringVar(&drainStepRules, "drain-step-rules", "", "계단식 정책 규칙 (예: \"80:1,60:2\")")
+	drainCmd.Flags().IntVar(&drainTargetAllocateRate, "target-allocate-rate", 80, "target 정책: 드레인 후 예상 사용률(%)이 이 값 미만이 되도록 노드별 allocatable 기준으로 후보 선택")
+	addAllocateResourcesFlags(drainCmd)
 	drainCmd.Flags().IntVar(&drainSafetyMaxAllocateRate, "drain-safety-max-allocate-rate", 0, "안전 조건: maxAllocateRate가 이 값 이상이면 0대로 강제 (0이면 비활성)")
 	drainCmd.Flags().StringVar(&drainSafetyQueries, "drain-safety-queries", "", "안전 조건 PromQL(세미콜론/개행 구분). 하나라도 결과가 >0이면 0대로 강제")
+	drainCmd.Flags().StringVar(&drainSafetyChecks, "drain-safety-checks", "", "이름이 있는 안전 조건 목록(YAML/JSON). 항목: name, query, comparator(>,>=,<,<=,==), threshold, failMode(closed|open), timeout")
+	drainCmd.Flags().StringVar(&drainSafetyChecksFile, "drain-safety-checks-file", "", "안전 조건 목록 파일 경로(YAML/JSON). 지정하면 --drain-safety-checks 대신 사용")
 	drainCmd.Flags().BoolVar(&drainSafetyFailClosed, "drain-safety-fail-closed", true, "안전 조건 쿼리 실패 시 0대로 강제할지 여부")
 	drainCmd.Flags().BoolVar(&drainProgressive, "drain-progressive", true, "점진적 드레인: 노드 1대 처리 후 안전 조건 재평가")
 
+	drainCmd.Flags().IntVar(&drainMaxPerZone, "drain-max-per-zone", 0, "존(topology.kubernetes.io/zone)별 드레인 최대 노드 수(0이면 비활성)")
+	drainCmd.Flags().Float64Var(&drainMaxFractionPerZone, "drain-max-fraction-per-zone", 0, "존별 드레인 최대 비율(예: 0.5=존 내 최대 50%, 0이면 비활성)")
+	drainCmd.Flags().BoolVar(&drainZoneInterleave, "drain-zone-interleave", true, "드레인 후보 정렬 시 존을 번갈아 배치할지 여부")
+
+	drainCmd.Flags().StringVar(&drainCapacityTypeOrder, "drain-capacity-type-order", "", "capacity-type 드레인 순서(콤마 구분, 예: \"spot,on-demand\"는 spot 먼저)")
+	drainCmd.Flags().IntVar(&drainMaxSpot, "drain-max-spot", 0, "spot 노드 드레인 최대 수(0이면 비활성)")
+	drainCmd.Flags().IntVar(&drainMaxOnDemand, "drain-max-on-demand", 0, "on-demand 노드 드레인 최대 수(0이면 비활성)")
+	drainCmd.Flags().BoolVar(&drainProtectOnDemandWhenSpotUnstable, "drain-protect-on-demand-when-spot-unstable", false, "spot 용량이 불안정하면 on-demand 노드를 드레인하지 않음")
+	drainCmd.Flags().StringVar(&drainSpotUnstableWindow, "drain-spot-unstable-window", "1h", "spot 불안정 판단용 SpotInterrupted 이벤트 집계 window")
+	drainCmd.Flags().IntVar(&drainSpotUnstableThreshold, "drain-spot-unstable-threshold", 1, "window 내 SpotInterrupted 이벤트가 이 값 이상이면 spot 불안정으로 판단")
+
+	drainCmd.Flags().StringVar(&nodeSelector, "node-selector", "", "nodepool 라벨에 추가로 적용할 노드 라벨 셀렉터(예: \"karpenter.k8s.aws/instance-family=m5\")")
+	drainCmd.Flags().StringVar(&excludeNodeSelector, "exclude-node-selector", "", "이 라벨 셀렉터에 매칭되는 노드는 드레인 대상에서 제외")
+	drainCmd.Flags().StringVar(&instanceTypes, "instance-types", "", "드레인 대상 인스턴스 타입 목록(콤마 구분, 예: \"m5.large,m5.xlarge\")")
+	drainCmd.Flags().StringVar(&excludeNodes, "exclude-nodes", "", "드레인 대상에서 제외할 노드 이름 목록(콤마 구분)")
+	drainCmd.Flags().StringVar(&nodeNames, "nodes", "", "드레인 대상으로 허용할 노드 이름 목록(콤마 구분, 긴급 드레인용)")
+
+	drainCmd.Flags().StringVar(&drainScoreWeights, "drain-score-weights", "age=1", "드레인 후보 점수 가중치 (age,utilization,pods,pdb,zoneSkew,price; 예: \"age=1,utilization=2,pdb=1\")")
+	drainCmd.Flags().StringVar(&drainScorePrices, "drain-score-prices", "", "price 신호용 인스턴스 타입별 시간당 가격 (예: \"m5.large=0.096,m5.xlarge=0.192\")")
+
+	drainCmd.Flags().BoolVar(&drainFeasibilityCheck, "drain-feasibility-check", false, "cordon 전 드레인할 노드의 파드가 남은 노드에 배치 가능한지 시뮬레이션하고, 불가능한 노드는 계획에서 제외")
+
+	addDrainBudgetFlags(drainCmd)
+
+	drainCmd.Flags().BoolVar(&drainHonorKarpenterBudgets, "drain-honor-karpenter-budgets", false, "Karpenter NodePool spec.disruption.budgets 중 현재 활성인 budget으로 드레인 노드 수 제한")
+	drainCmd.Flags().StringVar(&drainKarpenterBudgetReasons, "drain-karpenter-budget-reasons", "", "적용할 budget reason 목록(콤마 구분, 예: \"Drifted\"). 비우면 reason과 관계없이 활성 budget 모두 적용")
+
+	addAllocateRateWindowFlags(drainCmd)
+	drainCmd.Flags().IntVar(&drainHysteresisThreshold, "drain-hysteresis-threshold", 0, "최근 --drain-hysteresis-window 동안 사용률 최대값이 이 값 미만으로 유지될 때만 드레인 (0이면 비활성)")
+	drainCmd.Flags().StringVar(&drainHysteresisWindow, "drain-hysteresis-window", "1h", "hysteresis 판단 기간")
+
+	drainCmd.Flags().StringVar(&drainForecastHorizon, "drain-forecast-horizon", "4h", "forecast 정책: 예측할 향후 기간")
+	drainCmd.Flags().StringVar(&drainForecastPeriod, "drain-forecast-period", "24h", "forecast 정책: 과거 같은 시간대 간격 (24h=일, 168h=주)")
+	drainCmd.Flags().IntVar(&drainForecastLookback, "drain-forecast-lookback", 7, "forecast 정책: 비교할 과거 주기 수")
+
+	drainCmd.Flags().IntVar(&drainSafetyMaxPendingPods, "drain-safety-max-pending-pods", 0, "Pending 파드 수가 이 값 이상이면 0대로 강제 (0이면 비활성)")
+	drainCmd.Flags().IntVar(&drainSafetyMaxUnavailableWorkloads, "drain-safety-max-unavailable-workloads", 0, "가용 replica가 부족한 Deployment/StatefulSet 수가 이 값 이상이면 0대로 강제 (0이면 비활성)")
+	drainCmd.Flags().IntVar(&drainSafetyMaxNotReadyNodes, "drain-safety-max-not-ready-nodes", 0, "nodepool 내 NotReady 노드 수가 이 값 이상이면 0대로 강제 (0이면 비활성)")
+	drainCmd.Flags().IntVar(&drainSafetyMaxFailedSchedulingEvents, "drain-safety-max-failed-scheduling-events", 0, "최근 FailedScheduling 발생 횟수가 이 값 이상이면 0대로 강제 (0이면 비활성)")
+	drainCmd.Flags().StringVar(&drainSafetyFailedSchedulingWindow, "drain-safety-failed-scheduling-window", "10m", "FailedScheduling 이벤트 집계 기간")
+
+	drainCmd.Flags().BoolVar(&drainWaitWorkloadRecovery, "drain-wait-workload-recovery", false, "점진적 드레인: 다음 노드 전에 드레인한 파드의 Deployment/StatefulSet/ReplicaSet이 모두 available이 될 때까지 대기 (--node-concurrency 1에서만 사용 가능)")
+	drainCmd.Flags().StringVar(&drainWorkloadRecoveryTimeout, "drain-workload-recovery-timeout", "10m", "워크로드 복구 대기 타임아웃")
+	drainCmd.Flags().StringVar(&drainWorkloadRecoveryInterval, "drain-workload-recovery-interval", "10s", "워크로드 복구 확인 주기")
+	drainCmd.Flags().StringVar(&drainWorkloadRecoveryFailPolicy, "drain-workload-recovery-fail-policy", "stop", "복구 대기 타임아웃 시 동작 (stop: 추가 드레인 중단|continue: 다음 노드 진행|fail: 오류로 종료)")
+
+	drainCmd.Flags().IntVar(&drainNodeConcurrency, "node-concurrency", 1, "동시에 드레인할 노드 수 (PDB 토큰과 pod-max-concurrent 제한은 모든 노드가 공유, 새 노드 시작 전 안전 조건 재평가, 워크로드 복구 대기와 함께 사용 불가)")
+
+	drainCmd.Flags().BoolVar(&drainCanary, "drain-canary", false, "첫 노드를 canary로 드레인하고 soak 기간 동안 안전 조건/워크로드 상태를 점검한 뒤 통과해야 나머지 노드 진행")
+	drainCmd.Flags().StringVar(&drainCanarySoak, "drain-canary-soak", "5m", "canary 노드 드레인 후 관찰 기간")
+	drainCmd.Flags().StringVar(&drainCanaryCheckInterval, "drain-canary-check-interval", "30s", "canary soak 중 점검 주기")
+
+	drainCmd.Flags().BoolVar(&drainSurge, "drain-surge", false, "cordon 전에 제거될 파드와 같은 request의 낮은 우선순위 placeholder 파드를 만들어 용량을 먼저 확보(실행 종료 시 정리)")
+	drainCmd.Flags().StringVar(&drainSurgeNamespace, "drain-surge-namespace", "default", "surge placeholder 파드를 만들 namespace")
+	drainCmd.Flags().StringVar(&drainSurgePriorityClass, "drain-surge-priority-class", "node-drain-surge", "surge placeholder PriorityClass (없으면 value -10으로 생성)")
+	drainCmd.Flags().StringVar(&drainSurgeImage, "drain-surge-image", "registry.k8s.io/pause:3.9", "surge placeholder 컨테이너 이미지")
+	drainCmd.Flags().StringVar(&drainSurgeTimeout, "drain-surge-timeout", "10m", "placeholder가 Ready(용량 확보)가 될 때까지 대기 시간, 초과 시 경고 후 드레인 진행")
+	drainCmd.Flags().StringVar(&drainSurgeInterval, "drain-surge-interval", "10s", "surge 용량 확보 확인 주기")
+
+	drainCmd.Flags().Float64Var(&drainBreakerMaxEvictionFailureRate, "drain-breaker-max-eviction-failure-rate", 0, "eviction 실패 비율(%)이 이 값을 넘으면 실행 중단 (0이면 비활성)")
+	drainCmd.Flags().IntVar(&drainBreakerMinEvictions, "drain-breaker-min-evictions", 10, "실패 비율 breaker를 평가하기 위한 최소 eviction 시도 수")
+	drainCmd.Flags().IntVar(&drainBreakerMaxForceDeletes, "drain-breaker-max-force-deletes", 0, "강제 삭제 수가 이 값을 넘으면 실행 중단 (0이면 비활성)")
+	drainCmd.Flags().IntVar(&drainBreakerMaxFailedNodes, "drain-breaker-max-failed-nodes", 0, "실패 노드 수가 이 값을 넘으면 실행 중단 (0이면 비활성, breaker가 하나도 없으면 첫 실패에서 중단)")
+
+	drainCmd.Flags().BoolVar(&drainAdaptivePacing, "drain-adaptive-pacing", false, "노드 사이 고정 대기(50s) 대신 재스케줄링 상황(워크로드 복구, Pending 파드)에 맞춰 대기")
+	drainCmd.Flags().StringVar(&drainPacingMinDelay, "drain-pacing-min-delay", "10s", "적응형 pacing 최소 대기 시간")
+	drainCmd.Flags().StringVar(&drainPacingMaxDelay, "drain-pacing-max-delay", "5m", "적응형 pacing 최대 대기 시간")
+	drainCmd.Flags().StringVar(&drainPacingInterval, "drain-pacing-interval", "5s", "적응형 pacing 재스케줄링 상태 확인 주기")
+	drainCmd.Flags().IntVar(&drainPacingMaxPendingPods, "drain-pacing-max-pending-pods", 0, "드레인한 워크로드의 Pending 파드가 이 수 이하이면 재스케줄링이 끝난 것으로 판단 (무관한 Pending 파드는 제외)")
+
+	drainCmd.Flags().BoolVar(&drainUnhealthy, "drain-unhealthy", false, "비정상 노드 우선 드레인 모드 (비정상 노드를 정상 노드보다 먼저 드레인)")
+	drainCmd.Flags().StringVar(&drainUnhealthyConditions, "drain-unhealthy-conditions", "NotReady,MemoryPressure,DiskPressure,PIDPressure", "비정상으로 판단할 노드 condition 목록(콤마 구분, 예: \"NotReady,KernelDeadlock\")")
+	drainCmd.Flags().StringVar(&drainUnhealthyFlapWindow, "drain-unhealthy-flap-window", "0", "Ready flapping 감지 window (예: 1h, 0이면 비활성)")
+	drainCmd.Flags().IntVar(&drainUnhealthyFlapThreshold, "drain-unhealthy-flap-threshold", 3, "window 내 NodeNotReady 이벤트가 이 값 이상이면 flapping으로 판단")
+	drainCmd.Flags().StringVar(&drainUnhealthyPodEvictionMode, "drain-unhealthy-pod-eviction-mode", "", "비정상 노드 파드 제거 방식 (evict|delete, 빈 값이면 --pod-eviction-mode 사용)")
+	drainCmd.Flags().BoolVar(&drainUnhealthyForce, "drain-unhealthy-force", false, "비정상 노드에서 eviction 실패 시 delete 강제 전환 여부")
+	drainCmd.Flags().IntVar(&drainUnhealthyPodMaxRetries, "drain-unhealthy-pod-max-retries", 0, "비정상 노드 Pod 제거 최대 재시도 횟수 (0이면 --pod-max-retries 사용)")
+	drainCmd.Flags().StringVar(&drainUnhealthyPodDeletionTimeout, "drain-unhealthy-pod-deletion-timeout", "0", "비정상 노드 Pod 삭제 대기 타임아웃 (0이면 --pod-deletion-timeout 사용)")
+	drainCmd.Flags().BoolVar(&drainUnhealthySkipPostEvictionDelay, "drain-unhealthy-skip-post-eviction-delay", false, "비정상 노드 드레인 후 대기 시간 생략 여부")
+
 	drainCmd.Flags().StringVar(&podEvictionMode, "pod-eviction-mode", "evict", "파드 제거 방식 (evict|delete)")
-	drainCmd.Flags().BoolVar(&podForce, "force", false, "eviction 반복 실패/타임아웃 시 delete 강제 전환 여부")
+	drainCmd.Flags().BoolVar(&podForce, "force", false, "eviction 반복 실패/타임아웃 시 delete 강제 전환 여부(PDB 차단은 --force-on-pdb-block 필요)")
+	drainCmd.Flags().BoolVar(&podForceOnPDBBlock, "force-on-pdb-block", false, "--force일 때 PDB 차단(429)도 마지막 재시도에서 delete로 강제 전환(PDB 무시)")
 	drainCmd.Flags().BoolVar(&podForceProblemPods, "force-problem-pods", true, "문제 파드를 즉시 delete(grace=0)로 처리할지 여부")
 	drainCmd.Flags().BoolVar(&podPDBToken, "pdb-token", true, "같은 PDB에 매칭되는 파드 동시 처리 제한 여부")
 	drainCmd.Flags().IntVar(&podPDBTokenMaxInFlight, "pdb-token-max-in-flight", 1, "같은 PDB 토큰 동시 처리 개수")
@@ -148,5 +439,43 @@ func init() {
 	drainCmd.Flags().IntVar(&podMaxRetries, "pod-max-retries", 3, "Pod 제거 최대 재시도 횟수")
 	drainCmd.Flags().StringVar(&podRetryBackoff, "pod-retry-backoff", "10s", "Pod 제거 재시도 간격")
 	drainCmd.Flags().StringVar(&podDeletionTimeout, "pod-deletion-timeout", "2m", "Pod 삭제 대기 타임아웃")
-	drainCmd.Flags().StringVar(&podCheckInterval, "pod-check-interval", "20s", "Pod 삭제 상태 확인 주기")
+	drainCmd.Flags().StringVar(&podCheckInterval, "pod-check-interval", "20s", "Pod 삭제 상태 확인 주기(삭제는 watch로 바로 감지하며, watch를 쓸 수 없을 때의 폴링 주기)")
+	drainCmd.Flags().StringVar(&podGracePeriodMin, "pod-grace-period-min", "0s", "파드 terminationGracePeriodSeconds 하한(0이면 없음)")
+	drainCmd.Flags().StringVar(&podGracePeriodMax, "pod-grace-period-max", "0s", "grace period 상한(0이면 없음, annotation/namespace override에도 적용)")
+	drainCmd.Flags().StringVar(&podGracePeriodNamespaces, "pod-grace-period-namespaces", "", "namespace별 grace period override (예: batch=10m,ingress=90s)")
+}
+
+// addDrainBudgetFlags는 drain/allocate-rate 커맨드가 함께 쓰는 드레인 예산 플래그를 등록합니다.
+func addDrainBudgetFlags(command *cobra.Command) {
+	command.Flags().IntVar(&drainBudgetMaxNodes, "drain-budget-max-nodes", 0, "여러 실행에 걸쳐 기간 내 nodepool별 최대 드레인 노드 수 (0이면 비활성)")
+	command.Flags().StringVar(&drainBudgetWindow, "drain-budget-window", "24h", "드레인 예산 기간 (예: 24h, 168h)")
+	command.Flags().StringVar(&drainBudgetNamespace, "drain-budget-namespace", "kube-system", "드레인 이력 ConfigMap 네임스페이스")
+	command.Flags().StringVar(&drainBudgetConfigMap, "drain-budget-configmap", "node-drain-history", "드레인 이력 ConfigMap 이름")
+}
+
+func setDrainBudgetEnv() {
+	_ = os.Setenv("DRAIN_BUDGET_MAX_NODES", strconv.Itoa(drainBudgetMaxNodes))
+	_ = os.Setenv("DRAIN_BUDGET_WINDOW", drainBudgetWindow)
+	_ = os.Setenv("DRAIN_BUDGET_NAMESPACE", drainBudgetNamespace)
+	_ = os.Setenv("DRAIN_BUDGET_CONFIGMAP", drainBudgetConfigMap)
+}
+
+// addAllocateRateWindowFlags는 drain/allocate-rate 커맨드가 함께 쓰는 기간 집계 allocate rate 플래그를 등록합니다.
+func addAllocateRateWindowFlags(command *cobra.Command) {
+	command.Flags().StringVar(&allocateRateWindow, "allocate-rate-window", "0", "allocate rate를 최근 이 기간의 집계값으로 계산 (예: 1h, 0이면 순간값)")
+	command.Flags().StringVar(&allocateRateAggregation, "allocate-rate-aggregation", "max", "기간 집계 방식 (max|p95|avg)")
+}
+
+func setAllocateRateWindowEnv() {
+	_ = os.Setenv("ALLOCATE_RATE_WINDOW", allocateRateWindow)
+	_ = os.Setenv("ALLOCATE_RATE_AGGREGATION", allocateRateAggregation)
+}
+
+// addAllocateResourcesFlags는 drain/allocate-rate 커맨드가 함께 쓰는 사용률 리소스 플래그를 등록합니다.
+func addAllocateResourcesFlags(command *cobra.Command) {
+	command.Flags().StringVar(&drainAllocateResources, "drain-allocate-resources", "memory,cpu", "사용률 계산 리소스 목록(name[:weight[:threshold]]). 예: memory,cpu,nvidia.com/gpu:1:90 — 가중치 적용 사용률이 가장 큰 리소스가 드레인 수를 결정")
+}
+
+func setAllocateResourcesEnv() {
+	_ = os.Setenv("DRAIN_ALLOCATE_RESOURCES", drainAllocateResources)
 }
@@ -31,11 +31,97 @@ func TestDrainCommandReturnsKubeClientError(t *testing.T) {
 	drainStepRules = ""
 	drainSafetyMaxAllocateRate = 0
 	drainSafetyQueries = ""
+	drainSafetyChecks = "[{name: pending, query: sum(kube_pod_status_phase{phase='Pending'}), comparator: '>', threshold: 5}]"
+	drainSafetyChecksFile = "/etc/drain/safety-checks.yaml"
 	drainSafetyFailClosed = true
 	drainProgressive = true
 
+	drainUnhealthy = false
+	drainUnhealthyConditions = "NotReady,MemoryPressure,DiskPressure,PIDPressure"
+	drainUnhealthyFlapWindow = "0"
+	drainUnhealthyFlapThreshold = 3
+	drainUnhealthyPodEvictionMode = ""
+	drainUnhealthyForce = false
+	drainUnhealthyPodMaxRetries = 0
+	drainUnhealthyPodDeletionTimeout = "0"
+	drainUnhealthySkipPostEvictionDelay = false
+
+	drainMaxPerZone = 0
+	drainMaxFractionPerZone = 0
+	drainZoneInterleave = true
+
+	drainCapacityTypeOrder = ""
+	drainMaxSpot = 0
+	drainMaxOnDemand = 0
+	drainProtectOnDemandWhenSpotUnstable = false
+	drainSpotUnstableWindow = "1h"
+	drainSpotUnstableThreshold = 1
+
+	nodeSelector = ""
+	excludeNodeSelector = ""
+	instanceTypes = ""
+	excludeNodes = ""
+	nodeNames = ""
+
+	drainScoreWeights = "age=1"
+	drainScorePrices = ""
+
+	drainTargetAllocateRate = 80
+
+	drainFeasibilityCheck = false
+
+	drainAllocateResources = "memory,cpu"
+
+	drainBudgetMaxNodes = 0
+	drainBudgetWindow = "24h"
+	drainBudgetNamespace = "kube-system"
+	drainBudgetConfigMap = "node-drain-history"
+
+	drainHonorKarpenterBudgets = false
+	drainKarpenterBudgetReasons = ""
+
+	allocateRateWindow = "0"
+	allocateRateAggregation = "max"
+	drainHysteresisThreshold = 0
+	drainHysteresisWindow = "1h"
+
+	drainForecastHorizon = "2h"
+	drainForecastPeriod = "168h"
+	drainForecastLookback = 4
+
+	drainSafetyMaxPendingPods = 5
+	drainSafetyMaxUnavailableWorkloads = 2
+	drainSafetyMaxNotReadyNodes = 1
+	drainSafetyMaxFailedSchedulingEvents = 3
+	drainSafetyFailedSchedulingWindow = "15m"
+
+	drainWaitWorkloadRecovery = true
+	drainWorkloadRecoveryTimeout = "5m"
+	drainWorkloadRecoveryInterval = "5s"
+	drainWorkloadRecoveryFailPolicy = "continue"
+	drainNodeConcurrency = 3
+	drainCanary = true
+	drainCanarySoak = "2m"
+	drainCanaryCheckInterval = "15s"
+	drainSurge = true
+	drainSurgeNamespace = "surge"
+	drainSurgePriorityClass = "low"
+	drainSurgeImage = "pause"
+	drainSurgeTimeout = "3m"
+	drainSurgeInterval = "5s"
+	drainBreakerMaxEvictionFailureRate = 30
+	drainBreakerMinEvictions = 5
+	drainBreakerMaxForceDeletes = 2
+	drainBreakerMaxFailedNodes = 1
+	drainAdaptivePacing = true
+	drainPacingMinDelay = "5s"
+	drainPacingMaxDelay = "2m"
+	drainPacingInterval = "3s"
+	drainPacingMaxPendingPods = 2
+
 	podEvictionMode = "evict"
 	podForce = false
+	podForceOnPDBBlock = true
 	podForceProblemPods = true
 	podPDBToken = true
 	podPDBTokenMaxInFlight = 1
@@ -44,6 +130,9 @@ func TestDrainCommandReturnsKubeClientError(t *testing.T) {
 	podRetryBackoff = "10s"
 	podDeletionTimeout = "2m"
 	podCheckInterval = "20s"
+	podGracePeriodMin = "10s"
+	podGracePeriodMax = "5m"
+	podGracePeriodNamespaces = "batch=10m"
 
 	err := drainCmd.RunE(drainCmd, nil)
 	if err == nil {
@@ -73,10 +162,82 @@ func restoreCommandEnv(t *testing.T) {
 		"DRAIN_STEP_RULES",
 		"DRAIN_SAFETY_MAX_ALLOCATE_RATE",
 		"DRAIN_SAFETY_QUERIES",
+		"DRAIN_SAFETY_CHECKS",
+		"DRAIN_SAFETY_CHECKS_FILE",
 		"DRAIN_SAFETY_FAIL_CLOSED",
 		"DRAIN_PROGRESSIVE",
+		"DRAIN_UNHEALTHY",
+		"DRAIN_UNHEALTHY_CONDITIONS",
+		"DRAIN_UNHEALTHY_FLAP_WINDOW",
+		"DRAIN_UNHEALTHY_FLAP_THRESHOLD",
+		"DRAIN_UNHEALTHY_POD_EVICTION_MODE",
+		"DRAIN_UNHEALTHY_FORCE",
+		"DRAIN_UNHEALTHY_POD_MAX_RETRIES",
+		"DRAIN_UNHEALTHY_POD_DELETION_TIMEOUT",
+		"DRAIN_UNHEALTHY_SKIP_POST_EVICTION_DELAY",
+		"DRAIN_MAX_PER_ZONE",
+		"DRAIN_MAX_FRACTION_PER_ZONE",
+		"DRAIN_ZONE_INTERLEAVE",
+		"DRAIN_CAPACITY_TYPE_ORDER",
+		"DRAIN_MAX_SPOT",
+		"DRAIN_MAX_ON_DEMAND",
+		"DRAIN_PROTECT_ON_DEMAND_WHEN_SPOT_UNSTABLE",
+		"DRAIN_SPOT_UNSTABLE_WINDOW",
+		"DRAIN_SPOT_UNSTABLE_THRESHOLD",
+		"DRAIN_NODE_SELECTOR",
+		"DRAIN_EXCLUDE_NODE_SELECTOR",
+		"DRAIN_INSTANCE_TYPES",
+		"DRAIN_EXCLUDE_NODES",
+		"DRAIN_NODES",
+		"DRAIN_SCORE_WEIGHTS",
+		"DRAIN_SCORE_PRICES",
+		"DRAIN_TARGET_ALLOCATE_RATE",
+		"DRAIN_FEASIBILITY_CHECK",
+		"DRAIN_ALLOCATE_RESOURCES",
+		"DRAIN_BUDGET_MAX_NODES",
+		"DRAIN_BUDGET_WINDOW",
+		"DRAIN_BUDGET_NAMESPACE",
+		"DRAIN_BUDGET_CONFIGMAP",
+		"DRAIN_HONOR_KARPENTER_BUDGETS",
+		"DRAIN_KARPENTER_BUDGET_REASONS",
+		"ALLOCATE_RATE_WINDOW",
+		"ALLOCATE_RATE_AGGREGATION",
+		"DRAIN_HYSTERESIS_THRESHOLD",
+		"DRAIN_HYSTERESIS_WINDOW",
+		"DRAIN_FORECAST_HORIZON",
+		"DRAIN_FORECAST_PERIOD",
+		"DRAIN_FORECAST_LOOKBACK",
+		"DRAIN_SAFETY_MAX_PENDING_PODS",
+		"DRAIN_SAFETY_MAX_UNAVAILABLE_WORKLOADS",
+		"DRAIN_SAFETY_MAX_NOT_READY_NODES",
+		"DRAIN_SAFETY_MAX_FAILED_SCHEDULING_EVENTS",
+		"DRAIN_SAFETY_FAILED_SCHEDULING_WINDOW",
+		"DRAIN_WAIT_WORKLOAD_RECOVERY",
+		"DRAIN_WORKLOAD_RECOVERY_TIMEOUT",
+		"DRAIN_WORKLOAD_RECOVERY_INTERVAL",
+		"DRAIN_WORKLOAD_RECOVERY_FAIL_POLICY",
+		"DRAIN_NODE_CONCURRENCY",
+		"DRAIN_CANARY",
+		"DRAIN_CANARY_SOAK",
+		"DRAIN_CANARY_CHECK_INTERVAL",
+		"DRAIN_SURGE",
+		"DRAIN_SURGE_NAMESPACE",
+		"DRAIN_SURGE_PRIORITY_CLASS",
+		"DRAIN_SURGE_IMAGE",
+		"DRAIN_SURGE_TIMEOUT",
+		"DRAIN_SURGE_INTERVAL",
+		"DRAIN_BREAKER_MAX_EVICTION_FAILURE_RATE",
+		"DRAIN_BREAKER_MIN_EVICTIONS",
+		"DRAIN_BREAKER_MAX_FORCE_DELETES",
+		"DRAIN_BREAKER_MAX_FAILED_NODES",
+		"DRAIN_ADAPTIVE_PACING",
+		"DRAIN_PACING_MIN_DELAY",
+		"DRAIN_PACING_MAX_DELAY",
+		"DRAIN_PACING_INTERVAL",
+		"DRAIN_PACING_MAX_PENDING_PODS",
 		"POD_EVICTION_MODE",
 		"POD_FORCE",
+		"POD_FORCE_ON_PDB_BLOCK",
 		"POD_FORCE_PROBLEM_PODS",
 		"POD_PDB_TOKEN",
 		"POD_PDB_TOKEN_MAX_IN_FLIGHT",
@@ -85,6 +246,9 @@ func restoreCommandEnv(t *testing.T) {
 		"POD_RETRY_BACKOFF",
 		"POD_DELETION_TIMEOUT",
 		"POD_CHECK_INTERVAL",
+		"POD_GRACE_PERIOD_MIN",
+		"POD_GRACE_PERIOD_MAX",
+		"POD_GRACE_PERIOD_NAMESPACES",
 	}
 	for _, key := range keys {
 		t.Setenv(key, os.Getenv(key))
@@ -108,11 +272,97 @@ func snapshotCommandGlobals() func() {
 	origDrainStepRules := drainStepRules
 	origDrainSafetyMaxAllocateRate := drainSafetyMaxAllocateRate
 	origDrainSafetyQueries := drainSafetyQueries
+	origDrainSafetyChecks := drainSafetyChecks
+	origDrainSafetyChecksFile := drainSafetyChecksFile
 	origDrainSafetyFailClosed := drainSafetyFailClosed
 	origDrainProgressive := drainProgressive
 
+	origDrainUnhealthy := drainUnhealthy
+	origDrainUnhealthyConditions := drainUnhealthyConditions
+	origDrainUnhealthyFlapWindow := drainUnhealthyFlapWindow
+	origDrainUnhealthyFlapThreshold := drainUnhealthyFlapThreshold
+	origDrainUnhealthyPodEvictionMode := drainUnhealthyPodEvictionMode
+	origDrainUnhealthyForce := drainUnhealthyForce
+	origDrainUnhealthyPodMaxRetries := drainUnhealthyPodMaxRetries
+	origDrainUnhealthyPodDeletionTimeout := drainUnhealthyPodDeletionTimeout
+	origDrainUnhealthySkipPostEvictionDelay := drainUnhealthySkipPostEvictionDelay
+
+	origDrainMaxPerZone := drainMaxPerZone
+	origDrainMaxFractionPerZone := drainMaxFractionPerZone
+	origDrainZoneInterleave := drainZoneInterleave
+
+	origDrainCapacityTypeOrder := drainCapacityTypeOrder
+	origDrainMaxSpot := drainMaxSpot
+	origDrainMaxOnDemand := drainMaxOnDemand
+	origDrainProtectOnDemandWhenSpotUnstable := drainProtectOnDemandWhenSpotUnstable
+	origDrainSpotUnstableWindow := drainSpotUnstableWindow
+	origDrainSpotUnstableThreshold := drainSpotUnstableThreshold
+
+	origNodeSelector := nodeSelector
+	origExcludeNodeSelector := excludeNodeSelector
+	origInstanceTypes := instanceTypes
+	origExcludeNodes := excludeNodes
+	origNodeNames := nodeNames
+
+	origDrainScoreWeights := drainScoreWeights
+	origDrainScorePrices := drainScorePrices
+
+	origDrainTargetAllocateRate := drainTargetAllocateRate
+
+	origDrainFeasibilityCheck := drainFeasibilityCheck
+
+	origDrainAllocateResources := drainAllocateResources
+
+	origDrainBudgetMaxNodes := drainBudgetMaxNodes
+	origDrainBudgetWindow := drainBudgetWindow
+	origDrainBudgetNamespace := drainBudgetNamespace
+	origDrainBudgetConfigMap := drainBudgetConfigMap
+
+	origDrainHonorKarpenterBudgets := drainHonorKarpenterBudgets
+	origDrainKarpenterBudgetReasons := drainKarpenterBudgetReasons
+
+	origAllocateRateWindow := allocateRateWindow
+	origAllocateRateAggregation := allocateRateAggregation
+	origDrainHysteresisThreshold := drainHysteresisThreshold
+	origDrainHysteresisWindow := drainHysteresisWindow
+
+	origDrainForecastHorizon := drainForecastHorizon
+	origDrainForecastPeriod := drainForecastPeriod
+	origDrainForecastLookback := drainForecastLookback
+
+	origDrainSafetyMaxPendingPods := drainSafetyMaxPendingPods
+	origDrainSafetyMaxUnavailableWorkloads := drainSafetyMaxUnavailableWorkloads
+	origDrainSafetyMaxNotReadyNodes := drainSafetyMaxNotReadyNodes
+	origDrainSafetyMaxFailedSchedulingEvents := drainSafetyMaxFailedSchedulingEvents
+	origDrainSafetyFailedSchedulingWindow := drainSafetyFailedSchedulingWindow
+
+	origDrainWaitWorkloadRecovery := drainWaitWorkloadRecovery
+	origDrainWorkloadRecoveryTimeout := drainWorkloadRecoveryTimeout
+	origDrainWorkloadRecoveryInterval := drainWorkloadRecoveryInterval
+	origDrainWorkloadRecoveryFailPolicy := drainWorkloadRecoveryFailPolicy
+	origDrainNodeConcurrency := drainNodeConcurrency
+	origDrainCanary := drainCanary
+	origDrainCanarySoak := drainCanarySoak
+	origDrainCanaryCheckInterval := drainCanaryCheckInterval
+	origDrainSurge := drainSurge
+	origDrainSurgeNamespace := drainSurgeNamespace
+	origDrainSurgePriorityClass := drainSurgePriorityClass
+	origDrainSurgeImage := drainSurgeImage
+	origDrainSurgeTimeout := drainSurgeTimeout
+	origDrainSurgeInterval := drainSurgeInterval
+	origDrainBreakerMaxEvictionFailureRate := drainBreakerMaxEvictionFailureRate
+	origDrainBreakerMinEvictions := drainBreakerMinEvictions
+	origDrainBreakerMaxForceDeletes := drainBreakerMaxForceDeletes
+	origDrainBreakerMaxFailedNodes := drainBreakerMaxFailedNodes
+	origDrainAdaptivePacing := drainAdaptivePacing
+	origDrainPacingMinDelay := drainPacingMinDelay
+	origDrainPacingMaxDelay := drainPacingMaxDelay
+	origDrainPacingInterval := drainPacingInterval
+	origDrainPacingMaxPendingPods := drainPacingMaxPendingPods
+
 	origPodEvictionMode := podEvictionMode
 	origPodForce := podForce
+	origPodForceOnPDBBlock := podForceOnPDBBlock
 	origPodForceProblemPods := podForceProblemPods
 	origPodPDBToken := podPDBToken
 	origPodPDBTokenMaxInFlight := podPDBTokenMaxInFlight
@@ -121,6 +371,9 @@ func snapshotCommandGlobals() func() {
 	origPodRetryBackoff := podRetryBackoff
 	origPodDeletionTimeout := podDeletionTimeout
 	origPodCheckInterval := podCheckInterval
+	origPodGracePeriodMin := podGracePeriodMin
+	origPodGracePeriodMax := podGracePeriodMax
+	origPodGracePeriodNamespaces := podGracePeriodNamespaces
 
 	return func() {
 		prometheusAddress = origPrometheusAddress
@@ -139,11 +392,97 @@ func snapshotCommandGlobals() func() {
 		drainStepRules = origDrainStepRules
 		drainSafetyMaxAllocateRate = origDrainSafetyMaxAllocateRate
 		drainSafetyQueries = origDrainSafetyQueries
+		drainSafetyChecks = origDrainSafetyChecks
+		drainSafetyChecksFile = origDrainSafetyChecksFile
 		drainSafetyFailClosed = origDrainSafetyFailClosed
 		drainProgressive = origDrainProgressive
 
+		drainUnhealthy = origDrainUnhealthy
+		drainUnhealthyConditions = origDrainUnhealthyConditions
+		drainUnhealthyFlapWindow = origDrainUnhealthyFlapWindow
+		drainUnhealthyFlapThreshold = origDrainUnhealthyFlapThreshold
+		drainUnhealthyPodEvictionMode = origDrainUnhealthyPodEvictionMode
+		drainUnhealthyForce = origDrainUnhealthyForce
+		drainUnhealthyPodMaxRetries = origDrainUnhealthyPodMaxRetries
+		drainUnhealthyPodDeletionTimeout = origDrainUnhealthyPodDeletionTimeout
+		drainUnhealthySkipPostEvictionDelay = origDrainUnhealthySkipPostEvictionDelay
+
+		drainMaxPerZone = origDrainMaxPerZone
+		drainMaxFractionPerZone = origDrainMaxFractionPerZone
+		drainZoneInterleave = origDrainZoneInterleave
+
+		drainCapacityTypeOrder = origDrainCapacityTypeOrder
+		drainMaxSpot = origDrainMaxSpot
+		drainMaxOnDemand = origDrainMaxOnDemand
+		drainProtectOnDemandWhenSpotUnstable = origDrainProtectOnDemandWhenSpotUnstable
+		drainSpotUnstableWindow = origDrainSpotUnstableWindow
+		drainSpotUnstableThreshold = origDrainSpotUnstableThreshold
+
+		nodeSelector = origNodeSelector
+		excludeNodeSelector = origExcludeNodeSelector
+		instanceTypes = origInstanceTypes
+		excludeNodes = origExcludeNodes
+		nodeNames = origNodeNames
+
+		drainScoreWeights = origDrainScoreWeights
+		drainScorePrices = origDrainScorePrices
+
+		drainTargetAllocateRate = origDrainTargetAllocateRate
+
+		drainFeasibilityCheck = origDrainFeasibilityCheck
+
+		drainAllocateResources = origDrainAllocateResources
+
+		drainBudgetMaxNodes = origDrainBudgetMaxNodes
+		drainBudgetWindow = origDrainBudgetWindow
+		drainBudgetNamespace = origDrainBudgetNamespace
+		drainBudgetConfigMap = origDrainBudgetConfigMap
+
+		drainHonorKarpenterBudgets = origDrainHonorKarpenterBudgets
+		drainKarpenterBudgetReasons = origDrainKarpenterBudgetReasons
+
+		allocateRateWindow = origAllocateRateWindow
+		allocateRateAggregation = origAllocateRateAggregation
+		drainHysteresisThreshold = origDrainHysteresisThreshold
+		drainHysteresisWindow = origDrainHysteresisWindow
+
+		drainForecastHorizon = origDrainForecastHorizon
+		drainForecastPeriod = origDrainForecastPeriod
+		drainForecastLookback = origDrainForecastLookback
+
+		drainSafetyMaxPendingPods = origDrainSafetyMaxPendingPods
+		drainSafetyMaxUnavailableWorkloads = origDrainSafetyMaxUnavailableWorkloads
+		drainSafetyMaxNotReadyNodes = origDrainSafetyMaxNotReadyNodes
+		drainSafetyMaxFailedSchedulingEvents = origDrainSafetyMaxFailedSchedulingEvents
+		drainSafetyFailedSchedulingWindow = origDrainSafetyFailedSchedulingWindow
+
+		drainWaitWorkloadRecovery = origDrainWaitWorkloadRecovery
+		drainWorkloadRecoveryTimeout = origDrainWorkloadRecoveryTimeout
+		drainWorkloadRecoveryInterval = origDrainWorkloadRecoveryInterval
+		drainWorkloadRecoveryFailPolicy = origDrainWorkloadRecoveryFailPolicy
+		drainNodeConcurrency = origDrainNodeConcurrency
+		drainCanary = origDrainCanary
+		drainCanarySoak = origDrainCanarySoak
+		drainCanaryCheckInterval = origDrainCanaryCheckInterval
+		drainSurge = origDrainSurge
+		drainSurgeNamespace = origDrainSurgeNamespace
+		drainSurgePriorityClass = origDrainSurgePriorityClass
+		drainSurgeImage = origDrainSurgeImage
+		drainSurgeTimeout = origDrainSurgeTimeout
+		drainSurgeInterval = origDrainSurgeInterval
+		drainBreakerMaxEvictionFailureRate = origDrainBreakerMaxEvictionFailureRate
+		drainBreakerMinEvictions = origDrainBreakerMinEvictions
+		drainBreakerMaxForceDeletes = origDrainBreakerMaxForceDeletes
+		drainBreakerMaxFailedNodes = origDrainBreakerMaxFailedNodes
+		drainAdaptivePacing = origDrainAdaptivePacing
+		drainPacingMinDelay = origDrainPacingMinDelay
+		drainPacingMaxDelay = origDrainPacingMaxDelay
+		drainPacingInterval = origDrainPacingInterval
+		drainPacingMaxPendingPods = origDrainPacingMaxPendingPods
+
 		podEvictionMode = origPodEvictionMode
 		podForce = origPodForce
+		podForceOnPDBBlock = origPodForceOnPDBBlock
 		podForceProblemPods = origPodForceProblemPods
 		podPDBToken = origPodPDBToken
 		podPDBTokenMaxInFlight = origPodPDBTokenMaxInFlight
@@ -152,5 +491,8 @@ func snapshotCommandGlobals() func() {
 		podRetryBackoff = origPodRetryBackoff
 		podDeletionTimeout = origPodDeletionTimeout
 		podCheckInterval = origPodCheckInterval
+		podGracePeriodMin = origPodGracePeriodMin
+		podGracePeriodMax = origPodGracePeriodMax
+		podGracePeriodNamespaces = origPodGracePeriodNamespaces
 	}
 }
@@ -3,6 +3,7 @@ package cmd
 import (
 	"app/config"
 	"app/pkg/karpenter"
+	"app/pkg/node"
 	"context"
 	"fmt"
 	"log/slog"
@@ -25,6 +26,9 @@ var (
 			if ctx == nil {
 				ctx = context.Background()
 			}
+			setAllocateResourcesEnv()
+			setDrainBudgetEnv()
+			setAllocateRateWindowEnv()
 
 			return handleKarpenterAllocateRate(ctx)
 		},
@@ -34,6 +38,12 @@ var (
 func handleKarpenterAllocateRate(ctx context.Context) error {
 	slog.Info("Karpenter Allocate Rate 사용량 조회 커맨드를 실행합니다.")
 
+	resources, err := node.GetAllocateResourcesFromEnv()
+	if err != nil {
+		slog.Error("사용률 리소스 설정 오류", "error", err)
+		return err
+	}
+
 	prometheusClient, err := config.CreatePrometheusClient()
 	if err != nil {
 		slog.Error("Prometheus 클라이언트 생성 실패", "error", err)
@@ -42,26 +52,40 @@ func handleKarpenterAllocateRate(ctx context.Context) error {
 
 	nodepool := os.Getenv("NODEPOOL_NAME")
 	metricsQuerier := karpenter.NewPrometheusQuerier(prometheusClient)
-	karpenterClient := karpenter.NewClient(nodepool, metricsQuerier)
+	karpenterClient := karpenter.NewClient(nodepool, metricsQuerier).WithWindow(karpenter.GetAllocateRateWindowFromEnv())
 
-	memoryAllocateRate, err := karpenterClient.GetAllocateRate(ctx, "memory")
-	if err != nil {
-		slog.Error("Karpenter memory allocate rate 조회 실패", "error", err)
-		return fmt.Errorf("Karpenter memory allocate rate 조회 실패: %w", err)
+	for _, r := range resources {
+		rate, err := karpenterClient.GetAllocateRate(ctx, r.Name)
+		if err != nil {
+			slog.Error("Karpenter allocate rate 조회 실패", "resource", r.Name, "error", err)
+			return fmt.Errorf("Karpenter %s allocate rate 조회 실패: %w", r.Name, err)
+		}
+		slog.Info("Karpenter", "resource", r.Name, "allocateRate", fmt.Sprintf("%d %%", rate))
 	}
 
-	cpuAllocateRate, err := karpenterClient.GetAllocateRate(ctx, "cpu")
+	budgetOpts := node.GetRollingBudgetOptionsFromEnv()
+	if !budgetOpts.Enabled() {
+		return nil
+	}
+	clientSet, err := config.GetKubeClientSet(os.Getenv("KUBE_CONFIG"), os.Getenv("KUBECONFIG"))
 	if err != nil {
-		slog.Error("Karpenter cpu allocate rate 조회 실패", "error", err)
-		return fmt.Errorf("Karpenter cpu allocate rate 조회 실패: %w", err)
+		slog.Error("쿠버네티스 클라이언트 생성 실패", "error", err)
+		return fmt.Errorf("쿠버네티스 클라이언트 생성 실패: %w", err)
 	}
-
-	slog.Info("Karpenter", "memoryAllocateRate", fmt.Sprintf("%d %%", memoryAllocateRate))
-	slog.Info("Karpenter", "cpuAllocateRate", fmt.Sprintf("%d %%", cpuAllocateRate))
+	budget, err := node.GetRollingBudgetStatus(ctx, clientSet, nodepool, budgetOpts)
+	if err != nil {
+		slog.Error("드레인 예산 조회 실패", "error", err)
+		return fmt.Errorf("드레인 예산 조회 실패: %w", err)
+	}
+	slog.Info("Karpenter", "drainBudget", fmt.Sprintf("%d/%d (최근 %s)", budget.DrainedInWindow, budget.MaxNodes, budget.Window), "drainBudgetRemaining", budget.Remaining)
 	return nil
 }
 
 func init() {
 	rootCmd.AddCommand(karpenterCmd)
 	karpenterCmd.AddCommand(allocateRateCmd)
+
+	addAllocateResourcesFlags(allocateRateCmd)
+	addDrainBudgetFlags(allocateRateCmd)
+	addAllocateRateWindowFlags(allocateRateCmd)
 }
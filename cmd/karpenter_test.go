@@ -19,3 +19,17 @@ func TestHandleKarpenterAllocateRateReturnsPrometheusClientError(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
+
+func TestHandleKarpenterAllocateRateRejectsInvalidResources(t *testing.T) {
+	t.Setenv("PROMETHEUS_ADDRESS", "http://localhost:8080/prometheus")
+	t.Setenv("NODEPOOL_NAME", "test-nodepool")
+	t.Setenv("DRAIN_ALLOCATE_RESOURCES", "memory,nvidia.com/gpu:x")
+
+	err := handleKarpenterAllocateRate(context.Background())
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if !strings.Contains(err.Error(), "DRAIN_ALLOCATE_RESOURCES") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	clientCmd "k8s.io/client-go/tools/clientcmd"
@@ -13,21 +14,31 @@ import (
 
 // GetKubeClientSet returns a Kubernetes clientset by configuration mode.
 func GetKubeClientSet(kubeConfigMode string, kubeConfigPath string) (kubernetes.Interface, error) {
+	config, err := getRestConfig(kubeConfigMode, kubeConfigPath)
+	if err != nil {
+		return nil, err
+	}
+	return getClientSet(config)
+}
+
+// GetKubeDynamicClient returns a dynamic client by configuration mode.
+// Karpenter CRD(NodeClaim 등)처럼 타입이 없는 리소스를 조회할 때 사용합니다.
+func GetKubeDynamicClient(kubeConfigMode string, kubeConfigPath string) (dynamic.Interface, error) {
+	config, err := getRestConfig(kubeConfigMode, kubeConfigPath)
+	if err != nil {
+		return nil, err
+	}
+	return dynamic.NewForConfig(config)
+}
+
+func getRestConfig(kubeConfigMode string, kubeConfigPath string) (*rest.Config, error) {
 	switch {
 	case kubeConfigMode == "github_action", kubeConfigMode == "local":
 		configPath := resolveKubeConfigPath(kubeConfigPath)
-		config, err := clientCmd.BuildConfigFromFlags("", configPath)
-		if err != nil {
-			return nil, err
-		}
-		return getClientSet(config)
+		return clientCmd.BuildConfigFromFlags("", configPath)
 	case kubeConfigMode == "cluster":
 		// 클러스터 내부에서 config 를 가져올 때 사용
-		config, err := rest.InClusterConfig()
-		if err != nil {
-			return nil, err
-		}
-		return getClientSet(config)
+		return rest.InClusterConfig()
 	default:
 		return nil, fmt.Errorf("invalid kube config mode: %s", kubeConfigMode)
 	}
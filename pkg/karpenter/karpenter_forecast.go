@@ -0,0 +1,90 @@
+package karpenter
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"strconv"
+	"time"
+
+	prometheusModel "github.com/prometheus/common/model"
+)
+
+// ForecastSample은 과거 같은 시간대(offset 전)의 request와 그 이후 horizon 동안의 최대 request입니다.
+type ForecastSample struct {
+	Offset      time.Duration `json:"offset"`
+	Request     float64       `json:"request"`
+	PeakRequest float64       `json:"peak_request"`
+}
+
+// Growth는 그 시점 대비 horizon 내 최대 request 증가 배율입니다.
+func (s ForecastSample) Growth() float64 {
+	if s.Request <= 0 {
+		return 1
+	}
+	return s.PeakRequest / s.Request
+}
+
+// RequestForecast는 과거 같은 시간대 이력으로 추정한 향후 horizon 동안의 request 증가 배율입니다.
+type RequestForecast struct {
+	Horizon    time.Duration    `json:"horizon"`
+	Period     time.Duration    `json:"period"`
+	Samples    []ForecastSample `json:"samples"`
+	PeakGrowth float64          `json:"peak_growth"` // 샘플 중 가장 큰 증가 배율 (최소 1)
+}
+
+// GetRequestForecast는 period 간격(예: 24h, 168h)으로 lookback 번 과거로 거슬러 올라가,
+// 그 시점의 request와 이후 horizon 동안의 최대 request를 조회해 증가 배율을 계산합니다.
+// 데이터가 없는 시점은 건너뛰며, 유효한 샘플이 없으면 PeakGrowth는 1(현재 사용률 유지)입니다.
+func (c *Client) GetRequestForecast(ctx context.Context, resourceType string, horizon time.Duration, period time.Duration, lookback int) (RequestForecast, error) {
+	forecast := RequestForecast{Horizon: horizon, Period: period, PeakGrowth: 1}
+	if horizon <= 0 || period <= horizon || lookback <= 0 {
+		return forecast, fmt.Errorf("invalid forecast parameters: horizon=%s period=%s lookback=%d", horizon, period, lookback)
+	}
+	step := c.window.Step
+	if step <= 0 {
+		step = defaultAllocateRateStep
+	}
+
+	requestExpr := c.podRequestExpr(resourceType)
+	for k := 1; k <= lookback; k++ {
+		offset := time.Duration(k) * period
+		request, err := c.queryScalar(ctx, fmt.Sprintf("%s offset %s", requestExpr, prometheusModel.Duration(offset)))
+		if err != nil {
+			return forecast, err
+		}
+		// max_over_time(X[horizon:]) 은 평가 시각 이전 horizon 동안의 최대값이므로, offset-horizon 시점에 평가합니다.
+		peak, err := c.queryScalar(ctx, fmt.Sprintf("max_over_time(%s[%s:%s] offset %s)",
+			requestExpr, prometheusModel.Duration(horizon), prometheusModel.Duration(step), prometheusModel.Duration(offset-horizon)))
+		if err != nil {
+			return forecast, err
+		}
+		if math.IsNaN(request) || math.IsNaN(peak) || request <= 0 {
+			slog.Info("forecast 샘플 없음(건너뜀)", "resourceType", resourceType, "offset", offset.String())
+			continue
+		}
+
+		sample := ForecastSample{Offset: offset, Request: request, PeakRequest: peak}
+		forecast.Samples = append(forecast.Samples, sample)
+		forecast.PeakGrowth = math.Max(forecast.PeakGrowth, sample.Growth())
+	}
+	return forecast, nil
+}
+
+// queryScalar는 단일 값 쿼리 결과를 반환합니다. 결과가 비어 있으면 NaN 입니다.
+func (c *Client) queryScalar(ctx context.Context, query string) (float64, error) {
+	slog.Info("query", "query", query)
+	result, err := c.querier.Query(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("query forecast: %w", err)
+	}
+	if len(result) == 0 {
+		return math.NaN(), nil
+	}
+	v, err := strconv.ParseFloat(result[0].Value.String(), 64)
+	if err != nil {
+		return 0, fmt.Errorf("parse forecast value: %w", err)
+	}
+	return v, nil
+}
@@ -0,0 +1,73 @@
+package karpenter
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	prometheusModel "github.com/prometheus/common/model"
+)
+
+type funcMetricsQuerier func(query string) prometheusModel.Vector
+
+func (f funcMetricsQuerier) Query(ctx context.Context, query string) (prometheusModel.Vector, error) {
+	return f(query), nil
+}
+
+func TestGetRequestForecast(t *testing.T) {
+	var queries []string
+	querier := funcMetricsQuerier(func(query string) prometheusModel.Vector {
+		queries = append(queries, query)
+		switch {
+		// 2일 전 이력은 없음
+		case strings.HasSuffix(query, "offset 2d") || strings.HasSuffix(query, "offset 1d20h)"):
+			return prometheusModel.Vector{}
+		case strings.HasPrefix(query, "max_over_time(") && strings.HasSuffix(query, "offset 20h)"):
+			return vectorOf(150)
+		case strings.HasPrefix(query, "max_over_time("):
+			return vectorOf(120)
+		default:
+			return vectorOf(100)
+		}
+	})
+	client := NewClient("nodepool-a", querier)
+
+	forecast, err := client.GetRequestForecast(context.Background(), "cpu", 4*time.Hour, 24*time.Hour, 3)
+	if err != nil {
+		t.Fatalf("GetRequestForecast() error = %v", err)
+	}
+	if len(queries) != 6 {
+		t.Fatalf("query count = %d, want=6", len(queries))
+	}
+	if !strings.Contains(queries[1], "[4h:1m] offset 20h)") {
+		t.Fatalf("unexpected peak query: %s", queries[1])
+	}
+	if len(forecast.Samples) != 2 {
+		t.Fatalf("samples = %d, want=2", len(forecast.Samples))
+	}
+	if forecast.Samples[1].Offset != 72*time.Hour {
+		t.Fatalf("second sample offset = %s, want=72h", forecast.Samples[1].Offset)
+	}
+	if forecast.PeakGrowth != 1.5 {
+		t.Fatalf("PeakGrowth = %v, want=1.5", forecast.PeakGrowth)
+	}
+}
+
+func TestGetRequestForecastWithoutHistory(t *testing.T) {
+	client := NewClient("nodepool-a", funcMetricsQuerier(func(query string) prometheusModel.Vector {
+		return prometheusModel.Vector{}
+	}))
+
+	forecast, err := client.GetRequestForecast(context.Background(), "memory", time.Hour, 24*time.Hour, 2)
+	if err != nil {
+		t.Fatalf("GetRequestForecast() error = %v", err)
+	}
+	if len(forecast.Samples) != 0 || forecast.PeakGrowth != 1 {
+		t.Fatalf("unexpected forecast: %+v", forecast)
+	}
+
+	if _, err := client.GetRequestForecast(context.Background(), "memory", 24*time.Hour, 24*time.Hour, 2); err == nil {
+		t.Fatalf("horizon >= period 이면 오류여야 합니다")
+	}
+}
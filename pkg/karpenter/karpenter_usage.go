@@ -7,6 +7,7 @@ import (
 	"log/slog"
 	"math"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/prometheus/client_golang/api"
@@ -69,6 +70,7 @@ func (p *PrometheusQuerier) Query(ctx context.Context, query string) (prometheus
 type Client struct {
 	nodepoolName string
 	querier      MetricsQuerier
+	window       AllocateRateWindow
 }
 
 // NewClient creates a Karpenter metrics client.
@@ -81,13 +83,7 @@ func NewClient(nodepoolName string, querier MetricsQuerier) *Client {
 
 // GetKarpenterPodRequest returns pod request usage for a resource type.
 func (c *Client) GetKarpenterPodRequest(ctx context.Context, resourceType string) (float64, error) {
-	query := fmt.Sprintf(
-		"sum(karpenter_nodes_total_pod_requests{nodepool='%s',resource_type='%s'} + karpenter_nodes_total_daemon_requests{nodepool='%s',resource_type='%s'})",
-		c.nodepoolName,
-		resourceType,
-		c.nodepoolName,
-		resourceType,
-	)
+	query := c.podRequestExpr(resourceType)
 	slog.Info("query", "query", query)
 
 	result, err := c.querier.Query(ctx, query)
@@ -97,6 +93,17 @@ func (c *Client) GetKarpenterPodRequest(ctx context.Context, resourceType string
 	return parseUsageResult(result, resourceType)
 }
 
+// podRequestExpr는 nodepool의 파드+데몬셋 request 합계 PromQL 식을 만듭니다.
+func (c *Client) podRequestExpr(resourceType string) string {
+	return fmt.Sprintf(
+		"sum(karpenter_nodes_total_pod_requests{nodepool='%s',resource_type='%s'} + karpenter_nodes_total_daemon_requests{nodepool='%s',resource_type='%s'})",
+		c.nodepoolName,
+		resourceType,
+		c.nodepoolName,
+		resourceType,
+	)
+}
+
 // GetKarpenterNodepoolUsage returns nodepool usage for a resource type.
 func (c *Client) GetKarpenterNodepoolUsage(ctx context.Context, resourceType string) (float64, error) {
 	var emptyErr error
@@ -127,7 +134,12 @@ func (c *Client) GetKarpenterNodepoolUsage(ctx context.Context, resourceType str
 }
 
 // GetAllocateRate returns pod-request to nodepool-usage ratio in percent.
+// When a window is configured, the ratio is aggregated over the window instead of the instant value.
 func (c *Client) GetAllocateRate(ctx context.Context, resourceType string) (int, error) {
+	if c.window.Window > 0 {
+		return c.GetAllocateRateOverWindow(ctx, resourceType, c.window.Window, c.window.Aggregation)
+	}
+
 	nodepoolUsage, err := c.GetKarpenterNodepoolUsage(ctx, resourceType)
 	if err != nil {
 		return 0, err
@@ -141,42 +153,52 @@ func (c *Client) GetAllocateRate(ctx context.Context, resourceType string) (int,
 		return 0, err
 	}
 
+	unit := resourceUnit(resourceType)
+	slog.Info("Karpenter", "resourceType", resourceType, "nodepoolUsage", fmt.Sprintf("%.0f %s", nodepoolUsage, unit))
+	slog.Info("Karpenter", "resourceType", resourceType, "podRequest", fmt.Sprintf("%.0f %s", podRequest, unit))
+
+	allocateRate := math.Round((podRequest / nodepoolUsage) * 100)
+	return int(allocateRate), nil
+}
+
+// IsSupportedResourceType은 allocate rate를 계산할 수 있는 리소스 타입인지 반환합니다.
+// cpu, memory, ephemeral-storage, pods 와 확장 리소스(예: nvidia.com/gpu)를 지원합니다.
+func IsSupportedResourceType(resourceType string) bool {
+	switch resourceType {
+	case "cpu", "memory", "ephemeral-storage", "pods":
+		return true
+	}
+	return strings.Contains(resourceType, "/")
+}
+
+func resourceUnit(resourceType string) string {
 	switch resourceType {
-	case "memory":
-		slog.Info("Karpenter", "nodepoolUsage", fmt.Sprintf("%.0f GB", nodepoolUsage))
-		slog.Info("Karpenter", "podRequest", fmt.Sprintf("%.0f GB", podRequest))
 	case "cpu":
-		slog.Info("Karpenter", "nodepoolUsage", fmt.Sprintf("%.0f vCPU", nodepoolUsage))
-		slog.Info("Karpenter", "podRequest", fmt.Sprintf("%.0f vCPU", podRequest))
+		return "vCPU"
+	case "memory", "ephemeral-storage":
+		return "GB"
 	default:
-		return 0, fmt.Errorf("unsupported resource type: %s", resourceType)
+		return "개"
 	}
-
-	allocateRate := math.Round((podRequest / nodepoolUsage) * 100)
-	return int(allocateRate), nil
 }
 
 func parseUsageResult(result prometheusModel.Vector, resourceType string) (float64, error) {
 	if len(result) == 0 {
 		return 0, fmt.Errorf("empty prometheus result for resource type %s", resourceType)
 	}
+	if !IsSupportedResourceType(resourceType) {
+		return 0, fmt.Errorf("unsupported resource type: %s", resourceType)
+	}
 
-	sample := result[0]
+	usage, err := strconv.ParseFloat(result[0].Value.String(), 64)
+	if err != nil {
+		return 0, fmt.Errorf("parse %s usage: %w", resourceType, err)
+	}
 	switch resourceType {
-	case "memory":
-		usage, err := strconv.ParseFloat(sample.Value.String(), 64)
-		if err != nil {
-			return 0, fmt.Errorf("parse memory usage: %w", err)
-		}
+	case "memory", "ephemeral-storage":
 		return usage / (1000 * 1000 * 1000), nil
-	case "cpu":
-		usage, err := strconv.ParseFloat(sample.Value.String(), 64)
-		if err != nil {
-			return 0, fmt.Errorf("parse cpu usage: %w", err)
-		}
-		return usage, nil
 	default:
-		return 0, fmt.Errorf("unsupported resource type: %s", resourceType)
+		return usage, nil
 	}
 }
 
@@ -23,6 +23,12 @@ func (f fakeMetricsQuerier) Query(ctx context.Context, query string) (prometheus
 	if strings.Contains(query, "resource_type='cpu'") {
 		resourceType = "cpu"
 	}
+	if strings.Contains(query, "resource_type='nvidia.com/gpu'") {
+		resourceType = "nvidia.com/gpu"
+	}
+	if strings.Contains(query, "resource_type='ephemeral-storage'") {
+		resourceType = "ephemeral-storage"
+	}
 
 	for _, metricName := range nodepoolUsageMetricNames {
 		if !strings.Contains(query, metricName) {
@@ -83,6 +89,26 @@ func TestGetAllocateRate(t *testing.T) {
 			wantErr:  false,
 			wantRate: 50,
 		},
+		{
+			name:         "GPU 비율 계산",
+			resourceType: "nvidia.com/gpu",
+			querier: fakeMetricsQuerier{
+				usageByResource:   map[string]float64{"nvidia.com/gpu": 8},
+				requestByResource: map[string]float64{"nvidia.com/gpu": 6},
+			},
+			wantErr:  false,
+			wantRate: 75,
+		},
+		{
+			name:         "ephemeral-storage는 GB 단위로 계산",
+			resourceType: "ephemeral-storage",
+			querier: fakeMetricsQuerier{
+				usageByResource:   map[string]float64{"ephemeral-storage": 400 * 1000 * 1000 * 1000},
+				requestByResource: map[string]float64{"ephemeral-storage": 100 * 1000 * 1000 * 1000},
+			},
+			wantErr:  false,
+			wantRate: 25,
+		},
 		{
 			name:         "분모 0이면 오류 반환",
 			resourceType: "cpu",
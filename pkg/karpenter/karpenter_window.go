@@ -0,0 +1,125 @@
+package karpenter
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	prometheusModel "github.com/prometheus/common/model"
+)
+
+const (
+	AggregationMax = "max"
+	AggregationP95 = "p95"
+	AggregationAvg = "avg"
+
+	defaultAllocateRateStep = time.Minute
+)
+
+// AllocateRateWindow는 allocate rate를 순간값 대신 기간 집계값으로 계산하기 위한 설정입니다.
+type AllocateRateWindow struct {
+	Window      time.Duration // 0 이면 기존처럼 instant query
+	Aggregation string        // max|p95|avg
+	Step        time.Duration // subquery 해상도
+}
+
+// GetAllocateRateWindowFromEnv는 allocate rate 기간 집계 관련 환경 변수를 파싱합니다.
+func GetAllocateRateWindowFromEnv() AllocateRateWindow {
+	w := AllocateRateWindow{
+		Aggregation: AggregationMax,
+		Step:        defaultAllocateRateStep,
+	}
+	if v := strings.TrimSpace(os.Getenv("ALLOCATE_RATE_WINDOW")); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			w.Window = d
+		}
+	}
+	if v := strings.ToLower(strings.TrimSpace(os.Getenv("ALLOCATE_RATE_AGGREGATION"))); v != "" {
+		if IsSupportedAggregation(v) {
+			w.Aggregation = v
+		}
+	}
+	if v := strings.TrimSpace(os.Getenv("ALLOCATE_RATE_STEP")); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			w.Step = d
+		}
+	}
+	return w
+}
+
+// IsSupportedAggregation은 기간 집계 방식이 지원되는지 반환합니다.
+func IsSupportedAggregation(aggregation string) bool {
+	switch aggregation {
+	case AggregationMax, AggregationP95, AggregationAvg:
+		return true
+	}
+	return false
+}
+
+// WithWindow는 GetAllocateRate가 기간 집계값을 사용하도록 설정합니다.
+func (c *Client) WithWindow(window AllocateRateWindow) *Client {
+	c.window = window
+	return c
+}
+
+// GetAllocateRateOverWindow는 최근 window 동안의 (pod request / nodepool usage) 비율을 aggregation으로 집계해 퍼센트로 반환합니다.
+func (c *Client) GetAllocateRateOverWindow(ctx context.Context, resourceType string, window time.Duration, aggregation string) (int, error) {
+	if window <= 0 {
+		return 0, fmt.Errorf("invalid allocate rate window: %s", window)
+	}
+	step := c.window.Step
+	if step <= 0 {
+		step = defaultAllocateRateStep
+	}
+
+	var lastErr error
+	for _, metricName := range nodepoolUsageMetricNames {
+		query, err := windowedAllocateRateQuery(c.allocateRatioExpr(metricName, resourceType), window, step, aggregation)
+		if err != nil {
+			return 0, err
+		}
+		slog.Info("query", "query", query)
+
+		result, err := c.querier.Query(ctx, query)
+		if err != nil {
+			return 0, fmt.Errorf("query windowed allocate rate: %w", err)
+		}
+		if len(result) == 0 {
+			lastErr = fmt.Errorf("empty prometheus result for metric %s resource type %s", metricName, resourceType)
+			continue
+		}
+
+		ratio, err := strconv.ParseFloat(result[0].Value.String(), 64)
+		if err != nil || math.IsNaN(ratio) || math.IsInf(ratio, 0) {
+			return 0, fmt.Errorf("invalid windowed %s allocate rate: %s", resourceType, result[0].Value.String())
+		}
+		rate := int(math.Round(ratio * 100))
+		slog.Info("Karpenter", "resourceType", resourceType, "window", window.String(), "aggregation", aggregation, "allocateRate", rate)
+		return rate, nil
+	}
+	return 0, lastErr
+}
+
+// allocateRatioExpr는 순간 allocate 비율(0~1) PromQL 식을 만듭니다.
+func (c *Client) allocateRatioExpr(usageMetric string, resourceType string) string {
+	return fmt.Sprintf("(%s / sum(%s{nodepool='%s', resource_type='%s'}))", c.podRequestExpr(resourceType), usageMetric, c.nodepoolName, resourceType)
+}
+
+func windowedAllocateRateQuery(ratioExpr string, window time.Duration, step time.Duration, aggregation string) (string, error) {
+	subquery := fmt.Sprintf("%s[%s:%s]", ratioExpr, prometheusModel.Duration(window), prometheusModel.Duration(step))
+	switch aggregation {
+	case AggregationMax:
+		return fmt.Sprintf("max_over_time(%s)", subquery), nil
+	case AggregationP95:
+		return fmt.Sprintf("quantile_over_time(0.95, %s)", subquery), nil
+	case AggregationAvg:
+		return fmt.Sprintf("avg_over_time(%s)", subquery), nil
+	default:
+		return "", fmt.Errorf("unsupported allocate rate aggregation: %s", aggregation)
+	}
+}
@@ -0,0 +1,91 @@
+package karpenter
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	prometheusModel "github.com/prometheus/common/model"
+)
+
+type recordingMetricsQuerier struct {
+	ratio        float64
+	emptyMetrics map[string]bool
+	queries      []string
+}
+
+func (f *recordingMetricsQuerier) Query(ctx context.Context, query string) (prometheusModel.Vector, error) {
+	f.queries = append(f.queries, query)
+	for metricName := range f.emptyMetrics {
+		if strings.Contains(query, metricName+"{") {
+			return prometheusModel.Vector{}, nil
+		}
+	}
+	return vectorOf(f.ratio), nil
+}
+
+func TestGetAllocateRateOverWindow(t *testing.T) {
+	tests := []struct {
+		name        string
+		aggregation string
+		wantPrefix  string
+	}{
+		{name: "max", aggregation: AggregationMax, wantPrefix: "max_over_time("},
+		{name: "p95", aggregation: AggregationP95, wantPrefix: "quantile_over_time(0.95, "},
+		{name: "avg", aggregation: AggregationAvg, wantPrefix: "avg_over_time("},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			querier := &recordingMetricsQuerier{ratio: 0.424}
+			client := NewClient("nodepool-a", querier)
+
+			got, err := client.GetAllocateRateOverWindow(context.Background(), "cpu", time.Hour, tt.aggregation)
+			if err != nil {
+				t.Fatalf("GetAllocateRateOverWindow() error = %v", err)
+			}
+			if got != 42 {
+				t.Fatalf("GetAllocateRateOverWindow() = %d, want=42", got)
+			}
+			query := querier.queries[0]
+			if !strings.HasPrefix(query, tt.wantPrefix) || !strings.HasSuffix(query, "[1h:1m])") {
+				t.Fatalf("unexpected query: %s", query)
+			}
+		})
+	}
+}
+
+func TestGetAllocateRateUsesWindowWhenConfigured(t *testing.T) {
+	querier := &recordingMetricsQuerier{
+		ratio:        0.61,
+		emptyMetrics: map[string]bool{"karpenter_nodepools_usage": true},
+	}
+	client := NewClient("nodepool-a", querier).WithWindow(AllocateRateWindow{
+		Window:      30 * time.Minute,
+		Aggregation: AggregationP95,
+		Step:        30 * time.Second,
+	})
+
+	got, err := client.GetAllocateRate(context.Background(), "memory")
+	if err != nil {
+		t.Fatalf("GetAllocateRate() error = %v", err)
+	}
+	if got != 61 {
+		t.Fatalf("GetAllocateRate() = %d, want=61", got)
+	}
+	if len(querier.queries) != 2 || !strings.Contains(querier.queries[1], "karpenter_nodepool_usage{") {
+		t.Fatalf("legacy usage metric fallback 미사용: %v", querier.queries)
+	}
+	if !strings.Contains(querier.queries[1], "[30m:30s]") {
+		t.Fatalf("unexpected window/step: %s", querier.queries[1])
+	}
+}
+
+func TestGetAllocateRateOverWindowRejectsUnknownAggregation(t *testing.T) {
+	client := NewClient("nodepool-a", &recordingMetricsQuerier{ratio: 0.5})
+	if _, err := client.GetAllocateRateOverWindow(context.Background(), "cpu", time.Hour, "p50"); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
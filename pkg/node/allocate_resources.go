@@ -0,0 +1,188 @@
+package node
+
+import (
+	"app/types"
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AllocateResource는 드레인 노드 수 계산에 사용하는 리소스와 가중치/임계값입니다.
+type AllocateResource struct {
+	Name      string  // karpenter 메트릭 resource_type (예: cpu, memory, nvidia.com/gpu, ephemeral-storage, pods)
+	Weight    float64 // 사용률에 곱하는 가중치 (1 이면 그대로)
+	Threshold int     // 0 이면 비활성. 사용률이 이 값 이상이면 드레인 0대
+}
+
+var defaultAllocateResources = []AllocateResource{
+	{Name: "memory", Weight: 1},
+	{Name: "cpu", Weight: 1},
+}
+
+// GetAllocateResourcesFromEnv는 DRAIN_ALLOCATE_RESOURCES를 파싱합니다. 비어 있으면 memory/cpu입니다.
+// 잘못된 값은 다른 리소스로 대신 판단하지 않도록 오류를 반환합니다.
+func GetAllocateResourcesFromEnv() ([]AllocateResource, error) {
+	v := strings.TrimSpace(os.Getenv("DRAIN_ALLOCATE_RESOURCES"))
+	if v == "" {
+		return append([]AllocateResource(nil), defaultAllocateResources...), nil
+	}
+	resources, err := parseAllocateResources(v)
+	if err != nil {
+		return nil, fmt.Errorf("DRAIN_ALLOCATE_RESOURCES 파싱 실패: %w", err)
+	}
+	return resources, nil
+}
+
+// parseAllocateResources: "memory,cpu,nvidia.com/gpu:1.2:90" (이름[:가중치[:임계값]])
+func parseAllocateResources(s string) ([]AllocateResource, error) {
+	var resources []AllocateResource
+	for _, part := range splitList(s) {
+		fields := strings.Split(part, ":")
+		if len(fields) > 3 {
+			return nil, fmt.Errorf("invalid allocate resource: %q", part)
+		}
+		r := AllocateResource{Name: strings.TrimSpace(fields[0]), Weight: 1}
+		if r.Name == "" {
+			return nil, fmt.Errorf("invalid allocate resource: %q", part)
+		}
+		if len(fields) > 1 && strings.TrimSpace(fields[1]) != "" {
+			w, err := strconv.ParseFloat(strings.TrimSpace(fields[1]), 64)
+			if err != nil || w <= 0 {
+				return nil, fmt.Errorf("invalid weight: %q", part)
+			}
+			r.Weight = w
+		}
+		if len(fields) > 2 && strings.TrimSpace(fields[2]) != "" {
+			thr, err := strconv.Atoi(strings.TrimSpace(fields[2]))
+			if err != nil {
+				return nil, fmt.Errorf("invalid threshold: %q", part)
+			}
+			r.Threshold = thr
+		}
+		resources = append(resources, r)
+	}
+	if len(resources) == 0 {
+		return nil, fmt.Errorf("empty allocate resources")
+	}
+	return resources, nil
+}
+
+// getAllocateRates는 리소스별 allocate rate를 조회합니다.
+// 조회에 실패한 리소스가 있으면 조회된 값과 함께 첫 번째 오류를 반환합니다.
+func getAllocateRates(ctx context.Context, provider allocateRateProvider, resources []AllocateResource) (map[string]int, error) {
+	rates := make(map[string]int, len(resources))
+	var firstErr error
+	for _, r := range resources {
+		rate, err := provider.GetAllocateRate(ctx, r.Name)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("%s allocate rate 조회 실패: %w", r.Name, err)
+			}
+			continue
+		}
+		rates[r.Name] = rate
+	}
+	return rates, firstErr
+}
+
+// allocateRatesNotifier는 설정한 리소스 전체의 사용률 알림을 지원하는 Notifier입니다.
+type allocateRatesNotifier interface {
+	SendKarpenterAllocateRates(ctx context.Context, rates []types.ResourceAllocateRate) error
+}
+
+// notifyAllocateRates는 설정한 리소스 순서대로 사용률을 알립니다.
+// 전체 리소스 알림을 지원하지 않는 Notifier에는 기존처럼 memory/cpu만 보냅니다.
+func notifyAllocateRates(ctx context.Context, deps DrainDependencies, resources []AllocateResource, rates map[string]int) {
+	if deps.Notifier == nil {
+		return
+	}
+	notifier, ok := deps.Notifier.(allocateRatesNotifier)
+	if !ok {
+		if err := deps.Notifier.SendKarpenterAllocateRate(ctx, rates["memory"], rates["cpu"]); err != nil {
+			slog.Error("Karpenter 사용률 알림 전송 실패", "error", err)
+		}
+		return
+	}
+	list := make([]types.ResourceAllocateRate, 0, len(resources))
+	for _, r := range resources {
+		if rate, ok := rates[r.Name]; ok {
+			list = append(list, types.ResourceAllocateRate{Resource: r.Name, AllocateRate: rate})
+		}
+	}
+	if err := notifier.SendKarpenterAllocateRates(ctx, list); err != nil {
+		slog.Error("Karpenter 사용률 알림 전송 실패", "error", err)
+	}
+}
+
+// bottleneckAllocateRate는 가중치를 적용한 사용률이 가장 높은 리소스(병목)와 그 사용률을 반환합니다.
+func bottleneckAllocateRate(rates map[string]int, resources []AllocateResource) (int, string) {
+	maxRate, bottleneck := 0, ""
+	for _, r := range resources {
+		rate, ok := rates[r.Name]
+		if !ok {
+			continue
+		}
+		weighted := int(math.Round(float64(rate) * r.Weight))
+		if bottleneck == "" || weighted > maxRate {
+			maxRate, bottleneck = weighted, r.Name
+		}
+	}
+	return maxRate, bottleneck
+}
+
+// ShouldBlockDrainByResourceThresholds는 리소스별 임계값을 넘은 리소스가 있으면 0대 드레인을 강제합니다.
+func ShouldBlockDrainByResourceThresholds(rates map[string]int, resources []AllocateResource) (bool, string) {
+	for _, r := range resources {
+		rate, ok := rates[r.Name]
+		if !ok || r.Threshold <= 0 {
+			continue
+		}
+		if rate >= r.Threshold {
+			return true, fmt.Sprintf("%s allocateRate(%d) >= threshold(%d)", r.Name, rate, r.Threshold)
+		}
+	}
+	return false, ""
+}
+
+func hasResourceThreshold(resources []AllocateResource) bool {
+	for _, r := range resources {
+		if r.Threshold > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// allocateRateWindowProvider는 기간 집계 allocate rate 조회를 지원하는 provider입니다. (karpenter.Client)
+type allocateRateWindowProvider interface {
+	GetAllocateRateOverWindow(ctx context.Context, resourceType string, window time.Duration, aggregation string) (int, error)
+}
+
+// ShouldBlockDrainByHysteresis는 최근 HysteresisWindow 동안 리소스 사용률(가중치 적용)의 최대값이
+// HysteresisThreshold 미만으로 유지되지 않았으면 드레인을 막습니다. 순간적인 사용률 하락으로 과하게 드레인하는 것을 방지합니다.
+func ShouldBlockDrainByHysteresis(ctx context.Context, provider allocateRateProvider, opts DrainPolicyOptions) (bool, string, error) {
+	if opts.HysteresisThreshold <= 0 {
+		return false, "", nil
+	}
+	windowProvider, ok := provider.(allocateRateWindowProvider)
+	if !ok {
+		return opts.SafetyFailClosed, "hysteresis unsupported by allocate rate provider", fmt.Errorf("allocate rate provider가 기간 집계를 지원하지 않습니다")
+	}
+
+	for _, r := range opts.AllocateResources {
+		rate, err := windowProvider.GetAllocateRateOverWindow(ctx, r.Name, opts.HysteresisWindow, "max")
+		if err != nil {
+			return opts.SafetyFailClosed, fmt.Sprintf("hysteresis query failed: %s", r.Name), err
+		}
+		weighted := int(math.Round(float64(rate) * r.Weight))
+		if weighted >= opts.HysteresisThreshold {
+			return true, fmt.Sprintf("%s max allocateRate over %s (%d) >= hysteresisThreshold(%d)", r.Name, opts.HysteresisWindow, weighted, opts.HysteresisThreshold), nil
+		}
+	}
+	return false, "", nil
+}
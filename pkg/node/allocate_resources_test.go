@@ -0,0 +1,242 @@
+package node
+
+import (
+	"app/types"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestParseAllocateResources(t *testing.T) {
+	resources, err := parseAllocateResources("memory, cpu:1.5, nvidia.com/gpu:1:90, pods::95")
+	assert.NoError(t, err)
+	assert.Equal(t, []AllocateResource{
+		{Name: "memory", Weight: 1},
+		{Name: "cpu", Weight: 1.5},
+		{Name: "nvidia.com/gpu", Weight: 1, Threshold: 90},
+		{Name: "pods", Weight: 1, Threshold: 95},
+	}, resources)
+
+	for _, invalid := range []string{"", "cpu:0", "cpu:x", "cpu:1:x", "cpu:1:2:3", ":1"} {
+		_, err := parseAllocateResources(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestBottleneckAllocateRate(t *testing.T) {
+	resources := []AllocateResource{
+		{Name: "memory", Weight: 1},
+		{Name: "cpu", Weight: 1},
+		{Name: "nvidia.com/gpu", Weight: 1.5},
+	}
+
+	rate, name := bottleneckAllocateRate(map[string]int{"memory": 40, "cpu": 30, "nvidia.com/gpu": 30}, resources)
+	assert.Equal(t, 45, rate)
+	assert.Equal(t, "nvidia.com/gpu", name)
+
+	// 조회되지 않은 리소스는 무시
+	rate, name = bottleneckAllocateRate(map[string]int{"memory": 40, "cpu": 30}, resources)
+	assert.Equal(t, 40, rate)
+	assert.Equal(t, "memory", name)
+}
+
+func TestShouldBlockDrainByResourceThresholds(t *testing.T) {
+	resources := []AllocateResource{
+		{Name: "memory", Weight: 1},
+		{Name: "nvidia.com/gpu", Weight: 1, Threshold: 90},
+	}
+
+	blocked, reason := ShouldBlockDrainByResourceThresholds(map[string]int{"memory": 99, "nvidia.com/gpu": 89}, resources)
+	assert.False(t, blocked)
+	assert.Empty(t, reason)
+
+	blocked, reason = ShouldBlockDrainByResourceThresholds(map[string]int{"memory": 10, "nvidia.com/gpu": 90}, resources)
+	assert.True(t, blocked)
+	assert.Contains(t, reason, "nvidia.com/gpu")
+}
+
+func TestNodeDrainUsesBottleneckResource(t *testing.T) {
+	tests := []struct {
+		name          string
+		resources     string
+		expectedDrain int
+	}{
+		{name: "기본 리소스(memory,cpu)", resources: "memory,cpu", expectedDrain: 2},
+		{name: "GPU가 병목이면 드레인 수 감소", resources: "memory,cpu,nvidia.com/gpu", expectedDrain: 0},
+		{name: "GPU 임계값 초과 시 0대", resources: "memory,cpu,nvidia.com/gpu:0.5:80", expectedDrain: 0},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			setDefaultDrainEnv(t)
+			t.Setenv("DRAIN_ALLOCATE_RESOURCES", tt.resources)
+
+			clientSet := fake.NewSimpleClientset()
+			nodepoolName := "test-nodepool"
+			for i := 1; i <= 4; i++ {
+				if _, err := clientSet.CoreV1().Nodes().Create(context.Background(), newNode(nodepoolName, i), metaV1.CreateOptions{}); err != nil {
+					t.Fatalf("노드 생성 실패: %v", err)
+				}
+			}
+
+			results, err := NodeDrain(context.Background(), clientSet, DrainDependencies{
+				AllocateRateProvider: fakeAllocateRateProvider{
+					rates: map[string]int{"memory": 30, "cpu": 25, "nvidia.com/gpu": 80},
+				},
+				Notifier: fakeNotifier{},
+			}, DrainConfig{
+				NodepoolName: nodepoolName,
+				Eviction:     testEvictionConfig(),
+			})
+			assert.NoError(t, err)
+			assert.Len(t, results, tt.expectedDrain)
+		})
+	}
+}
+
+type fakeAllocateRatesNotifier struct {
+	fakeNotifier
+	sent *[][]types.ResourceAllocateRate
+}
+
+func (f fakeAllocateRatesNotifier) SendKarpenterAllocateRates(ctx context.Context, rates []types.ResourceAllocateRate) error {
+	*f.sent = append(*f.sent, rates)
+	return nil
+}
+
+func TestNodeDrainRejectsInvalidAllocateResources(t *testing.T) {
+	setDefaultDrainEnv(t)
+	t.Setenv("DRAIN_ALLOCATE_RESOURCES", "memory,cpu,nvidia.com/gpu:x")
+
+	clientSet := fake.NewSimpleClientset(newNode("test-nodepool", 1), newNode("test-nodepool", 2))
+	results, err := NodeDrain(context.Background(), clientSet, DrainDependencies{
+		AllocateRateProvider: fakeAllocateRateProvider{rates: map[string]int{"memory": 30, "cpu": 25}},
+		Notifier:             fakeNotifier{},
+	}, DrainConfig{
+		NodepoolName: "test-nodepool",
+		Eviction:     testEvictionConfig(),
+	})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "DRAIN_ALLOCATE_RESOURCES")
+	}
+	assert.Len(t, results, 0)
+	assertNodeUnschedulable(t, clientSet, "node-1", false)
+}
+
+func TestNodeDrainNotifiesConfiguredResources(t *testing.T) {
+	setDefaultDrainEnv(t)
+	t.Setenv("DRAIN_ALLOCATE_RESOURCES", "memory,cpu,nvidia.com/gpu")
+
+	var sent [][]types.ResourceAllocateRate
+	clientSet := fake.NewSimpleClientset(newNode("test-nodepool", 1), newNode("test-nodepool", 2))
+	_, err := NodeDrain(context.Background(), clientSet, DrainDependencies{
+		AllocateRateProvider: fakeAllocateRateProvider{rates: map[string]int{"memory": 30, "cpu": 25, "nvidia.com/gpu": 95}},
+		Notifier:             fakeAllocateRatesNotifier{sent: &sent},
+	}, DrainConfig{
+		NodepoolName: "test-nodepool",
+		Eviction:     testEvictionConfig(),
+	})
+	assert.NoError(t, err)
+	if assert.NotEmpty(t, sent) {
+		assert.Equal(t, []types.ResourceAllocateRate{
+			{Resource: "memory", AllocateRate: 30},
+			{Resource: "cpu", AllocateRate: 25},
+			{Resource: "nvidia.com/gpu", AllocateRate: 95},
+		}, sent[0])
+	}
+}
+
+type fakeWindowAllocateRateProvider struct {
+	fakeAllocateRateProvider
+	windowRates map[string]int
+}
+
+func (f fakeWindowAllocateRateProvider) GetAllocateRateOverWindow(ctx context.Context, resourceType string, window time.Duration, aggregation string) (int, error) {
+	return f.windowRates[resourceType], nil
+}
+
+func TestShouldBlockDrainByHysteresis(t *testing.T) {
+	opts := DrainPolicyOptions{
+		AllocateResources:   defaultAllocateResources,
+		HysteresisThreshold: 60,
+		HysteresisWindow:    time.Hour,
+		SafetyFailClosed:    true,
+	}
+
+	provider := fakeWindowAllocateRateProvider{windowRates: map[string]int{"memory": 55, "cpu": 40}}
+	blocked, _, err := ShouldBlockDrainByHysteresis(context.Background(), provider, opts)
+	assert.NoError(t, err)
+	assert.False(t, blocked)
+
+	provider.windowRates["cpu"] = 60
+	blocked, reason, err := ShouldBlockDrainByHysteresis(context.Background(), provider, opts)
+	assert.NoError(t, err)
+	assert.True(t, blocked)
+	assert.Contains(t, reason, "cpu")
+
+	// 기간 집계를 지원하지 않는 provider는 fail-closed 설정을 따름
+	blocked, _, err = ShouldBlockDrainByHysteresis(context.Background(), fakeAllocateRateProvider{}, opts)
+	assert.Error(t, err)
+	assert.True(t, blocked)
+}
+
+func TestNodeDrainBlockedByHysteresis(t *testing.T) {
+	setDefaultDrainEnv(t)
+	t.Setenv("DRAIN_HYSTERESIS_THRESHOLD", "70")
+
+	clientSet := fake.NewSimpleClientset()
+	nodepoolName := "test-nodepool"
+	for i := 1; i <= 4; i++ {
+		if _, err := clientSet.CoreV1().Nodes().Create(context.Background(), newNode(nodepoolName, i), metaV1.CreateOptions{}); err != nil {
+			t.Fatalf("노드 생성 실패: %v", err)
+		}
+	}
+
+	// 지금은 30%지만 최근 1시간 중 최대 memory 사용률이 85%
+	results, summary, err := NodeDrainWithSummary(context.Background(), clientSet, DrainDependencies{
+		AllocateRateProvider: fakeWindowAllocateRateProvider{
+			fakeAllocateRateProvider: fakeAllocateRateProvider{rates: map[string]int{"memory": 30, "cpu": 25}},
+			windowRates:              map[string]int{"memory": 85, "cpu": 40},
+		},
+		Notifier: fakeNotifier{},
+	}, DrainConfig{
+		NodepoolName: nodepoolName,
+		Eviction:     testEvictionConfig(),
+	})
+	assert.NoError(t, err)
+	assert.Len(t, results, 0)
+	assert.True(t, summary.StoppedBySafety)
+	assert.Contains(t, summary.StopSafetyReason, "hysteresis: memory max allocateRate")
+}
+
+func TestNodeDrainRecordsHysteresisFailClosed(t *testing.T) {
+	setDefaultDrainEnv(t)
+	t.Setenv("DRAIN_HYSTERESIS_THRESHOLD", "70")
+	t.Setenv("DRAIN_SAFETY_FAIL_CLOSED", "true")
+
+	clientSet := fake.NewSimpleClientset()
+	nodepoolName := "test-nodepool"
+	for i := 1; i <= 4; i++ {
+		if _, err := clientSet.CoreV1().Nodes().Create(context.Background(), newNode(nodepoolName, i), metaV1.CreateOptions{}); err != nil {
+			t.Fatalf("노드 생성 실패: %v", err)
+		}
+	}
+
+	// 기간 집계를 지원하지 않는 provider + fail-closed
+	results, summary, err := NodeDrainWithSummary(context.Background(), clientSet, DrainDependencies{
+		AllocateRateProvider: fakeAllocateRateProvider{rates: map[string]int{"memory": 30, "cpu": 25}},
+		Notifier:             fakeNotifier{},
+	}, DrainConfig{
+		NodepoolName: nodepoolName,
+		Eviction:     testEvictionConfig(),
+	})
+	assert.NoError(t, err)
+	assert.Len(t, results, 0)
+	assert.True(t, summary.StoppedBySafety)
+	assert.Contains(t, summary.StopSafetyReason, "hysteresis 평가 실패(fail-closed)")
+}
@@ -0,0 +1,188 @@
+package node
+
+import (
+	"app/types"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	coreV1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	defaultDrainBudgetNamespace = "kube-system"
+	defaultDrainBudgetConfigMap = "node-drain-history"
+	defaultDrainBudgetWindow    = 24 * time.Hour
+)
+
+// RollingBudgetOptions는 여러 실행에 걸친 nodepool별 드레인 예산(기간 내 최대 드레인 노드 수)입니다.
+// 드레인 이력은 ConfigMap에 nodepool 이름을 키로 저장합니다.
+type RollingBudgetOptions struct {
+	MaxNodes      int           // 0 이면 비활성
+	Window        time.Duration // 예산 기간 (예: 24h, 168h)
+	Namespace     string        // 이력 ConfigMap 네임스페이스
+	ConfigMapName string        // 이력 ConfigMap 이름
+}
+
+// GetRollingBudgetOptionsFromEnv는 드레인 예산 관련 환경 변수를 파싱합니다.
+func GetRollingBudgetOptionsFromEnv() RollingBudgetOptions {
+	opts := RollingBudgetOptions{
+		MaxNodes:      parseEnvInt("DRAIN_BUDGET_MAX_NODES", 0),
+		Window:        parseEnvDuration("DRAIN_BUDGET_WINDOW", defaultDrainBudgetWindow),
+		Namespace:     strings.TrimSpace(os.Getenv("DRAIN_BUDGET_NAMESPACE")),
+		ConfigMapName: strings.TrimSpace(os.Getenv("DRAIN_BUDGET_CONFIGMAP")),
+	}
+	if opts.Window <= 0 {
+		opts.Window = defaultDrainBudgetWindow
+	}
+	if opts.Namespace == "" {
+		opts.Namespace = defaultDrainBudgetNamespace
+	}
+	if opts.ConfigMapName == "" {
+		opts.ConfigMapName = defaultDrainBudgetConfigMap
+	}
+	return opts
+}
+
+// Enabled는 드레인 예산이 설정되어 있는지 반환합니다.
+func (o RollingBudgetOptions) Enabled() bool {
+	return o.MaxNodes > 0
+}
+
+// drainHistoryEntry는 ConfigMap에 기록하는 드레인 이력 한 건입니다.
+type drainHistoryEntry struct {
+	NodeName  string    `json:"nodeName"`
+	DrainedAt time.Time `json:"drainedAt"`
+}
+
+// GetRollingBudgetStatus는 nodepool의 기간 내 드레인 수와 남은 예산을 조회합니다.
+func GetRollingBudgetStatus(ctx context.Context, clientSet kubernetes.Interface, nodepoolName string, opts RollingBudgetOptions) (types.DrainBudgetStatus, error) {
+	status := types.DrainBudgetStatus{
+		NodepoolName: nodepoolName,
+		MaxNodes:     opts.MaxNodes,
+		Window:       opts.Window.String(),
+	}
+	if !opts.Enabled() {
+		return status, nil
+	}
+
+	entries, err := loadDrainHistory(ctx, clientSet, nodepoolName, opts)
+	if err != nil {
+		return status, err
+	}
+	status.DrainedInWindow = len(entriesInWindow(entries, time.Now(), opts.Window))
+	status.Remaining = opts.MaxNodes - status.DrainedInWindow
+	if status.Remaining < 0 {
+		status.Remaining = 0
+	}
+	return status, nil
+}
+
+// loadDrainHistory는 이력 ConfigMap에서 nodepool의 드레인 이력을 읽습니다. ConfigMap이 없으면 빈 이력입니다.
+func loadDrainHistory(ctx context.Context, clientSet kubernetes.Interface, nodepoolName string, opts RollingBudgetOptions) ([]drainHistoryEntry, error) {
+	cm, err := clientSet.CoreV1().ConfigMaps(opts.Namespace).Get(ctx, opts.ConfigMapName, metaV1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("드레인 이력 ConfigMap %s/%s 조회 실패: %w", opts.Namespace, opts.ConfigMapName, err)
+	}
+	return parseDrainHistory(cm.Data[nodepoolName])
+}
+
+func parseDrainHistory(raw string) ([]drainHistoryEntry, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+	var entries []drainHistoryEntry
+	if err := json.Unmarshal([]byte(raw), &entries); err != nil {
+		return nil, fmt.Errorf("드레인 이력 파싱 실패: %w", err)
+	}
+	return entries, nil
+}
+
+// recordDrainHistory는 드레인한 노드를 이력 ConfigMap에 추가하고, 기간이 지난 이력은 정리합니다.
+func recordDrainHistory(ctx context.Context, clientSet kubernetes.Interface, nodepoolName string, nodeName string, drainedAt time.Time, opts RollingBudgetOptions) error {
+	configMaps := clientSet.CoreV1().ConfigMaps(opts.Namespace)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cm, err := configMaps.Get(ctx, opts.ConfigMapName, metaV1.GetOptions{})
+		notFound := apierrors.IsNotFound(err)
+		if err != nil && !notFound {
+			return err
+		}
+		if notFound {
+			cm = &coreV1.ConfigMap{
+				ObjectMeta: metaV1.ObjectMeta{
+					Name:      opts.ConfigMapName,
+					Namespace: opts.Namespace,
+				},
+			}
+		}
+
+		entries, err := parseDrainHistory(cm.Data[nodepoolName])
+		if err != nil {
+			// 손상된 이력은 덮어써서 이후 실행이 계속 동작하도록 합니다.
+			slog.Warn("드레인 이력이 손상되어 새로 기록합니다.", "nodepool", nodepoolName, "error", err)
+			entries = nil
+		}
+		entries = append(entriesInWindow(entries, drainedAt, opts.Window), drainHistoryEntry{NodeName: nodeName, DrainedAt: drainedAt.UTC()})
+		raw, err := json.Marshal(entries)
+		if err != nil {
+			return err
+		}
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		cm.Data[nodepoolName] = string(raw)
+
+		if notFound {
+			_, err = configMaps.Create(ctx, cm, metaV1.CreateOptions{})
+			if apierrors.IsAlreadyExists(err) {
+				// 동시에 다른 실행이 생성한 경우 conflict로 보고 재시도
+				return apierrors.NewConflict(coreV1.Resource("configmaps"), opts.ConfigMapName, err)
+			}
+			return err
+		}
+		_, err = configMaps.Update(ctx, cm, metaV1.UpdateOptions{})
+		return err
+	})
+}
+
+// entriesInWindow는 now 기준 window 안의 이력만 시간순으로 반환합니다.
+func entriesInWindow(entries []drainHistoryEntry, now time.Time, window time.Duration) []drainHistoryEntry {
+	since := now.Add(-window)
+	kept := make([]drainHistoryEntry, 0, len(entries))
+	for _, e := range entries {
+		if e.DrainedAt.After(since) {
+			kept = append(kept, e)
+		}
+	}
+	sort.SliceStable(kept, func(i, j int) bool {
+		return kept[i].DrainedAt.Before(kept[j].DrainedAt)
+	})
+	return kept
+}
+
+// budgetNotifier는 남은 드레인 예산 알림을 지원하는 Notifier입니다.
+type budgetNotifier interface {
+	SendDrainBudget(ctx context.Context, status types.DrainBudgetStatus) error
+}
+
+func notifyDrainBudget(ctx context.Context, deps DrainDependencies, status types.DrainBudgetStatus) {
+	notifier, ok := deps.Notifier.(budgetNotifier)
+	if !ok {
+		return
+	}
+	if err := notifier.SendDrainBudget(ctx, status); err != nil {
+		slog.Error("드레인 예산 알림 전송 실패", "error", err)
+	}
+}
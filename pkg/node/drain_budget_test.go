@@ -0,0 +1,137 @@
+package node
+
+import (
+	"app/types"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func testBudgetOptions(maxNodes int) RollingBudgetOptions {
+	return RollingBudgetOptions{
+		MaxNodes:      maxNodes,
+		Window:        24 * time.Hour,
+		Namespace:     "kube-system",
+		ConfigMapName: "node-drain-history",
+	}
+}
+
+func newDrainHistoryConfigMap(t *testing.T, nodepool string, entries []drainHistoryEntry) *coreV1.ConfigMap {
+	t.Helper()
+	raw, err := json.Marshal(entries)
+	if err != nil {
+		t.Fatalf("이력 직렬화 실패: %v", err)
+	}
+	return &coreV1.ConfigMap{
+		ObjectMeta: metaV1.ObjectMeta{Name: "node-drain-history", Namespace: "kube-system"},
+		Data:       map[string]string{nodepool: string(raw)},
+	}
+}
+
+func TestCalculateDrainNodeCount_RollingBudget(t *testing.T) {
+	opts := DrainPolicyOptions{
+		Policy:   DrainPolicyFormula,
+		Rounding: DrainRoundingFloor,
+	}
+	budget := &types.DrainBudgetStatus{MaxNodes: 10, DrainedInWindow: 8, Remaining: 2}
+
+	// lenNodes=8, max=20 => floor(6.32)=6, 남은 예산 2 => 2
+	assert.Equal(t, 2, CalculateDrainNodeCount(8, 20, opts, budget, 0))
+
+	// 예산 소진 시 0
+	budget.DrainedInWindow, budget.Remaining = 10, 0
+	assert.Equal(t, 0, CalculateDrainNodeCount(8, 20, opts, budget, 0))
+
+	// 예산 비활성(nil)이면 상한 없음
+	assert.Equal(t, 6, CalculateDrainNodeCount(8, 20, opts, nil, 0))
+}
+
+func TestRecordDrainHistoryPrunesOldEntries(t *testing.T) {
+	now := time.Now()
+	clientSet := fake.NewSimpleClientset(newDrainHistoryConfigMap(t, "test-nodepool", []drainHistoryEntry{
+		{NodeName: "old", DrainedAt: now.Add(-48 * time.Hour)},
+		{NodeName: "recent", DrainedAt: now.Add(-1 * time.Hour)},
+	}))
+	opts := testBudgetOptions(10)
+
+	if err := recordDrainHistory(context.Background(), clientSet, "test-nodepool", "node-1", now, opts); err != nil {
+		t.Fatalf("이력 기록 실패: %v", err)
+	}
+
+	entries, err := loadDrainHistory(context.Background(), clientSet, "test-nodepool", opts)
+	assert.NoError(t, err)
+	if len(entries) != 2 {
+		t.Fatalf("이력 개수 불일치: got=%d want=2", len(entries))
+	}
+	assert.Equal(t, "recent", entries[0].NodeName)
+	assert.Equal(t, "node-1", entries[1].NodeName)
+
+	status, err := GetRollingBudgetStatus(context.Background(), clientSet, "test-nodepool", opts)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, status.DrainedInWindow)
+	assert.Equal(t, 8, status.Remaining)
+}
+
+func TestRecordDrainHistoryCreatesConfigMap(t *testing.T) {
+	clientSet := fake.NewSimpleClientset()
+	opts := testBudgetOptions(5)
+
+	if err := recordDrainHistory(context.Background(), clientSet, "test-nodepool", "node-1", time.Now(), opts); err != nil {
+		t.Fatalf("이력 기록 실패: %v", err)
+	}
+
+	status, err := GetRollingBudgetStatus(context.Background(), clientSet, "test-nodepool", opts)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, status.DrainedInWindow)
+	assert.Equal(t, 4, status.Remaining)
+
+	// 다른 nodepool 이력과는 독립
+	other, err := GetRollingBudgetStatus(context.Background(), clientSet, "other-nodepool", opts)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, other.DrainedInWindow)
+}
+
+func TestNodeDrainRespectsRollingBudget(t *testing.T) {
+	setDefaultDrainEnv(t)
+	t.Setenv("DRAIN_BUDGET_MAX_NODES", "3")
+
+	nodepoolName := "test-nodepool"
+	now := time.Now()
+	clientSet := fake.NewSimpleClientset(newDrainHistoryConfigMap(t, nodepoolName, []drainHistoryEntry{
+		{NodeName: "old", DrainedAt: now.Add(-30 * time.Hour)},
+		{NodeName: "recent-1", DrainedAt: now.Add(-2 * time.Hour)},
+		{NodeName: "recent-2", DrainedAt: now.Add(-1 * time.Hour)},
+	}))
+	for i := 1; i <= 4; i++ {
+		if _, err := clientSet.CoreV1().Nodes().Create(context.Background(), newNode(nodepoolName, i), metaV1.CreateOptions{}); err != nil {
+			t.Fatalf("노드 생성 실패: %v", err)
+		}
+	}
+
+	// memory 30 / cpu 25 => 4대 중 2대 드레인이지만 남은 예산이 1대
+	results, err := NodeDrain(context.Background(), clientSet, DrainDependencies{
+		AllocateRateProvider: fakeAllocateRateProvider{rates: map[string]int{"memory": 30, "cpu": 25}},
+		Notifier:             fakeNotifier{},
+	}, DrainConfig{
+		NodepoolName: nodepoolName,
+		Eviction:     testEvictionConfig(),
+	})
+	if err != nil {
+		t.Fatalf("NodeDrain 실패: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("drain 결과 개수 불일치: got=%d want=1", len(results))
+	}
+	assert.Equal(t, "node-1", results[0].NodeName)
+
+	status, err := GetRollingBudgetStatus(context.Background(), clientSet, nodepoolName, GetRollingBudgetOptionsFromEnv())
+	assert.NoError(t, err)
+	assert.Equal(t, 3, status.DrainedInWindow)
+	assert.Equal(t, 0, status.Remaining)
+}
@@ -0,0 +1,102 @@
+package node
+
+import (
+	"app/types"
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+// CanaryOptions는 첫 노드를 canary로 드레인하고 soak 기간 동안 점검한 뒤 나머지 노드를 진행하는 옵션입니다.
+type CanaryOptions struct {
+	Enabled  bool
+	Soak     time.Duration // canary 드레인 후 관찰 기간
+	Interval time.Duration // soak 중 점검 주기
+}
+
+// GetCanaryOptionsFromEnv는 canary 드레인 관련 환경 변수를 파싱합니다. 기본값은 비활성입니다.
+func GetCanaryOptionsFromEnv() CanaryOptions {
+	opts := CanaryOptions{
+		Enabled:  parseEnvBool("DRAIN_CANARY", false),
+		Soak:     parseEnvDuration("DRAIN_CANARY_SOAK", 5*time.Minute),
+		Interval: parseEnvDuration("DRAIN_CANARY_CHECK_INTERVAL", 30*time.Second),
+	}
+	if opts.Soak < 0 {
+		opts.Soak = 0
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = 30 * time.Second
+	}
+	return opts
+}
+
+// CanaryFailedError는 canary 점검 실패로 나머지 노드 드레인을 중단했을 때의 오류입니다.
+type CanaryFailedError struct {
+	NodeName string
+	Reason   string
+}
+
+func (e *CanaryFailedError) Error() string {
+	return fmt.Sprintf("canary node %s failed: %s", e.NodeName, e.Reason)
+}
+
+// soakCanary는 soak 기간 동안 주기적으로 안전 조건을 평가하고, 기간이 끝나면 canary 노드에서 제거한 워크로드가 모두 available인지 확인합니다.
+// 하나라도 걸리면 Passed=false와 사유를 반환합니다.
+func soakCanary(ctx context.Context, clientSet kubernetes.Interface, nodeName string, workloads []workloadRef, opts CanaryOptions, check func(ctx context.Context) SafetyDecision) (types.DrainCanaryResult, error) {
+	result := types.DrainCanaryResult{NodeName: nodeName, Soak: opts.Soak.String()}
+	slog.Info("canary soak 시작", "nodeName", nodeName, "soak", opts.Soak.String(), "workloads", len(workloads))
+
+	deadline := time.NewTimer(opts.Soak)
+	defer deadline.Stop()
+	ticker := time.NewTicker(opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		if decision := check(ctx); decision.Blocked {
+			result.Reason = decision.Reason
+			result.Check = decision.Check
+			return result, nil
+		}
+		if notReady := unavailableWorkloads(ctx, clientSet, workloads); len(notReady) > 0 {
+			slog.Info("canary 워크로드 복구 대기 중", "nodeName", nodeName, "remaining", workloadNames(notReady))
+		}
+
+		select {
+		case <-ctx.Done():
+			return result, ctx.Err()
+		case <-deadline.C:
+			if decision := check(ctx); decision.Blocked {
+				result.Reason = decision.Reason
+				result.Check = decision.Check
+				return result, nil
+			}
+			if notReady := unavailableWorkloads(ctx, clientSet, workloads); len(notReady) > 0 {
+				result.Reason = fmt.Sprintf("canary workloads not available after soak: %s", strings.Join(workloadNames(notReady), ", "))
+				return result, nil
+			}
+			result.Passed = true
+			slog.Info("canary soak 통과", "nodeName", nodeName)
+			return result, nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// canaryNotifier는 canary 점검 실패 알림을 지원하는 Notifier입니다.
+type canaryNotifier interface {
+	SendDrainCanaryFailed(ctx context.Context, result types.DrainCanaryResult) error
+}
+
+func notifyCanaryFailed(ctx context.Context, deps DrainDependencies, result types.DrainCanaryResult) {
+	notifier, ok := deps.Notifier.(canaryNotifier)
+	if !ok {
+		return
+	}
+	if err := notifier.SendDrainCanaryFailed(ctx, result); err != nil {
+		slog.Error("canary 점검 실패 알림 전송 실패", "error", err)
+	}
+}
@@ -0,0 +1,182 @@
+package node
+
+import (
+	"app/pkg/pod"
+	"app/types"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+type fakeCanaryNotifier struct {
+	fakeNotifier
+	failed *[]types.DrainCanaryResult
+}
+
+func (f fakeCanaryNotifier) SendDrainCanaryFailed(ctx context.Context, result types.DrainCanaryResult) error {
+	*f.failed = append(*f.failed, result)
+	return nil
+}
+
+func TestGetCanaryOptionsFromEnv(t *testing.T) {
+	t.Setenv("DRAIN_CANARY", "")
+	t.Setenv("DRAIN_CANARY_SOAK", "")
+	t.Setenv("DRAIN_CANARY_CHECK_INTERVAL", "")
+	assert.Equal(t, CanaryOptions{Enabled: false, Soak: 5 * time.Minute, Interval: 30 * time.Second}, GetCanaryOptionsFromEnv())
+
+	t.Setenv("DRAIN_CANARY", "true")
+	t.Setenv("DRAIN_CANARY_SOAK", "2m")
+	t.Setenv("DRAIN_CANARY_CHECK_INTERVAL", "0s")
+	assert.Equal(t, CanaryOptions{Enabled: true, Soak: 2 * time.Minute, Interval: 30 * time.Second}, GetCanaryOptionsFromEnv())
+}
+
+func TestSoakCanary(t *testing.T) {
+	opts := CanaryOptions{Enabled: true, Soak: 50 * time.Millisecond, Interval: 10 * time.Millisecond}
+	refs := []workloadRef{
+		{kind: "Deployment", namespace: "default", name: "api"},
+		{kind: "StatefulSet", namespace: "default", name: "db"},
+	}
+	clean := func(ctx context.Context) SafetyDecision { return SafetyDecision{} }
+
+	// 안전 조건과 워크로드가 모두 정상이면 통과
+	result, err := soakCanary(context.Background(), fake.NewSimpleClientset(workloadObjects(2)...), "node-1", refs, opts, clean)
+	assert.NoError(t, err)
+	assert.True(t, result.Passed)
+
+	// soak 중 안전 조건이 걸리면 즉시 실패
+	calls := 0
+	result, err = soakCanary(context.Background(), fake.NewSimpleClientset(workloadObjects(2)...), "node-1", refs, opts, func(ctx context.Context) SafetyDecision {
+		calls++
+		if calls < 2 {
+			return SafetyDecision{}
+		}
+		check := types.SafetyCheckResult{Name: "k8s-pending-pods", Blocked: true}
+		return SafetyDecision{Blocked: true, Reason: "pending pods", Check: &check}
+	})
+	assert.NoError(t, err)
+	assert.False(t, result.Passed)
+	assert.Equal(t, "pending pods", result.Reason)
+	assert.Equal(t, "k8s-pending-pods", result.Check.Name)
+	assert.Equal(t, 2, calls)
+
+	// soak가 끝날 때까지 워크로드가 복구되지 않으면 실패
+	result, err = soakCanary(context.Background(), fake.NewSimpleClientset(workloadObjects(1)...), "node-1", refs, opts, clean)
+	assert.NoError(t, err)
+	assert.False(t, result.Passed)
+	assert.Contains(t, result.Reason, "Deployment/default/api")
+	assert.NotContains(t, result.Reason, "StatefulSet/default/db")
+
+	// 취소되면 오류
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = soakCanary(ctx, fake.NewSimpleClientset(workloadObjects(2)...), "node-1", refs, CanaryOptions{Soak: time.Minute, Interval: time.Minute}, clean)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestNodeDrainCanary(t *testing.T) {
+	tests := []struct {
+		name          string
+		available     int32
+		expectedDrain int
+		expectFailed  bool
+	}{
+		{name: "canary 통과 후 나머지 노드 진행", available: 2, expectedDrain: 2},
+		{name: "canary 워크로드 미복구 시 중단", available: 1, expectedDrain: 1, expectFailed: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			setDefaultDrainEnv(t)
+			t.Setenv("DRAIN_CANARY", "true")
+			t.Setenv("DRAIN_CANARY_SOAK", "30ms")
+			t.Setenv("DRAIN_CANARY_CHECK_INTERVAL", "10ms")
+
+			nodepoolName := "test-nodepool"
+			objects := workloadObjects(tt.available)
+			for i := 1; i <= 4; i++ {
+				objects = append(objects, newNode(nodepoolName, i))
+			}
+			clientSet := fake.NewSimpleClientset(objects...)
+
+			var failed []types.DrainCanaryResult
+			evictionCfg := testEvictionConfig()
+			evictionCfg.EvictionMode = pod.EvictionModeDelete
+			results, summary, err := NodeDrainWithSummary(context.Background(), clientSet, DrainDependencies{
+				AllocateRateProvider: fakeAllocateRateProvider{rates: map[string]int{"memory": 30, "cpu": 25}},
+				Notifier:             fakeCanaryNotifier{failed: &failed},
+			}, DrainConfig{
+				NodepoolName: nodepoolName,
+				Eviction:     evictionCfg,
+			})
+			assert.Len(t, results, tt.expectedDrain)
+			if tt.expectFailed {
+				var canaryErr *CanaryFailedError
+				if assert.ErrorAs(t, err, &canaryErr) {
+					assert.Equal(t, "node-1", canaryErr.NodeName)
+				}
+				if assert.Len(t, failed, 1) {
+					assert.Equal(t, "node-1", failed[0].NodeName)
+				}
+				assert.Equal(t, "node-1", summary.StoppedByCanary)
+				assert.Contains(t, summary.CanaryFailureReason, "Deployment/default/api")
+			} else {
+				assert.NoError(t, err)
+				assert.Empty(t, failed)
+				assert.Empty(t, summary.StoppedByCanary)
+			}
+		})
+	}
+}
+
+// fakeCanarySafetyNotifier는 canary 실패와 안전 조건 차단 알림을 모두 받습니다.
+type fakeCanarySafetyNotifier struct {
+	fakeCanaryNotifier
+	blocked *[]types.SafetyCheckResult
+}
+
+func (f fakeCanarySafetyNotifier) SendDrainSafetyBlocked(ctx context.Context, result types.SafetyCheckResult) error {
+	*f.blocked = append(*f.blocked, result)
+	return nil
+}
+
+func TestNodeDrainCanarySafetyBlockSendsSingleAlert(t *testing.T) {
+	setDefaultDrainEnv(t)
+	t.Setenv("DRAIN_CANARY", "true")
+	t.Setenv("DRAIN_CANARY_SOAK", "30ms")
+	t.Setenv("DRAIN_CANARY_CHECK_INTERVAL", "10ms")
+	t.Setenv("DRAIN_SAFETY_CHECKS", `[{"name":"pending-pods","query":"pending","comparator":">=","threshold":3}]`)
+
+	nodepoolName := "test-nodepool"
+	objects := workloadObjects(2)
+	for i := 1; i <= 4; i++ {
+		objects = append(objects, newNode(nodepoolName, i))
+	}
+	clientSet := fake.NewSimpleClientset(objects...)
+
+	// 실행 전 평가는 통과하고, canary soak 중 차단
+	var failed []types.DrainCanaryResult
+	var blocked []types.SafetyCheckResult
+	evictionCfg := testEvictionConfig()
+	evictionCfg.EvictionMode = pod.EvictionModeDelete
+	results, summary, err := NodeDrainWithSummary(context.Background(), clientSet, DrainDependencies{
+		AllocateRateProvider: fakeAllocateRateProvider{rates: map[string]int{"memory": 30, "cpu": 25}},
+		Notifier:             fakeCanarySafetyNotifier{fakeCanaryNotifier: fakeCanaryNotifier{failed: &failed}, blocked: &blocked},
+		SafetyQuerier:        &sequenceSafetyQuerier{values: []float64{0, 7}},
+	}, DrainConfig{
+		NodepoolName: nodepoolName,
+		Eviction:     evictionCfg,
+	})
+
+	var canaryErr *CanaryFailedError
+	assert.ErrorAs(t, err, &canaryErr)
+	assert.Len(t, results, 1)
+	if assert.Len(t, failed, 1) {
+		assert.Equal(t, "pending-pods", failed[0].Check.Name)
+	}
+	assert.Empty(t, blocked)
+	assert.Equal(t, "node-1", summary.StoppedByCanary)
+}
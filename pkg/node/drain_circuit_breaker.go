@@ -0,0 +1,108 @@
+package node
+
+import (
+	"app/pkg/pod"
+	"fmt"
+	"log/slog"
+)
+
+// circuit breaker 이름입니다.
+const (
+	CircuitBreakerEvictionFailureRate = "eviction-failure-rate"
+	CircuitBreakerForceDeletes        = "force-deletes"
+	CircuitBreakerFailedNodes         = "failed-nodes"
+)
+
+// CircuitBreakerOptions는 실행 전체의 실패 추세로 드레인을 중단하는 조건입니다. 각 임계값은 0 이면 비활성입니다.
+// breaker가 하나라도 설정되면 노드 실패만으로는 중단하지 않고 breaker가 중단 여부를 판단합니다.
+// 하나도 없으면 기존처럼 첫 실패 노드에서 중단합니다.
+type CircuitBreakerOptions struct {
+	MaxEvictionFailureRate float64 // eviction 실패 비율(%)이 이 값을 넘으면 중단
+	MinEvictions           int     // 실패 비율은 eviction 시도가 이 값 이상일 때부터 평가
+	MaxForceDeletes        int     // 강제 삭제 수가 이 값을 넘으면 중단
+	MaxFailedNodes         int     // 실패 노드 수가 이 값을 넘으면 중단
+}
+
+// GetCircuitBreakerOptionsFromEnv는 circuit breaker 관련 환경 변수를 파싱합니다. 기본값은 모두 비활성입니다.
+func GetCircuitBreakerOptionsFromEnv() CircuitBreakerOptions {
+	opts := CircuitBreakerOptions{
+		MaxEvictionFailureRate: parseEnvFloat("DRAIN_BREAKER_MAX_EVICTION_FAILURE_RATE", 0),
+		MinEvictions:           parseEnvInt("DRAIN_BREAKER_MIN_EVICTIONS", 10),
+		MaxForceDeletes:        parseEnvInt("DRAIN_BREAKER_MAX_FORCE_DELETES", 0),
+		MaxFailedNodes:         parseEnvInt("DRAIN_BREAKER_MAX_FAILED_NODES", 0),
+	}
+	if opts.MinEvictions < 1 {
+		opts.MinEvictions = 1
+	}
+	return opts
+}
+
+// Enabled는 eviction 집계가 필요한 breaker가 설정됐는지 반환합니다.
+func (o CircuitBreakerOptions) Enabled() bool {
+	return o.MaxEvictionFailureRate > 0 || o.MaxForceDeletes > 0 || o.MaxFailedNodes > 0
+}
+
+// CircuitBreakerError는 circuit breaker가 동작해 드레인을 중단했을 때의 오류입니다.
+type CircuitBreakerError struct {
+	Breaker string
+	Reason  string
+}
+
+func (e *CircuitBreakerError) Error() string {
+	return fmt.Sprintf("circuit breaker %q tripped: %s", e.Breaker, e.Reason)
+}
+
+// circuitBreakerCounts는 breaker 판단에 쓰는 실행 전체 집계입니다.
+type circuitBreakerCounts struct {
+	attempted    int64
+	failed       int64
+	forceDeleted int64
+	pdbBlocked   int64
+	failedNodes  int
+}
+
+func newCircuitBreakerCounts(stats *pod.EvictionStats, failedNodes int) circuitBreakerCounts {
+	return circuitBreakerCounts{
+		attempted:    stats.Attempted(),
+		failed:       stats.Failed(),
+		forceDeleted: stats.ForceDeleted(),
+		pdbBlocked:   stats.PDBBlocked(),
+		failedNodes:  failedNodes,
+	}
+}
+
+// checkCircuitBreakers는 현재까지의 집계로 breaker를 평가합니다. 동작하지 않으면 nil입니다.
+func checkCircuitBreakers(opts CircuitBreakerOptions, counts circuitBreakerCounts) *CircuitBreakerError {
+	if opts.MaxFailedNodes > 0 && counts.failedNodes > opts.MaxFailedNodes {
+		return &CircuitBreakerError{
+			Breaker: CircuitBreakerFailedNodes,
+			Reason:  fmt.Sprintf("failed nodes %d > %d", counts.failedNodes, opts.MaxFailedNodes),
+		}
+	}
+	if opts.MaxForceDeletes > 0 && counts.forceDeleted > int64(opts.MaxForceDeletes) {
+		return &CircuitBreakerError{
+			Breaker: CircuitBreakerForceDeletes,
+			Reason:  fmt.Sprintf("force deletes %d > %d", counts.forceDeleted, opts.MaxForceDeletes),
+		}
+	}
+	if opts.MaxEvictionFailureRate > 0 && counts.attempted > 0 && counts.attempted >= int64(opts.MinEvictions) {
+		rate := float64(counts.failed) / float64(counts.attempted) * 100
+		if rate > opts.MaxEvictionFailureRate {
+			return &CircuitBreakerError{
+				Breaker: CircuitBreakerEvictionFailureRate,
+				Reason:  fmt.Sprintf("eviction failure rate %.1f%% (%d/%d) > %.1f%%", rate, counts.failed, counts.attempted, opts.MaxEvictionFailureRate),
+			}
+		}
+	}
+	return nil
+}
+
+func logCircuitBreakerCounts(counts circuitBreakerCounts) {
+	slog.Info("eviction 집계",
+		"attempted", counts.attempted,
+		"failed", counts.failed,
+		"forceDeleted", counts.forceDeleted,
+		"pdbBlocked", counts.pdbBlocked,
+		"failedNodes", counts.failedNodes,
+	)
+}
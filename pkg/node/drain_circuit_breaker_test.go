@@ -0,0 +1,131 @@
+package node
+
+import (
+	"app/pkg/pod"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestGetCircuitBreakerOptionsFromEnv(t *testing.T) {
+	t.Setenv("DRAIN_BREAKER_MAX_EVICTION_FAILURE_RATE", "")
+	t.Setenv("DRAIN_BREAKER_MIN_EVICTIONS", "")
+	t.Setenv("DRAIN_BREAKER_MAX_FORCE_DELETES", "")
+	t.Setenv("DRAIN_BREAKER_MAX_FAILED_NODES", "")
+	opts := GetCircuitBreakerOptionsFromEnv()
+	assert.Equal(t, CircuitBreakerOptions{MinEvictions: 10}, opts)
+	assert.False(t, opts.Enabled())
+
+	t.Setenv("DRAIN_BREAKER_MAX_EVICTION_FAILURE_RATE", "12.5")
+	t.Setenv("DRAIN_BREAKER_MIN_EVICTIONS", "0")
+	opts = GetCircuitBreakerOptionsFromEnv()
+	assert.Equal(t, CircuitBreakerOptions{MaxEvictionFailureRate: 12.5, MinEvictions: 1}, opts)
+	assert.True(t, opts.Enabled())
+}
+
+func TestCheckCircuitBreakers(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    CircuitBreakerOptions
+		counts  circuitBreakerCounts
+		breaker string
+	}{
+		{name: "비활성", opts: CircuitBreakerOptions{MinEvictions: 1}, counts: circuitBreakerCounts{attempted: 10, failed: 10, forceDeleted: 10, failedNodes: 10}},
+		{name: "실패 비율 초과", opts: CircuitBreakerOptions{MaxEvictionFailureRate: 20, MinEvictions: 5}, counts: circuitBreakerCounts{attempted: 10, failed: 3}, breaker: CircuitBreakerEvictionFailureRate},
+		{name: "실패 비율 경계값은 통과", opts: CircuitBreakerOptions{MaxEvictionFailureRate: 30, MinEvictions: 5}, counts: circuitBreakerCounts{attempted: 10, failed: 3}},
+		{name: "최소 eviction 수 미만", opts: CircuitBreakerOptions{MaxEvictionFailureRate: 20, MinEvictions: 20}, counts: circuitBreakerCounts{attempted: 10, failed: 10}},
+		{name: "강제 삭제 초과", opts: CircuitBreakerOptions{MaxForceDeletes: 2, MinEvictions: 1}, counts: circuitBreakerCounts{forceDeleted: 3}, breaker: CircuitBreakerForceDeletes},
+		{name: "강제 삭제 경계값은 통과", opts: CircuitBreakerOptions{MaxForceDeletes: 2, MinEvictions: 1}, counts: circuitBreakerCounts{forceDeleted: 2}},
+		{name: "실패 노드 초과", opts: CircuitBreakerOptions{MaxFailedNodes: 1, MinEvictions: 1}, counts: circuitBreakerCounts{failedNodes: 2}, breaker: CircuitBreakerFailedNodes},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			tripped := checkCircuitBreakers(tt.opts, tt.counts)
+			if tt.breaker == "" {
+				assert.Nil(t, tripped)
+				return
+			}
+			if assert.NotNil(t, tripped) {
+				assert.Equal(t, tt.breaker, tripped.Breaker)
+				assert.Contains(t, tripped.Error(), tt.breaker)
+			}
+		})
+	}
+}
+
+func TestNodeDrainCircuitBreakers(t *testing.T) {
+	tests := []struct {
+		name          string
+		env           map[string]string
+		expectedDrain int
+		breaker       string
+	}{
+		{name: "breaker 없으면 첫 실패에서 중단", expectedDrain: 1},
+		{name: "실패 노드 허용 후 초과 시 중단", env: map[string]string{"DRAIN_BREAKER_MAX_FAILED_NODES": "1"}, expectedDrain: 2, breaker: CircuitBreakerFailedNodes},
+		{name: "허용 범위의 실패 노드도 오류로 반환", env: map[string]string{"DRAIN_BREAKER_MAX_FAILED_NODES": "5"}, expectedDrain: 2},
+		{name: "실패 노드 breaker 없이 eviction 실패 비율 초과", env: map[string]string{"DRAIN_BREAKER_MAX_EVICTION_FAILURE_RATE": "50", "DRAIN_BREAKER_MIN_EVICTIONS": "2"}, expectedDrain: 2, breaker: CircuitBreakerEvictionFailureRate},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			setDefaultDrainEnv(t)
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			nodepoolName := "test-nodepool"
+			objects := []runtime.Object{
+				&coreV1.Pod{ObjectMeta: metaV1.ObjectMeta{Name: "stuck", Namespace: "default"}, Spec: coreV1.PodSpec{NodeName: "node-1"}},
+			}
+			for i := 1; i <= 4; i++ {
+				objects = append(objects, newNode(nodepoolName, i))
+			}
+			clientSet := fake.NewSimpleClientset(objects...)
+			clientSet.PrependReactor("delete", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				return true, nil, errors.New("delete failed")
+			})
+
+			evictionCfg := testEvictionConfig()
+			evictionCfg.EvictionMode = pod.EvictionModeDelete
+			results, summary, err := NodeDrainWithSummary(context.Background(), clientSet, DrainDependencies{
+				AllocateRateProvider: fakeAllocateRateProvider{rates: map[string]int{"memory": 30, "cpu": 25}},
+				Notifier:             fakeNotifier{},
+			}, DrainConfig{
+				NodepoolName: nodepoolName,
+				Eviction:     evictionCfg,
+			})
+			assert.Error(t, err)
+			assert.Len(t, results, tt.expectedDrain)
+			for idx, result := range results {
+				assert.Equal(t, fmt.Sprintf("node-%d", idx+1), result.NodeName)
+				assert.False(t, result.Success)
+			}
+
+			assert.Equal(t, 0, summary.DrainedNodeCount)
+			assert.NotEmpty(t, summary.TopErrorReasons)
+
+			var breakerErr *CircuitBreakerError
+			if tt.breaker == "" {
+				assert.False(t, errors.As(err, &breakerErr))
+				assert.Empty(t, summary.StoppedByCircuitBreaker)
+				return
+			}
+			if assert.True(t, errors.As(err, &breakerErr)) {
+				assert.Equal(t, tt.breaker, breakerErr.Breaker)
+				assert.Equal(t, tt.breaker, summary.StoppedByCircuitBreaker)
+				assert.Equal(t, breakerErr.Reason, summary.CircuitBreakerReason)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package node
+
+import (
+	"app/types"
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ClusterSafetyOptions는 Prometheus 없이 API 서버에서 계산하는 안전 조건입니다.
+// 각 임계값은 0 이면 비활성이며, 관측값이 임계값 이상이면 드레인을 차단합니다.
+type ClusterSafetyOptions struct {
+	MaxPendingPods            int           // Pending 파드 수
+	MaxUnavailableWorkloads   int           // 가용 replica가 부족한 Deployment/StatefulSet 수
+	MaxNotReadyNodes          int           // nodepool 내 NotReady 노드 수
+	MaxFailedSchedulingEvents int           // 최근 FailedSchedulingWindow 동안의 FailedScheduling 발생 횟수 (집계된 이벤트는 Count 반영)
+	FailedSchedulingWindow    time.Duration // FailedScheduling 이벤트 집계 기간
+}
+
+// GetClusterSafetyOptionsFromEnv는 쿠버네티스 기반 안전 조건 환경 변수를 파싱합니다. 기본값은 모두 비활성입니다.
+func GetClusterSafetyOptionsFromEnv() ClusterSafetyOptions {
+	return ClusterSafetyOptions{
+		MaxPendingPods:            parseEnvInt("DRAIN_SAFETY_MAX_PENDING_PODS", 0),
+		MaxUnavailableWorkloads:   parseEnvInt("DRAIN_SAFETY_MAX_UNAVAILABLE_WORKLOADS", 0),
+		MaxNotReadyNodes:          parseEnvInt("DRAIN_SAFETY_MAX_NOT_READY_NODES", 0),
+		MaxFailedSchedulingEvents: parseEnvInt("DRAIN_SAFETY_MAX_FAILED_SCHEDULING_EVENTS", 0),
+		FailedSchedulingWindow:    parseEnvDuration("DRAIN_SAFETY_FAILED_SCHEDULING_WINDOW", 10*time.Minute),
+	}
+}
+
+// Enabled는 하나 이상의 쿠버네티스 기반 안전 조건이 설정됐는지 반환합니다.
+func (o ClusterSafetyOptions) Enabled() bool {
+	return o.MaxPendingPods > 0 || o.MaxUnavailableWorkloads > 0 || o.MaxNotReadyNodes > 0 || o.MaxFailedSchedulingEvents > 0
+}
+
+type clusterSafetyCheck struct {
+	name      string
+	threshold int
+	count     func(ctx context.Context) (int, error)
+}
+
+// ShouldBlockDrainByClusterConditions는 API 서버 기준 안전 조건을 순서대로 평가해 처음으로 차단한 조건을 반환합니다.
+// 조회 실패 시 failClosed면 차단합니다.
+func ShouldBlockDrainByClusterConditions(ctx context.Context, clientSet kubernetes.Interface, nodepoolName string, opts ClusterSafetyOptions, failClosed bool, now time.Time) (SafetyDecision, error) {
+	checks := []clusterSafetyCheck{
+		{name: "k8s-pending-pods", threshold: opts.MaxPendingPods, count: func(ctx context.Context) (int, error) {
+			return countPendingPods(ctx, clientSet)
+		}},
+		{name: "k8s-unavailable-workloads", threshold: opts.MaxUnavailableWorkloads, count: func(ctx context.Context) (int, error) {
+			return countUnavailableWorkloads(ctx, clientSet)
+		}},
+		{name: "k8s-not-ready-nodes", threshold: opts.MaxNotReadyNodes, count: func(ctx context.Context) (int, error) {
+			return countNotReadyNodes(ctx, clientSet, nodepoolName)
+		}},
+		{name: "k8s-failed-scheduling-events", threshold: opts.MaxFailedSchedulingEvents, count: func(ctx context.Context) (int, error) {
+			return countFailedSchedulingEvents(ctx, clientSet, now.Add(-opts.FailedSchedulingWindow))
+		}},
+	}
+
+	for _, check := range checks {
+		if check.threshold <= 0 {
+			continue
+		}
+		result := types.SafetyCheckResult{Name: check.name, Comparator: ">=", Threshold: float64(check.threshold)}
+		count, err := check.count(ctx)
+		if err != nil {
+			result.Error = err.Error()
+			if failClosed {
+				result.Blocked = true
+				return SafetyDecision{Blocked: true, Reason: fmt.Sprintf("safety check %q failed (fail-closed)", check.name), Check: &result}, err
+			}
+			slog.Warn("쿠버네티스 안전 조건 조회 실패(무시하고 진행)", "check", check.name, "error", err)
+			continue
+		}
+
+		result.Observed = float64(count)
+		result.Blocked = count >= check.threshold
+		slog.Info("쿠버네티스 안전 조건", "check", check.name, "observed", count, "threshold", check.threshold, "blocked", result.Blocked)
+		if result.Blocked {
+			return SafetyDecision{
+				Blocked: true,
+				Reason:  fmt.Sprintf("safety check %q triggered: %d >= %d", check.name, count, check.threshold),
+				Check:   &result,
+			}, nil
+		}
+	}
+	return SafetyDecision{}, nil
+}
+
+func countPendingPods(ctx context.Context, clientSet kubernetes.Interface) (int, error) {
+	pods, err := clientSet.CoreV1().Pods(metaV1.NamespaceAll).List(ctx, metaV1.ListOptions{
+		FieldSelector: "status.phase=Pending",
+	})
+	if err != nil {
+		return 0, fmt.Errorf("Pending 파드 조회 실패: %w", err)
+	}
+	count := 0
+	for _, p := range pods.Items {
+		// surge placeholder는 용량 확보를 기다리는 동안 Pending이므로 제외합니다.
+		if p.Status.Phase == coreV1.PodPending && p.DeletionTimestamp == nil && p.Labels[surgePlaceholderLabel] != "true" {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func countUnavailableWorkloads(ctx context.Context, clientSet kubernetes.Interface) (int, error) {
+	deployments, err := clientSet.AppsV1().Deployments(metaV1.NamespaceAll).List(ctx, metaV1.ListOptions{})
+	if err != nil {
+		return 0, fmt.Errorf("Deployment 조회 실패: %w", err)
+	}
+	statefulSets, err := clientSet.AppsV1().StatefulSets(metaV1.NamespaceAll).List(ctx, metaV1.ListOptions{})
+	if err != nil {
+		return 0, fmt.Errorf("StatefulSet 조회 실패: %w", err)
+	}
+
+	count := 0
+	for _, d := range deployments.Items {
+		if desiredReplicas(d.Spec.Replicas) > d.Status.AvailableReplicas || d.Status.UnavailableReplicas > 0 {
+			count++
+		}
+	}
+	for _, s := range statefulSets.Items {
+		if desiredReplicas(s.Spec.Replicas) > s.Status.AvailableReplicas {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// desiredReplicas는 spec.replicas가 비어 있으면 기본값 1을 반환합니다.
+func desiredReplicas(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
+
+func countNotReadyNodes(ctx context.Context, clientSet kubernetes.Interface, nodepoolName string) (int, error) {
+	nodes, err := clientSet.CoreV1().Nodes().List(ctx, metaV1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", nodepoolLabel, nodepoolName),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("노드 조회 실패: %w", err)
+	}
+	count := 0
+	for _, n := range nodes.Items {
+		for _, cond := range n.Status.Conditions {
+			if cond.Type == coreV1.NodeReady && cond.Status != coreV1.ConditionTrue {
+				count++
+				break
+			}
+		}
+	}
+	return count, nil
+}
+
+// countFailedSchedulingEvents는 since 이후 FailedScheduling 발생 횟수를 반환합니다. 같은 파드의 반복 실패는 한 Event의 Count로 집계되므로 발생 횟수를 합산합니다.
+func countFailedSchedulingEvents(ctx context.Context, clientSet kubernetes.Interface, since time.Time) (int, error) {
+	events, err := clientSet.CoreV1().Events(metaV1.NamespaceAll).List(ctx, metaV1.ListOptions{
+		FieldSelector: "reason=FailedScheduling",
+	})
+	if err != nil {
+		return 0, fmt.Errorf("FailedScheduling 이벤트 조회 실패: %w", err)
+	}
+	count := 0
+	for _, e := range events.Items {
+		if e.Reason == "FailedScheduling" {
+			count += eventOccurrencesSince(e, since)
+		}
+	}
+	return count, nil
+}
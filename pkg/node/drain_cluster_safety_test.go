@@ -0,0 +1,153 @@
+package node
+
+import (
+	"app/types"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	appsV1 "k8s.io/api/apps/v1"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func int32Ptr(v int32) *int32 {
+	return &v
+}
+
+func failedSchedulingEvent(name string, lastSeen time.Time) *coreV1.Event {
+	return &coreV1.Event{
+		ObjectMeta:    metaV1.ObjectMeta{Name: name, Namespace: "default"},
+		Reason:        "FailedScheduling",
+		LastTimestamp: metaV1.NewTime(lastSeen),
+	}
+}
+
+func TestShouldBlockDrainByClusterConditions(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	notReady := newNode("test-nodepool", 2)
+	notReady.Status.Conditions = []coreV1.NodeCondition{{Type: coreV1.NodeReady, Status: coreV1.ConditionFalse}}
+
+	clientSet := fake.NewSimpleClientset(
+		&coreV1.Pod{ObjectMeta: metaV1.ObjectMeta{Name: "pending-1", Namespace: "default"}, Status: coreV1.PodStatus{Phase: coreV1.PodPending}},
+		&coreV1.Pod{ObjectMeta: metaV1.ObjectMeta{Name: "pending-2", Namespace: "default"}, Status: coreV1.PodStatus{Phase: coreV1.PodPending}},
+		&coreV1.Pod{ObjectMeta: metaV1.ObjectMeta{Name: "running", Namespace: "default"}, Status: coreV1.PodStatus{Phase: coreV1.PodRunning}},
+		&appsV1.Deployment{ObjectMeta: metaV1.ObjectMeta{Name: "api", Namespace: "default"}, Spec: appsV1.DeploymentSpec{Replicas: int32Ptr(3)}, Status: appsV1.DeploymentStatus{AvailableReplicas: 2}},
+		&appsV1.Deployment{ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: "default"}, Spec: appsV1.DeploymentSpec{Replicas: int32Ptr(2)}, Status: appsV1.DeploymentStatus{AvailableReplicas: 2}},
+		&appsV1.StatefulSet{ObjectMeta: metaV1.ObjectMeta{Name: "db", Namespace: "default"}, Spec: appsV1.StatefulSetSpec{Replicas: int32Ptr(3)}, Status: appsV1.StatefulSetStatus{AvailableReplicas: 1}},
+		newNode("test-nodepool", 1),
+		notReady,
+		failedSchedulingEvent("recent", now.Add(-5*time.Minute)),
+		failedSchedulingEvent("old", now.Add(-time.Hour)),
+	)
+
+	tests := []struct {
+		name      string
+		opts      ClusterSafetyOptions
+		blockedBy string
+		observed  float64
+	}{
+		{name: "비활성", opts: ClusterSafetyOptions{}},
+		{name: "Pending 파드 임계값 미만", opts: ClusterSafetyOptions{MaxPendingPods: 3}},
+		{name: "Pending 파드", opts: ClusterSafetyOptions{MaxPendingPods: 2}, blockedBy: "k8s-pending-pods", observed: 2},
+		{name: "가용 replica 부족 워크로드", opts: ClusterSafetyOptions{MaxUnavailableWorkloads: 2}, blockedBy: "k8s-unavailable-workloads", observed: 2},
+		{name: "NotReady 노드", opts: ClusterSafetyOptions{MaxNotReadyNodes: 1}, blockedBy: "k8s-not-ready-nodes", observed: 1},
+		{name: "최근 FailedScheduling 이벤트만 집계", opts: ClusterSafetyOptions{MaxFailedSchedulingEvents: 2, FailedSchedulingWindow: 10 * time.Minute}},
+		{name: "FailedScheduling 이벤트", opts: ClusterSafetyOptions{MaxFailedSchedulingEvents: 2, FailedSchedulingWindow: 2 * time.Hour}, blockedBy: "k8s-failed-scheduling-events", observed: 2},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			decision, err := ShouldBlockDrainByClusterConditions(context.Background(), clientSet, "test-nodepool", tt.opts, true, now)
+			assert.NoError(t, err)
+			if tt.blockedBy == "" {
+				assert.False(t, decision.Blocked)
+				return
+			}
+			assert.True(t, decision.Blocked)
+			assert.Equal(t, tt.blockedBy, decision.Check.Name)
+			assert.Equal(t, tt.observed, decision.Check.Observed)
+		})
+	}
+}
+
+func TestShouldBlockDrainByClusterConditionsFailMode(t *testing.T) {
+	clientSet := fake.NewSimpleClientset()
+	clientSet.PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("apiserver unavailable")
+	})
+	opts := ClusterSafetyOptions{MaxPendingPods: 1}
+
+	decision, err := ShouldBlockDrainByClusterConditions(context.Background(), clientSet, "test-nodepool", opts, true, time.Now())
+	assert.Error(t, err)
+	assert.True(t, decision.Blocked)
+
+	decision, err = ShouldBlockDrainByClusterConditions(context.Background(), clientSet, "test-nodepool", opts, false, time.Now())
+	assert.NoError(t, err)
+	assert.False(t, decision.Blocked)
+}
+
+func TestNodeDrainRechecksClusterConditionsBetweenNodes(t *testing.T) {
+	setDefaultDrainEnv(t)
+	t.Setenv("DRAIN_PROGRESSIVE", "true")
+	t.Setenv("DRAIN_SAFETY_MAX_FAILED_SCHEDULING_EVENTS", "1")
+
+	clientSet := fake.NewSimpleClientset()
+	nodepoolName := "test-nodepool"
+	for i := 1; i <= 4; i++ {
+		if _, err := clientSet.CoreV1().Nodes().Create(context.Background(), newNode(nodepoolName, i), metaV1.CreateOptions{}); err != nil {
+			t.Fatalf("노드 생성 실패: %v", err)
+		}
+	}
+
+	// 실행 전에는 이벤트가 없고, 첫 노드 드레인 후 FailedScheduling 이벤트가 발생
+	calls := 0
+	clientSet.PrependReactor("list", "events", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		calls++
+		list := &coreV1.EventList{}
+		if calls > 1 {
+			for i := 0; i < 3; i++ {
+				list.Items = append(list.Items, *failedSchedulingEvent(fmt.Sprintf("event-%d", i), time.Now()))
+			}
+		}
+		return true, list, nil
+	})
+
+	var blocked []types.SafetyCheckResult
+	results, err := NodeDrain(context.Background(), clientSet, DrainDependencies{
+		AllocateRateProvider: fakeAllocateRateProvider{rates: map[string]int{"memory": 30, "cpu": 25}},
+		Notifier:             fakeSafetyNotifier{blocked: &blocked},
+	}, DrainConfig{
+		NodepoolName: nodepoolName,
+		Eviction:     testEvictionConfig(),
+	})
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+	if assert.Len(t, blocked, 1) {
+		assert.Equal(t, "k8s-failed-scheduling-events", blocked[0].Name)
+		assert.Equal(t, 3.0, blocked[0].Observed)
+	}
+}
+
+func TestCountFailedSchedulingEventsSumsOccurrences(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	aggregated := failedSchedulingEvent("aggregated", now.Add(-time.Minute))
+	aggregated.Count = 5
+	aggregated.FirstTimestamp = metaV1.NewTime(now.Add(-8 * time.Minute))
+	longRunning := failedSchedulingEvent("long-running", now.Add(-time.Minute))
+	longRunning.Count = 20
+	longRunning.FirstTimestamp = metaV1.NewTime(now.Add(-100 * time.Minute))
+	clientSet := fake.NewSimpleClientset(aggregated, longRunning, failedSchedulingEvent("single", now.Add(-2*time.Minute)))
+
+	// aggregated 5회 + long-running은 10분 window 비율만큼 2회 + single 1회
+	count, err := countFailedSchedulingEvents(context.Background(), clientSet, now.Add(-10*time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, 8, count)
+}
@@ -0,0 +1,69 @@
+package node
+
+import (
+	"app/pkg/karpenter"
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+)
+
+// requestForecastProvider는 과거 같은 시간대 이력 기반 request 예측을 지원하는 provider입니다. (karpenter.Client)
+type requestForecastProvider interface {
+	GetRequestForecast(ctx context.Context, resourceType string, horizon time.Duration, period time.Duration, lookback int) (karpenter.RequestForecast, error)
+}
+
+// allocateForecast는 forecast 정책에서 리소스별 향후 horizon 동안의 예상 최대 사용률입니다.
+type allocateForecast struct {
+	horizon   time.Duration
+	forecasts map[string]karpenter.RequestForecast
+	// predict 이후 채워짐
+	current      map[string]int
+	predicted    map[string]int
+	predictedMax int
+	bottleneck   string
+}
+
+// loadAllocateForecast는 리소스별로 과거 같은 시간대의 request 증가 배율을 조회합니다.
+func loadAllocateForecast(ctx context.Context, provider allocateRateProvider, opts DrainPolicyOptions) (*allocateForecast, error) {
+	forecastProvider, ok := provider.(requestForecastProvider)
+	if !ok {
+		return nil, fmt.Errorf("forecast 정책을 지원하지 않는 allocate rate provider 입니다")
+	}
+
+	f := &allocateForecast{
+		horizon:   opts.ForecastHorizon,
+		forecasts: make(map[string]karpenter.RequestForecast, len(opts.AllocateResources)),
+	}
+	for _, r := range opts.AllocateResources {
+		forecast, err := forecastProvider.GetRequestForecast(ctx, r.Name, opts.ForecastHorizon, opts.ForecastPeriod, opts.ForecastLookback)
+		if err != nil {
+			return nil, fmt.Errorf("%s forecast 조회 실패: %w", r.Name, err)
+		}
+		if len(forecast.Samples) == 0 {
+			slog.Warn("forecast 이력이 없어 현재 사용률을 그대로 사용합니다.", "resource", r.Name)
+		}
+		for _, s := range forecast.Samples {
+			slog.Info("forecast 입력", "resource", r.Name, "offset", s.Offset.String(), "request", s.Request, "peakRequest", s.PeakRequest, "growth", roundScore(s.Growth()))
+		}
+		f.forecasts[r.Name] = forecast
+	}
+	return f, nil
+}
+
+// predict는 현재 사용률에 리소스별 최대 증가 배율을 곱해 예상 최대 사용률을 계산하고, 병목 리소스의 값을 반환합니다.
+func (f *allocateForecast) predict(rates map[string]int, resources []AllocateResource) int {
+	f.current = rates
+	f.predicted = make(map[string]int, len(rates))
+	for name, rate := range rates {
+		growth := 1.0
+		if forecast, ok := f.forecasts[name]; ok {
+			growth = math.Max(growth, forecast.PeakGrowth)
+		}
+		f.predicted[name] = int(math.Round(float64(rate) * growth))
+	}
+	f.predictedMax, f.bottleneck = bottleneckAllocateRate(f.predicted, resources)
+	slog.Info("forecast 예상 최대 사용률", "horizon", f.horizon.String(), "currentAllocateRates", rates, "predictedAllocateRates", f.predicted, "predictedMaxAllocateRate", f.predictedMax, "bottleneck", f.bottleneck)
+	return f.predictedMax
+}
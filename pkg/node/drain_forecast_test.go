@@ -0,0 +1,105 @@
+package node
+
+import (
+	"app/pkg/karpenter"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+type fakeForecastProvider struct {
+	fakeAllocateRateProvider
+	growth map[string]float64
+	err    error
+}
+
+func (f fakeForecastProvider) GetRequestForecast(ctx context.Context, resourceType string, horizon time.Duration, period time.Duration, lookback int) (karpenter.RequestForecast, error) {
+	if f.err != nil {
+		return karpenter.RequestForecast{}, f.err
+	}
+	growth, ok := f.growth[resourceType]
+	if !ok {
+		return karpenter.RequestForecast{Horizon: horizon, Period: period, PeakGrowth: 1}, nil
+	}
+	return karpenter.RequestForecast{
+		Horizon:    horizon,
+		Period:     period,
+		Samples:    []karpenter.ForecastSample{{Offset: period, Request: 100, PeakRequest: 100 * growth}},
+		PeakGrowth: growth,
+	}, nil
+}
+
+func TestAllocateForecastPredict(t *testing.T) {
+	opts := DrainPolicyOptions{AllocateResources: defaultAllocateResources, ForecastHorizon: 4 * time.Hour, ForecastPeriod: 24 * time.Hour, ForecastLookback: 7}
+	provider := fakeForecastProvider{growth: map[string]float64{"memory": 1.2, "cpu": 2}}
+
+	forecast, err := loadAllocateForecast(context.Background(), provider, opts)
+	assert.NoError(t, err)
+
+	// memory 50 -> 60, cpu 40 -> 80 이므로 cpu가 병목
+	predicted := forecast.predict(map[string]int{"memory": 50, "cpu": 40}, opts.AllocateResources)
+	assert.Equal(t, 80, predicted)
+	assert.Equal(t, "cpu", forecast.bottleneck)
+	assert.Equal(t, map[string]int{"memory": 60, "cpu": 80}, forecast.predicted)
+
+	// forecast를 지원하지 않는 provider
+	_, err = loadAllocateForecast(context.Background(), fakeAllocateRateProvider{}, opts)
+	assert.Error(t, err)
+}
+
+func TestNodeDrainForecastPolicy(t *testing.T) {
+	tests := []struct {
+		name          string
+		growth        map[string]float64
+		err           error
+		failClosed    bool
+		expectedDrain int
+		stopReason    string
+	}{
+		{name: "증가 이력 없으면 현재 사용률과 동일", expectedDrain: 2},
+		{name: "2배 증가 예상", growth: map[string]float64{"memory": 2}, expectedDrain: 1},
+		{name: "3배 증가 예상이면 드레인 없음", growth: map[string]float64{"cpu": 3.2}, expectedDrain: 0},
+		{name: "조회 실패 + fail-open 이면 현재 사용률 사용", err: errors.New("prometheus down"), expectedDrain: 2},
+		{name: "조회 실패 + fail-closed 이면 드레인 없음", err: errors.New("prometheus down"), failClosed: true, expectedDrain: 0, stopReason: "forecast fail-closed: memory forecast 조회 실패: prometheus down"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			setDefaultDrainEnv(t)
+			t.Setenv("DRAIN_POLICY", "forecast")
+			t.Setenv("DRAIN_SAFETY_FAIL_CLOSED", "false")
+			if tt.failClosed {
+				t.Setenv("DRAIN_SAFETY_FAIL_CLOSED", "true")
+			}
+
+			clientSet := fake.NewSimpleClientset()
+			nodepoolName := "test-nodepool"
+			for i := 1; i <= 4; i++ {
+				if _, err := clientSet.CoreV1().Nodes().Create(context.Background(), newNode(nodepoolName, i), metaV1.CreateOptions{}); err != nil {
+					t.Fatalf("노드 생성 실패: %v", err)
+				}
+			}
+
+			results, summary, err := NodeDrainWithSummary(context.Background(), clientSet, DrainDependencies{
+				AllocateRateProvider: fakeForecastProvider{
+					fakeAllocateRateProvider: fakeAllocateRateProvider{rates: map[string]int{"memory": 30, "cpu": 25}},
+					growth:                   tt.growth,
+					err:                      tt.err,
+				},
+				Notifier: fakeNotifier{},
+			}, DrainConfig{
+				NodepoolName: nodepoolName,
+				Eviction:     testEvictionConfig(),
+			})
+			assert.NoError(t, err)
+			assert.Len(t, results, tt.expectedDrain)
+			assert.Equal(t, tt.stopReason, summary.StopSafetyReason)
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// PacingOptions는 노드 사이 대기 시간을 고정값(PostEvictionNodeDelay) 대신 재스케줄링 상황에 맞춰 조절하는 옵션입니다.
+type PacingOptions struct {
+	Enabled        bool
+	MinDelay       time.Duration // 클러스터가 빨리 안정돼도 최소한 기다리는 시간
+	MaxDelay       time.Duration // 클러스터가 느려도 최대로 기다리는 시간
+	Interval       time.Duration // 재스케줄링 상태 확인 주기
+	MaxPendingPods int           // 드레인한 워크로드의 Pending 파드가 이 수 이하이면 안정된 것으로 판단
+}
+
+// GetPacingOptionsFromEnv는 적응형 pacing 관련 환경 변수를 파싱합니다. 기본값은 비활성입니다.
+func GetPacingOptionsFromEnv() PacingOptions {
+	opts := PacingOptions{
+		Enabled:        parseEnvBool("DRAIN_ADAPTIVE_PACING", false),
+		MinDelay:       parseEnvDuration("DRAIN_PACING_MIN_DELAY", 10*time.Second),
+		MaxDelay:       parseEnvDuration("DRAIN_PACING_MAX_DELAY", 5*time.Minute),
+		Interval:       parseEnvDuration("DRAIN_PACING_INTERVAL", 5*time.Second),
+		MaxPendingPods: parseEnvInt("DRAIN_PACING_MAX_PENDING_PODS", 0),
+	}
+	if opts.MinDelay < 0 {
+		opts.MinDelay = 0
+	}
+	if opts.MaxDelay < opts.MinDelay {
+		opts.MaxDelay = opts.MinDelay
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = 5 * time.Second
+	}
+	if opts.MaxPendingPods < 0 {
+		opts.MaxPendingPods = 0
+	}
+	return opts
+}
+
+// waitAdaptivePacing은 드레인한 노드의 파드가 다시 스케줄링되어 Ready가 될 때까지(= 워크로드 available, 그 워크로드의 Pending 파드 임계값 이하) 기다립니다.
+// 드레인과 무관한 워크로드의 Pending 파드는 세지 않습니다.
+// 대기 시간은 MinDelay 이상, MaxDelay 이하이며, 클러스터가 느리면 길어지고 빨리 회복하면 짧아집니다. 실제로 기다린 시간을 반환합니다.
+func waitAdaptivePacing(ctx context.Context, clientSet kubernetes.Interface, nodeName string, workloads []workloadRef, opts PacingOptions) time.Duration {
+	start := time.Now()
+	deadline := time.NewTimer(opts.MaxDelay)
+	defer deadline.Stop()
+	ticker := time.NewTicker(opts.Interval)
+	defer ticker.Stop()
+
+	var settledAfter time.Duration
+	for {
+		pending, err := countPendingWorkloadPods(ctx, clientSet, workloads)
+		if err != nil {
+			slog.Warn("pacing 중 Pending 파드 조회 실패(재시도)", "nodeName", nodeName, "error", err)
+		}
+		notReady := unavailableWorkloads(ctx, clientSet, workloads)
+		if err == nil && pending <= opts.MaxPendingPods && len(notReady) == 0 {
+			settledAfter = time.Since(start)
+			break
+		}
+		slog.Info("재스케줄링 대기 중", "nodeName", nodeName, "pendingPods", pending, "remaining", workloadNames(notReady))
+
+		select {
+		case <-ctx.Done():
+			return time.Since(start)
+		case <-deadline.C:
+			slog.Warn("재스케줄링이 최대 대기 시간 안에 끝나지 않았습니다(다음 노드 진행).", "nodeName", nodeName, "maxDelay", opts.MaxDelay.String())
+			return time.Since(start)
+		case <-ticker.C:
+		}
+	}
+
+	// 빨리 안정됐어도 MinDelay까지는 기다립니다.
+	if remaining := opts.MinDelay - settledAfter; remaining > 0 {
+		timer := time.NewTimer(remaining)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return time.Since(start)
+		case <-timer.C:
+		}
+	}
+
+	waited := time.Since(start)
+	slog.Info("적응형 pacing 대기 완료", "nodeName", nodeName, "rescheduleLatency", settledAfter.String(), "delay", waited.String())
+	return waited
+}
+
+// countPendingWorkloadPods는 workloads가 소유한 Pending 파드 수를 셉니다.
+func countPendingWorkloadPods(ctx context.Context, clientSet kubernetes.Interface, workloads []workloadRef) (int, error) {
+	owned := make(map[workloadRef]bool, len(workloads))
+	namespaces := make(map[string]bool)
+	for _, ref := range workloads {
+		owned[ref] = true
+		namespaces[ref.namespace] = true
+	}
+
+	count := 0
+	for namespace := range namespaces {
+		pods, err := clientSet.CoreV1().Pods(namespace).List(ctx, metaV1.ListOptions{
+			FieldSelector: "status.phase=Pending",
+		})
+		if err != nil {
+			return 0, fmt.Errorf("Pending 파드 조회 실패: %w", err)
+		}
+		for _, p := range pods.Items {
+			if p.Status.Phase != coreV1.PodPending || p.DeletionTimestamp != nil {
+				continue
+			}
+			if ref, ok := podWorkload(ctx, clientSet, p); ok && owned[ref] {
+				count++
+			}
+		}
+	}
+	return count, nil
+}
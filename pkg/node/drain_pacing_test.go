@@ -0,0 +1,135 @@
+package node
+
+import (
+	"app/pkg/pod"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// pendingPod는 Deployment api(ReplicaSet api-abc)가 소유한 Pending 파드입니다.
+func pendingPod(name string) *coreV1.Pod {
+	return &coreV1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: "default", OwnerReferences: controllerRef("ReplicaSet", "api-abc")},
+		Status:     coreV1.PodStatus{Phase: coreV1.PodPending},
+	}
+}
+
+func TestGetPacingOptionsFromEnv(t *testing.T) {
+	t.Setenv("DRAIN_ADAPTIVE_PACING", "")
+	t.Setenv("DRAIN_PACING_MIN_DELAY", "")
+	t.Setenv("DRAIN_PACING_MAX_DELAY", "")
+	t.Setenv("DRAIN_PACING_INTERVAL", "")
+	t.Setenv("DRAIN_PACING_MAX_PENDING_PODS", "")
+	assert.Equal(t, PacingOptions{
+		MinDelay: 10 * time.Second,
+		MaxDelay: 5 * time.Minute,
+		Interval: 5 * time.Second,
+	}, GetPacingOptionsFromEnv())
+
+	// 최대 대기 시간이 최소보다 작으면 최소값으로 맞춥니다.
+	t.Setenv("DRAIN_PACING_MIN_DELAY", "1m")
+	t.Setenv("DRAIN_PACING_MAX_DELAY", "30s")
+	assert.Equal(t, time.Minute, GetPacingOptionsFromEnv().MaxDelay)
+}
+
+func TestWaitAdaptivePacing(t *testing.T) {
+	opts := PacingOptions{Enabled: true, MinDelay: 20 * time.Millisecond, MaxDelay: 200 * time.Millisecond, Interval: 5 * time.Millisecond}
+	refs := []workloadRef{{kind: "Deployment", namespace: "default", name: "api"}}
+
+	// 빨리 안정되면 최소 대기 시간만 기다림
+	waited := waitAdaptivePacing(context.Background(), fake.NewSimpleClientset(workloadObjects(2)...), "node-1", refs, opts)
+	assert.GreaterOrEqual(t, waited, opts.MinDelay)
+	assert.Less(t, waited, opts.MaxDelay)
+
+	// 워크로드가 복구되지 않으면 최대 대기 시간까지 기다림
+	waited = waitAdaptivePacing(context.Background(), fake.NewSimpleClientset(workloadObjects(1)...), "node-1", refs, opts)
+	assert.GreaterOrEqual(t, waited, opts.MaxDelay)
+
+	// Pending 파드가 임계값을 넘으면 최대 대기 시간까지 기다림
+	objects := append(workloadObjects(2), pendingPod("pending-1"), pendingPod("pending-2"))
+	waited = waitAdaptivePacing(context.Background(), fake.NewSimpleClientset(objects...), "node-1", refs, opts)
+	assert.GreaterOrEqual(t, waited, opts.MaxDelay)
+
+	opts.MaxPendingPods = 2
+	waited = waitAdaptivePacing(context.Background(), fake.NewSimpleClientset(objects...), "node-1", refs, opts)
+	assert.Less(t, waited, opts.MaxDelay)
+}
+
+func TestWaitAdaptivePacingIgnoresUnrelatedPendingPods(t *testing.T) {
+	opts := PacingOptions{Enabled: true, MinDelay: 20 * time.Millisecond, MaxDelay: 200 * time.Millisecond, Interval: 5 * time.Millisecond}
+	refs := []workloadRef{{kind: "Deployment", namespace: "default", name: "api"}}
+
+	// 드레인한 워크로드와 무관한 Pending 파드(다른 워크로드, 소유자 없음)는 기다리지 않음
+	unrelated := &coreV1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{Name: "batch-0", Namespace: "default", OwnerReferences: controllerRef("StatefulSet", "batch")},
+		Status:     coreV1.PodStatus{Phase: coreV1.PodPending},
+	}
+	standalone := &coreV1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{Name: "debug", Namespace: "default"},
+		Status:     coreV1.PodStatus{Phase: coreV1.PodPending},
+	}
+	objects := append(workloadObjects(2), unrelated, standalone)
+	waited := waitAdaptivePacing(context.Background(), fake.NewSimpleClientset(objects...), "node-1", refs, opts)
+	assert.GreaterOrEqual(t, waited, opts.MinDelay)
+	assert.Less(t, waited, opts.MaxDelay)
+
+	count, err := countPendingWorkloadPods(context.Background(), fake.NewSimpleClientset(append(objects, pendingPod("api-abc-2"))...), refs)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestWaitAdaptivePacingRecovers(t *testing.T) {
+	opts := PacingOptions{Enabled: true, MaxDelay: 2 * time.Second, Interval: 5 * time.Millisecond}
+	refs := []workloadRef{{kind: "Deployment", namespace: "default", name: "api"}}
+	clientSet := fake.NewSimpleClientset(append(workloadObjects(2), pendingPod("pending-1"))...)
+
+	// Pending 파드가 스케줄링되면 최대 대기 시간 전에 끝남
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		p := pendingPod("pending-1")
+		p.Status.Phase = coreV1.PodRunning
+		_, _ = clientSet.CoreV1().Pods("default").UpdateStatus(context.Background(), p, metaV1.UpdateOptions{})
+	}()
+	waited := waitAdaptivePacing(context.Background(), clientSet, "node-1", refs, opts)
+	assert.GreaterOrEqual(t, waited, 50*time.Millisecond)
+	assert.Less(t, waited, opts.MaxDelay)
+}
+
+func TestNodeDrainWithAdaptivePacing(t *testing.T) {
+	setDefaultDrainEnv(t)
+	t.Setenv("DRAIN_ADAPTIVE_PACING", "true")
+	t.Setenv("DRAIN_PACING_MIN_DELAY", "30ms")
+	t.Setenv("DRAIN_PACING_MAX_DELAY", "1s")
+	t.Setenv("DRAIN_PACING_INTERVAL", "5ms")
+
+	nodepoolName := "test-nodepool"
+	objects := workloadObjects(2)
+	for i := 1; i <= 4; i++ {
+		objects = append(objects, newNode(nodepoolName, i))
+	}
+	clientSet := fake.NewSimpleClientset(objects...)
+
+	evictionCfg := testEvictionConfig()
+	evictionCfg.EvictionMode = pod.EvictionModeDelete
+	evictionCfg.PostEvictionNodeDelay = time.Hour
+	start := time.Now()
+	results, err := NodeDrain(context.Background(), clientSet, DrainDependencies{
+		AllocateRateProvider: fakeAllocateRateProvider{rates: map[string]int{"memory": 30, "cpu": 25}},
+		Notifier:             fakeNotifier{},
+	}, DrainConfig{
+		NodepoolName: nodepoolName,
+		Eviction:     evictionCfg,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+	// 고정 대기(1시간) 대신 적응형 pacing으로 첫 노드 뒤에만 최소 대기 시간만큼 기다립니다.
+	elapsed := time.Since(start)
+	assert.GreaterOrEqual(t, elapsed, 30*time.Millisecond)
+	assert.Less(t, elapsed, 10*time.Second)
+}
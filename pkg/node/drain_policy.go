@@ -1,7 +1,9 @@
 package node
 
 import (
-	"app/config"
+	"app/pkg/karpenter"
+	"app/types"
+	"context"
 	"fmt"
 	"log/slog"
 	"math"
@@ -9,8 +11,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
-
-	prometheusModel "github.com/prometheus/common/model"
+	"time"
 )
 
 type DrainPolicy string
@@ -18,6 +19,10 @@ type DrainPolicy string
 const (
 	DrainPolicyFormula DrainPolicy = "formula"
 	DrainPolicyStep    DrainPolicy = "step"
+	// DrainPolicyTarget은 노드별 allocatable로 드레인 후 예상 사용률이 TargetAllocateRate 미만이 되도록 후보를 고릅니다.
+	DrainPolicyTarget DrainPolicy = "target"
+	// DrainPolicyForecast는 과거 같은 시간대 이력으로 향후 ForecastHorizon 동안의 최대 사용률을 예측해 계산식에 사용합니다.
+	DrainPolicyForecast DrainPolicy = "forecast"
 )
 
 type DrainRounding string
@@ -34,15 +39,28 @@ type StepRule struct {
 }
 
 type DrainPolicyOptions struct {
-	Policy                DrainPolicy
-	Rounding              DrainRounding
-	MinDrain              int
-	MaxDrainAbsolute      int     // 0 이면 비활성
-	MaxDrainFraction      float64 // 0 이면 비활성 (예: 0.2 = 최대 20%)
-	StepRules             []StepRule
-	SafetyMaxAllocateRate int      // 0 이면 비활성 (예: 90이면 maxAllocateRate>=90일 때 0대로 강제)
-	SafetyQueries         []string // PromQL; 하나라도 결과가 >0이면 0대로 강제
-	SafetyFailClosed      bool     // safety query 실패 시 0대로 강제할지
+	Policy                  DrainPolicy
+	Rounding                DrainRounding
+	MinDrain                int
+	MaxDrainAbsolute        int     // 0 이면 비활성
+	MaxDrainFraction        float64 // 0 이면 비활성 (예: 0.2 = 최대 20%)
+	MaxDrainPerZone         int     // 0 이면 비활성 (topology.kubernetes.io/zone 별 최대 드레인 노드 수)
+	MaxDrainFractionPerZone float64 // 0 이면 비활성 (존 내 노드 수 대비 최대 비율)
+	ZoneInterleave          bool    // 후보 정렬 시 존을 번갈아 배치할지
+	StepRules               []StepRule
+	TargetAllocateRate      int                // target 정책: 드레인 후 예상 사용률(%)이 이 값 미만이 되도록 유지
+	AllocateResources       []AllocateResource // allocate rate를 계산할 리소스 (가중치 적용 후 가장 높은 리소스가 병목)
+	ForecastHorizon         time.Duration      // forecast 정책: 예측할 향후 기간
+	ForecastPeriod          time.Duration      // forecast 정책: 과거 같은 시간대 간격 (24h=일, 168h=주)
+	ForecastLookback        int                // forecast 정책: 과거 몇 주기를 볼지
+	HysteresisThreshold     int                // 0 이면 비활성. 최근 HysteresisWindow 동안 사용률 최대값이 이 값 미만이어야 드레인
+	HysteresisWindow        time.Duration      // hysteresis 판단 기간
+	SafetyMaxAllocateRate   int                // 0 이면 비활성 (예: 90이면 maxAllocateRate>=90일 때 0대로 강제)
+	SafetyQueries           []string           // PromQL; 하나라도 결과가 >0이면 0대로 강제 (SafetyChecks로 변환됨)
+	SafetyChecks            []SafetyCheck      // 이름이 있는 안전 조건 (DRAIN_SAFETY_QUERIES + DRAIN_SAFETY_CHECKS)
+	SafetyChecksErr         error              // DRAIN_SAFETY_CHECKS 파싱 오류 (있으면 SafetyFailClosed에 따라 차단)
+	AllocateResourcesErr    error              // DRAIN_ALLOCATE_RESOURCES 파싱 오류 (있으면 드레인하지 않고 오류로 종료)
+	SafetyFailClosed        bool               // safety query 실패 시 0대로 강제할지 (안전 조건별 failMode 기본값)
 }
 
 // GetDrainPolicyOptionsFromEnv는 drain 정책 관련 환경 변수를 파싱합니다.
@@ -59,11 +77,18 @@ func GetDrainPolicyOptionsFromEnv() DrainPolicyOptions {
 		// 기존 동작은 allocate rate로만 결정하므로, 안전 조건은 기본 비활성
 		SafetyMaxAllocateRate: 0,
 		SafetyFailClosed:      true, // safety query를 쓰는 경우엔 보수적으로
+		ZoneInterleave:        true,
+		TargetAllocateRate:    80,
+		AllocateResources:     append([]AllocateResource(nil), defaultAllocateResources...),
+		HysteresisWindow:      time.Hour,
+		ForecastHorizon:       4 * time.Hour,
+		ForecastPeriod:        24 * time.Hour,
+		ForecastLookback:      7,
 	}
 
 	if v := strings.TrimSpace(os.Getenv("DRAIN_POLICY")); v != "" {
 		switch DrainPolicy(strings.ToLower(v)) {
-		case DrainPolicyFormula, DrainPolicyStep:
+		case DrainPolicyFormula, DrainPolicyStep, DrainPolicyTarget, DrainPolicyForecast:
 			opts.Policy = DrainPolicy(strings.ToLower(v))
 		}
 	}
@@ -80,6 +105,15 @@ func GetDrainPolicyOptionsFromEnv() DrainPolicyOptions {
 	opts.SafetyMaxAllocateRate = parseEnvInt("DRAIN_SAFETY_MAX_ALLOCATE_RATE", opts.SafetyMaxAllocateRate)
 
 	opts.MaxDrainFraction = parseEnvFloat("DRAIN_MAX_FRACTION", opts.MaxDrainFraction)
+	opts.MaxDrainPerZone = parseEnvInt("DRAIN_MAX_PER_ZONE", opts.MaxDrainPerZone)
+	opts.MaxDrainFractionPerZone = parseEnvFloat("DRAIN_MAX_FRACTION_PER_ZONE", opts.MaxDrainFractionPerZone)
+	opts.ZoneInterleave = parseEnvBool("DRAIN_ZONE_INTERLEAVE", opts.ZoneInterleave)
+	opts.TargetAllocateRate = parseEnvInt("DRAIN_TARGET_ALLOCATE_RATE", opts.TargetAllocateRate)
+	opts.ForecastHorizon = parseEnvDuration("DRAIN_FORECAST_HORIZON", opts.ForecastHorizon)
+	opts.ForecastPeriod = parseEnvDuration("DRAIN_FORECAST_PERIOD", opts.ForecastPeriod)
+	opts.ForecastLookback = parseEnvInt("DRAIN_FORECAST_LOOKBACK", opts.ForecastLookback)
+	opts.HysteresisThreshold = parseEnvInt("DRAIN_HYSTERESIS_THRESHOLD", opts.HysteresisThreshold)
+	opts.HysteresisWindow = parseEnvDuration("DRAIN_HYSTERESIS_WINDOW", opts.HysteresisWindow)
 
 	if v := strings.TrimSpace(os.Getenv("DRAIN_SAFETY_FAIL_CLOSED")); v != "" {
 		if b, err := strconv.ParseBool(v); err == nil {
@@ -93,9 +127,23 @@ func GetDrainPolicyOptionsFromEnv() DrainPolicyOptions {
 		}
 	}
 
+	if resources, err := GetAllocateResourcesFromEnv(); err != nil {
+		slog.Error("사용률 리소스(DRAIN_ALLOCATE_RESOURCES) 파싱 실패", "error", err)
+		opts.AllocateResourcesErr = err
+	} else {
+		opts.AllocateResources = resources
+	}
+
 	if v := strings.TrimSpace(os.Getenv("DRAIN_SAFETY_QUERIES")); v != "" {
 		opts.SafetyQueries = splitQueries(v)
 	}
+	opts.SafetyChecks = legacySafetyChecks(opts.SafetyQueries, opts.SafetyFailClosed)
+	if checks, err := loadSafetyChecks(opts.SafetyFailClosed); err != nil {
+		slog.Error("안전 조건(DRAIN_SAFETY_CHECKS) 파싱 실패", "error", err)
+		opts.SafetyChecksErr = err
+	} else {
+		opts.SafetyChecks = append(opts.SafetyChecks, checks...)
+	}
 
 	return opts
 }
@@ -124,6 +172,30 @@ func parseEnvFloat(key string, defaultValue float64) float64 {
 	return f
 }
 
+func parseEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	v := strings.TrimSpace(os.Getenv(key))
+	if v == "" {
+		return defaultValue
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return defaultValue
+	}
+	return d
+}
+
+// splitList는 콤마/개행 구분 목록을 파싱합니다.
+func splitList(s string) []string {
+	s = strings.ReplaceAll(s, "\n", ",")
+	var out []string
+	for _, p := range strings.Split(s, ",") {
+		if v := strings.TrimSpace(p); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
 func splitQueries(s string) []string {
 	// 세미콜론/개행 구분 지원
 	s = strings.ReplaceAll(s, "\n", ";")
@@ -170,51 +242,41 @@ func parseStepRules(s string) ([]StepRule, error) {
 }
 
 // ShouldBlockDrainBySafetyConditions는 안전 조건에 의해 0대 드레인을 강제해야 하는지 판단합니다.
-func ShouldBlockDrainBySafetyConditions(maxAllocateRate int, opts DrainPolicyOptions) (bool, string, error) {
+// querier가 nil 이면 환경 변수 기반 Prometheus 클라이언트를 사용합니다.
+func ShouldBlockDrainBySafetyConditions(ctx context.Context, querier karpenter.MetricsQuerier, maxAllocateRate int, opts DrainPolicyOptions) (SafetyDecision, error) {
 	if opts.SafetyMaxAllocateRate > 0 && maxAllocateRate >= opts.SafetyMaxAllocateRate {
-		return true, fmt.Sprintf("maxAllocateRate(%d) >= safetyMaxAllocateRate(%d)", maxAllocateRate, opts.SafetyMaxAllocateRate), nil
-	}
-
-	if len(opts.SafetyQueries) == 0 {
-		return false, "", nil
+		return SafetyDecision{Blocked: true, Reason: fmt.Sprintf("maxAllocateRate(%d) >= safetyMaxAllocateRate(%d)", maxAllocateRate, opts.SafetyMaxAllocateRate)}, nil
 	}
 
-	promClient, err := config.CreatePrometheusClient()
-	if err != nil {
+	if opts.SafetyChecksErr != nil {
 		if opts.SafetyFailClosed {
-			return true, "prometheus client init failed (fail-closed)", err
+			return SafetyDecision{Blocked: true, Reason: "invalid safety checks (fail-closed)"}, opts.SafetyChecksErr
 		}
-		return false, "prometheus client init failed (fail-open)", err
+		slog.Warn("안전 조건 설정 오류(무시하고 진행)", "error", opts.SafetyChecksErr)
 	}
 
-	for _, q := range opts.SafetyQueries {
-		vec, qErr := config.QueryPrometheus(promClient, q)
-		if qErr != nil {
+	if len(opts.SafetyChecks) == 0 {
+		return SafetyDecision{}, nil
+	}
+
+	if querier == nil {
+		q, err := defaultSafetyQuerier()
+		if err != nil {
 			if opts.SafetyFailClosed {
-				return true, fmt.Sprintf("safety query failed (fail-closed): %s", q), qErr
+				return SafetyDecision{Blocked: true, Reason: "prometheus client init failed (fail-closed)"}, err
 			}
-			slog.Warn("안전 조건 쿼리 실패(무시하고 진행)", "query", q, "error", qErr)
-			continue
-		}
-		if prometheusVectorHasPositive(vec) {
-			return true, fmt.Sprintf("safety query triggered: %s", q), nil
+			return SafetyDecision{Reason: "prometheus client init failed (fail-open)"}, err
 		}
+		querier = q
 	}
 
-	return false, "", nil
-}
-
-func prometheusVectorHasPositive(vec prometheusModel.Vector) bool {
-	for _, s := range vec {
-		if float64(s.Value) > 0 {
-			return true
-		}
-	}
-	return false
+	return EvaluateSafetyChecks(ctx, querier, opts.SafetyChecks)
 }
 
 // CalculateDrainNodeCount는 정책/라운딩/클램프를 적용해 최종 드레인 대상 노드 수를 계산합니다.
-func CalculateDrainNodeCount(lenNodes int, maxAllocateRate int, opts DrainPolicyOptions) int {
+// budget이 있으면 기간 내 남은 드레인 예산도 상한으로 적용합니다.
+// disrupting은 Karpenter가 이미 중단 중인 노드 수이며, 동시 중단 상한(노드 수/비율/절대값)에서 뺍니다.
+func CalculateDrainNodeCount(lenNodes int, maxAllocateRate int, opts DrainPolicyOptions, budget *types.DrainBudgetStatus, disrupting int) int {
 	if lenNodes <= 0 {
 		return 0
 	}
@@ -223,7 +285,11 @@ func CalculateDrainNodeCount(lenNodes int, maxAllocateRate int, opts DrainPolicy
 	switch opts.Policy {
 	case DrainPolicyStep:
 		base = stepPolicyCount(maxAllocateRate, opts.StepRules)
+	case DrainPolicyTarget:
+		// 실제 대상은 후보 선택 단계에서 노드별 allocatable 기준으로 제한하므로 여기서는 상한만 적용
+		base = lenNodes
 	default:
+		// formula, forecast(maxAllocateRate에 예상 최대 사용률이 들어옴)
 		base = formulaPolicyCount(lenNodes, maxAllocateRate, opts)
 	}
 
@@ -236,7 +302,7 @@ func CalculateDrainNodeCount(lenNodes int, maxAllocateRate int, opts DrainPolicy
 	}
 
 	// 상한(퍼센트/절대) 적용
-	base = applyCaps(lenNodes, base, opts)
+	base = applyCaps(lenNodes, base, opts, budget, disrupting)
 
 	return clampInt(base, 0, lenNodes)
 }
@@ -287,7 +353,7 @@ func formulaPolicyCount(lenNodes int, maxAllocateRate int, opts DrainPolicyOptio
 	return base
 }
 
-func applyCaps(lenNodes int, base int, opts DrainPolicyOptions) int {
+func applyCaps(lenNodes int, base int, opts DrainPolicyOptions, budget *types.DrainBudgetStatus, disrupting int) int {
 	if base <= 0 {
 		return 0
 	}
@@ -309,6 +375,17 @@ func applyCaps(lenNodes int, base int, opts DrainPolicyOptions) int {
 		capValue = opts.MaxDrainAbsolute
 	}
 
+	// Karpenter가 이미 중단 중인 노드도 동시 중단 상한을 사용
+	if disrupting > 0 {
+		slog.Info("Karpenter 중단 중인 노드 수만큼 드레인 상한 차감", "disrupting", disrupting, "cap", capValue, "adjusted", capValue-disrupting)
+		capValue -= disrupting
+	}
+
+	// 여러 실행에 걸친 예산: 기간 내 이미 드레인한 수를 뺀 만큼만 허용
+	if budget != nil && budget.MaxNodes > 0 && budget.Remaining < capValue {
+		capValue = budget.Remaining
+	}
+
 	if capValue < 0 {
 		capValue = 0
 	}
@@ -1,6 +1,7 @@
 package node
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -23,10 +24,10 @@ func TestCalculateDrainNodeCount_Formula_Floor_DefaultBehavior(t *testing.T) {
 	}
 
 	// lenNodes=8, max=63 => drainRate=(99-63)/100=0.36 => floor(2.88)=2
-	assert.Equal(t, 2, CalculateDrainNodeCount(8, 63, opts))
+	assert.Equal(t, 2, CalculateDrainNodeCount(8, 63, opts, nil, 0))
 
 	// lenNodes=8, max=90 => 0.09 => floor(0.72)=0
-	assert.Equal(t, 0, CalculateDrainNodeCount(8, 90, opts))
+	assert.Equal(t, 0, CalculateDrainNodeCount(8, 90, opts, nil, 0))
 }
 
 func TestCalculateDrainNodeCount_Formula_Round_MinDrain(t *testing.T) {
@@ -37,7 +38,7 @@ func TestCalculateDrainNodeCount_Formula_Round_MinDrain(t *testing.T) {
 	}
 
 	// lenNodes=8, max=90 => raw=0.72, floor=0 이지만 drainRate>0 + minDrain=1 => 1로 보정
-	assert.Equal(t, 1, CalculateDrainNodeCount(8, 90, opts))
+	assert.Equal(t, 1, CalculateDrainNodeCount(8, 90, opts, nil, 0))
 }
 
 func TestCalculateDrainNodeCount_Caps(t *testing.T) {
@@ -50,7 +51,11 @@ func TestCalculateDrainNodeCount_Caps(t *testing.T) {
 	}
 
 	// max=20 => drainRate=0.79 => raw=6.32 => ceil=7, min=1 => 7, capAbs=2/capFrac=2 => 2
-	assert.Equal(t, 2, CalculateDrainNodeCount(8, 20, opts))
+	assert.Equal(t, 2, CalculateDrainNodeCount(8, 20, opts, nil, 0))
+
+	// Karpenter가 1대를 중단 중이면 상한 2대 중 1대만 남음
+	assert.Equal(t, 1, CalculateDrainNodeCount(8, 20, opts, nil, 1))
+	assert.Equal(t, 0, CalculateDrainNodeCount(8, 20, opts, nil, 3))
 }
 
 func TestCalculateDrainNodeCount_StepPolicy(t *testing.T) {
@@ -59,9 +64,9 @@ func TestCalculateDrainNodeCount_StepPolicy(t *testing.T) {
 		StepRules: []StepRule{{MaxAllocateRate: 60, DrainCount: 2}, {MaxAllocateRate: 80, DrainCount: 1}},
 	}
 
-	assert.Equal(t, 2, CalculateDrainNodeCount(10, 55, opts))
-	assert.Equal(t, 1, CalculateDrainNodeCount(10, 75, opts))
-	assert.Equal(t, 0, CalculateDrainNodeCount(10, 90, opts))
+	assert.Equal(t, 2, CalculateDrainNodeCount(10, 55, opts, nil, 0))
+	assert.Equal(t, 1, CalculateDrainNodeCount(10, 75, opts, nil, 0))
+	assert.Equal(t, 0, CalculateDrainNodeCount(10, 90, opts, nil, 0))
 }
 
 func TestShouldBlockDrainBySafetyMaxAllocateRate(t *testing.T) {
@@ -69,8 +74,8 @@ func TestShouldBlockDrainBySafetyMaxAllocateRate(t *testing.T) {
 		SafetyMaxAllocateRate: 90,
 	}
 
-	blocked, reason, err := ShouldBlockDrainBySafetyConditions(90, opts)
+	decision, err := ShouldBlockDrainBySafetyConditions(context.Background(), nil, 90, opts)
 	assert.NoError(t, err)
-	assert.True(t, blocked)
-	assert.Contains(t, reason, ">= safetyMaxAllocateRate")
+	assert.True(t, decision.Blocked)
+	assert.Contains(t, decision.Reason, ">= safetyMaxAllocateRate")
 }
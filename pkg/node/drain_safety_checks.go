@@ -0,0 +1,254 @@
+package node
+
+import (
+	"app/config"
+	"app/pkg/karpenter"
+	"app/types"
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	prometheusModel "github.com/prometheus/common/model"
+	"sigs.k8s.io/yaml"
+)
+
+const defaultSafetyCheckTimeout = 30 * time.Second
+
+// SafetyCheck는 이름이 있는 안전 조건입니다. 쿼리 결과 중 하나라도 `값 Comparator Threshold`를 만족하면 드레인을 차단합니다.
+type SafetyCheck struct {
+	Name       string
+	Query      string
+	Comparator string // >, >=, <, <=, ==
+	Threshold  float64
+	FailClosed bool          // 쿼리 실패(타임아웃 포함) 시 차단할지
+	Timeout    time.Duration // 쿼리 타임아웃
+}
+
+// safetyCheckSpec은 DRAIN_SAFETY_CHECKS(YAML/JSON)의 항목 형식입니다.
+type safetyCheckSpec struct {
+	Name       string  `json:"name"`
+	Query      string  `json:"query"`
+	Comparator string  `json:"comparator"`
+	Threshold  float64 `json:"threshold"`
+	FailMode   string  `json:"failMode"` // closed|open, 비우면 DRAIN_SAFETY_FAIL_CLOSED
+	Timeout    string  `json:"timeout"`  // 비우면 30s
+}
+
+// SafetyDecision은 안전 조건 평가 결과입니다. Check는 차단한(또는 차단 판단에 실패한) 안전 조건입니다.
+type SafetyDecision struct {
+	Blocked bool
+	Reason  string
+	Check   *types.SafetyCheckResult
+}
+
+// loadSafetyChecks는 DRAIN_SAFETY_CHECKS(인라인) 또는 DRAIN_SAFETY_CHECKS_FILE(파일 경로)의 YAML/JSON 목록을 파싱합니다.
+func loadSafetyChecks(defaultFailClosed bool) ([]SafetyCheck, error) {
+	raw := strings.TrimSpace(os.Getenv("DRAIN_SAFETY_CHECKS"))
+	if path := strings.TrimSpace(os.Getenv("DRAIN_SAFETY_CHECKS_FILE")); path != "" {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("안전 조건 파일 읽기 실패: %w", err)
+		}
+		raw = strings.TrimSpace(string(b))
+	}
+	if raw == "" {
+		return nil, nil
+	}
+	return parseSafetyChecks([]byte(raw), defaultFailClosed)
+}
+
+func parseSafetyChecks(data []byte, defaultFailClosed bool) ([]SafetyCheck, error) {
+	var specs []safetyCheckSpec
+	if err := yaml.UnmarshalStrict(data, &specs); err != nil {
+		return nil, fmt.Errorf("invalid safety checks: %w", err)
+	}
+
+	checks := make([]SafetyCheck, 0, len(specs))
+	names := make(map[string]bool, len(specs))
+	for i, s := range specs {
+		check := SafetyCheck{
+			Name:       strings.TrimSpace(s.Name),
+			Query:      strings.TrimSpace(s.Query),
+			Comparator: strings.TrimSpace(s.Comparator),
+			Threshold:  s.Threshold,
+			FailClosed: defaultFailClosed,
+			Timeout:    defaultSafetyCheckTimeout,
+		}
+		if check.Name == "" {
+			return nil, fmt.Errorf("safety checks[%d]: name is required", i)
+		}
+		if names[check.Name] {
+			return nil, fmt.Errorf("safety checks[%d]: duplicated name %q", i, check.Name)
+		}
+		names[check.Name] = true
+		if check.Query == "" {
+			return nil, fmt.Errorf("safety check %q: query is required", check.Name)
+		}
+		if check.Comparator == "" {
+			check.Comparator = ">"
+		}
+		if _, err := compareSafetyValue(0, check.Comparator, 0); err != nil {
+			return nil, fmt.Errorf("safety check %q: %w", check.Name, err)
+		}
+		switch strings.ToLower(strings.TrimSpace(s.FailMode)) {
+		case "":
+		case "closed":
+			check.FailClosed = true
+		case "open":
+			check.FailClosed = false
+		default:
+			return nil, fmt.Errorf("safety check %q: invalid failMode %q", check.Name, s.FailMode)
+		}
+		if v := strings.TrimSpace(s.Timeout); v != "" {
+			d, err := time.ParseDuration(v)
+			if err != nil || d <= 0 {
+				return nil, fmt.Errorf("safety check %q: invalid timeout %q", check.Name, s.Timeout)
+			}
+			check.Timeout = d
+		}
+		checks = append(checks, check)
+	}
+	return checks, nil
+}
+
+// legacySafetyChecks는 DRAIN_SAFETY_QUERIES를 "결과 > 0" 조건으로 변환합니다.
+func legacySafetyChecks(queries []string, failClosed bool) []SafetyCheck {
+	checks := make([]SafetyCheck, 0, len(queries))
+	for i, q := range queries {
+		checks = append(checks, SafetyCheck{
+			Name:       fmt.Sprintf("query-%d", i+1),
+			Query:      q,
+			Comparator: ">",
+			Threshold:  0,
+			FailClosed: failClosed,
+			Timeout:    defaultSafetyCheckTimeout,
+		})
+	}
+	return checks
+}
+
+func compareSafetyValue(value float64, comparator string, threshold float64) (bool, error) {
+	switch comparator {
+	case ">":
+		return value > threshold, nil
+	case ">=":
+		return value >= threshold, nil
+	case "<":
+		return value < threshold, nil
+	case "<=":
+		return value <= threshold, nil
+	case "==":
+		return value == threshold, nil
+	default:
+		return false, fmt.Errorf("unsupported comparator %q", comparator)
+	}
+}
+
+// evaluateSafetyCheck은 안전 조건 하나를 평가합니다.
+// 조건을 만족하는 샘플이 있으면 그 값을, 없으면 임계값에 가장 가까운 쪽 값(>: 최대, <: 최소)을 Observed로 기록합니다.
+func evaluateSafetyCheck(ctx context.Context, querier karpenter.MetricsQuerier, check SafetyCheck) (types.SafetyCheckResult, error) {
+	result := types.SafetyCheckResult{
+		Name:       check.Name,
+		Query:      check.Query,
+		Comparator: check.Comparator,
+		Threshold:  check.Threshold,
+	}
+
+	queryCtx, cancel := context.WithTimeout(ctx, check.Timeout)
+	defer cancel()
+	vec, err := querier.Query(queryCtx, check.Query)
+	if err != nil {
+		result.Error = err.Error()
+		result.Blocked = check.FailClosed
+		return result, err
+	}
+	if len(vec) == 0 {
+		result.NoData = true
+		return result, nil
+	}
+
+	observed := math.NaN()
+	for _, s := range vec {
+		v := float64(s.Value)
+		matched, _ := compareSafetyValue(v, check.Comparator, check.Threshold)
+		if matched {
+			result.Blocked = true
+			result.Observed = v
+			return result, nil
+		}
+		if math.IsNaN(observed) || closerToThreshold(v, observed, check.Comparator) {
+			observed = v
+		}
+	}
+	result.Observed = observed
+	return result, nil
+}
+
+func closerToThreshold(v float64, current float64, comparator string) bool {
+	if strings.HasPrefix(comparator, "<") {
+		return v < current
+	}
+	return v > current
+}
+
+// EvaluateSafetyChecks는 안전 조건을 순서대로 평가해 처음으로 차단한 조건을 반환합니다.
+func EvaluateSafetyChecks(ctx context.Context, querier karpenter.MetricsQuerier, checks []SafetyCheck) (SafetyDecision, error) {
+	for _, check := range checks {
+		result, err := evaluateSafetyCheck(ctx, querier, check)
+		if err != nil {
+			if result.Blocked {
+				return SafetyDecision{Blocked: true, Reason: fmt.Sprintf("safety check %q failed (fail-closed)", check.Name), Check: &result}, err
+			}
+			slog.Warn("안전 조건 쿼리 실패(무시하고 진행)", "check", check.Name, "query", check.Query, "error", err)
+			continue
+		}
+		if result.NoData {
+			slog.Info("안전 조건 결과 없음", "check", check.Name)
+			continue
+		}
+		slog.Info("안전 조건", "check", check.Name, "observed", result.Observed, "comparator", check.Comparator, "threshold", check.Threshold, "blocked", result.Blocked)
+		if result.Blocked {
+			return SafetyDecision{
+				Blocked: true,
+				Reason:  fmt.Sprintf("safety check %q triggered: %s %s %s", check.Name, formatSafetyValue(result.Observed), check.Comparator, formatSafetyValue(check.Threshold)),
+				Check:   &result,
+			}, nil
+		}
+	}
+	return SafetyDecision{}, nil
+}
+
+func formatSafetyValue(v float64) string {
+	return prometheusModel.SampleValue(v).String()
+}
+
+// defaultSafetyQuerier는 DrainDependencies.SafetyQuerier가 없을 때 환경 변수 기반 Prometheus 클라이언트로 querier를 만듭니다.
+func defaultSafetyQuerier() (karpenter.MetricsQuerier, error) {
+	promClient, err := config.CreatePrometheusClient()
+	if err != nil {
+		return nil, err
+	}
+	return karpenter.NewPrometheusQuerier(promClient), nil
+}
+
+// safetyNotifier는 안전 조건 차단 알림을 지원하는 Notifier입니다.
+type safetyNotifier interface {
+	SendDrainSafetyBlocked(ctx context.Context, result types.SafetyCheckResult) error
+}
+
+func notifySafetyBlocked(ctx context.Context, deps DrainDependencies, decision SafetyDecision) {
+	if decision.Check == nil {
+		return
+	}
+	notifier, ok := deps.Notifier.(safetyNotifier)
+	if !ok {
+		return
+	}
+	if err := notifier.SendDrainSafetyBlocked(ctx, *decision.Check); err != nil {
+		slog.Error("안전 조건 차단 알림 전송 실패", "error", err)
+	}
+}
@@ -0,0 +1,193 @@
+package node
+
+import (
+	"app/types"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	prometheusModel "github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+type fakeSafetyQuerier struct {
+	values map[string][]float64
+	errs   map[string]error
+	delay  time.Duration
+}
+
+func (f fakeSafetyQuerier) Query(ctx context.Context, query string) (prometheusModel.Vector, error) {
+	if f.delay > 0 {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(f.delay):
+		}
+	}
+	if err := f.errs[query]; err != nil {
+		return nil, err
+	}
+	vec := prometheusModel.Vector{}
+	for _, v := range f.values[query] {
+		vec = append(vec, &prometheusModel.Sample{Value: prometheusModel.SampleValue(v)})
+	}
+	return vec, nil
+}
+
+type fakeSafetyNotifier struct {
+	fakeNotifier
+	blocked *[]types.SafetyCheckResult
+}
+
+func (f fakeSafetyNotifier) SendDrainSafetyBlocked(ctx context.Context, result types.SafetyCheckResult) error {
+	*f.blocked = append(*f.blocked, result)
+	return nil
+}
+
+func TestParseSafetyChecks(t *testing.T) {
+	checks, err := parseSafetyChecks([]byte(`
+- name: pending-pods
+  query: sum(kube_pod_status_phase{phase="Pending"})
+  comparator: ">="
+  threshold: 10
+  failMode: open
+  timeout: 5s
+- name: error-rate
+  query: sum(rate(http_errors_total[5m]))
+`), true)
+	assert.NoError(t, err)
+	assert.Equal(t, []SafetyCheck{
+		{Name: "pending-pods", Query: `sum(kube_pod_status_phase{phase="Pending"})`, Comparator: ">=", Threshold: 10, FailClosed: false, Timeout: 5 * time.Second},
+		{Name: "error-rate", Query: "sum(rate(http_errors_total[5m]))", Comparator: ">", Threshold: 0, FailClosed: true, Timeout: defaultSafetyCheckTimeout},
+	}, checks)
+
+	// JSON도 지원
+	checks, err = parseSafetyChecks([]byte(`[{"name":"ready","query":"min(up)","comparator":"<","threshold":1,"failMode":"closed"}]`), false)
+	assert.NoError(t, err)
+	assert.Len(t, checks, 1)
+	assert.True(t, checks[0].FailClosed)
+
+	for _, invalid := range []string{
+		`[{"query":"up"}]`,
+		`[{"name":"a"}]`,
+		`[{"name":"a","query":"up","comparator":"!="}]`,
+		`[{"name":"a","query":"up","failMode":"maybe"}]`,
+		`[{"name":"a","query":"up","timeout":"-1s"}]`,
+		`[{"name":"a","query":"up"},{"name":"a","query":"up"}]`,
+		`[{"name":"a","query":"up","unknown":1}]`,
+	} {
+		_, err := parseSafetyChecks([]byte(invalid), true)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestLoadSafetyChecksFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "safety-checks.yaml")
+	if err := os.WriteFile(path, []byte("- name: file-check\n  query: up\n"), 0o600); err != nil {
+		t.Fatalf("파일 생성 실패: %v", err)
+	}
+	t.Setenv("DRAIN_SAFETY_CHECKS", `[{"name":"inline","query":"up"}]`)
+	t.Setenv("DRAIN_SAFETY_CHECKS_FILE", path)
+
+	checks, err := loadSafetyChecks(true)
+	assert.NoError(t, err)
+	assert.Len(t, checks, 1)
+	assert.Equal(t, "file-check", checks[0].Name)
+}
+
+func TestEvaluateSafetyChecks(t *testing.T) {
+	querier := fakeSafetyQuerier{
+		values: map[string][]float64{
+			"pending":  {2, 4},
+			"ready":    {3, 1},
+			"no-data":  nil,
+			"restarts": {0},
+		},
+		errs: map[string]error{"broken": errors.New("bad query")},
+	}
+
+	// 조건을 만족하지 않으면 통과
+	decision, err := EvaluateSafetyChecks(context.Background(), querier, []SafetyCheck{
+		{Name: "pending-pods", Query: "pending", Comparator: ">", Threshold: 5, Timeout: time.Second},
+		{Name: "no-data", Query: "no-data", Comparator: ">", Threshold: 0, Timeout: time.Second},
+		{Name: "broken-open", Query: "broken", Comparator: ">", Threshold: 0, FailClosed: false, Timeout: time.Second},
+	})
+	assert.NoError(t, err)
+	assert.False(t, decision.Blocked)
+
+	// 차단한 조건 이름과 관측값을 반환
+	decision, err = EvaluateSafetyChecks(context.Background(), querier, []SafetyCheck{
+		{Name: "restarts", Query: "restarts", Comparator: ">", Threshold: 0, Timeout: time.Second},
+		{Name: "ready-replicas", Query: "ready", Comparator: "<", Threshold: 2, Timeout: time.Second},
+	})
+	assert.NoError(t, err)
+	assert.True(t, decision.Blocked)
+	assert.Equal(t, "ready-replicas", decision.Check.Name)
+	assert.Equal(t, 1.0, decision.Check.Observed)
+	assert.Contains(t, decision.Reason, `"ready-replicas"`)
+
+	// fail-closed 조건의 쿼리 실패는 차단
+	decision, err = EvaluateSafetyChecks(context.Background(), querier, []SafetyCheck{
+		{Name: "broken-closed", Query: "broken", Comparator: ">", Threshold: 0, FailClosed: true, Timeout: time.Second},
+	})
+	assert.Error(t, err)
+	assert.True(t, decision.Blocked)
+	assert.Equal(t, "bad query", decision.Check.Error)
+
+	// 조건별 timeout
+	decision, err = EvaluateSafetyChecks(context.Background(), fakeSafetyQuerier{delay: time.Second}, []SafetyCheck{
+		{Name: "slow", Query: "pending", Comparator: ">", Threshold: 0, FailClosed: true, Timeout: 10 * time.Millisecond},
+	})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.True(t, decision.Blocked)
+}
+
+func TestShouldBlockDrainByInvalidSafetyChecks(t *testing.T) {
+	t.Setenv("DRAIN_SAFETY_CHECKS", `[{"name":"a"}]`)
+	t.Setenv("DRAIN_SAFETY_FAIL_CLOSED", "true")
+
+	opts := GetDrainPolicyOptionsFromEnv()
+	assert.Error(t, opts.SafetyChecksErr)
+
+	decision, err := ShouldBlockDrainBySafetyConditions(context.Background(), fakeSafetyQuerier{}, 10, opts)
+	assert.Error(t, err)
+	assert.True(t, decision.Blocked)
+}
+
+func TestNodeDrainBlockedByNamedSafetyCheck(t *testing.T) {
+	setDefaultDrainEnv(t)
+	t.Setenv("DRAIN_SAFETY_QUERIES", "legacy")
+	t.Setenv("DRAIN_SAFETY_CHECKS", `[{"name":"pending-pods","query":"pending","comparator":">=","threshold":3}]`)
+
+	clientSet := fake.NewSimpleClientset()
+	nodepoolName := "test-nodepool"
+	for i := 1; i <= 4; i++ {
+		if _, err := clientSet.CoreV1().Nodes().Create(context.Background(), newNode(nodepoolName, i), metaV1.CreateOptions{}); err != nil {
+			t.Fatalf("노드 생성 실패: %v", err)
+		}
+	}
+
+	var blocked []types.SafetyCheckResult
+	results, err := NodeDrain(context.Background(), clientSet, DrainDependencies{
+		AllocateRateProvider: fakeAllocateRateProvider{rates: map[string]int{"memory": 30, "cpu": 25}},
+		Notifier:             fakeSafetyNotifier{blocked: &blocked},
+		SafetyQuerier: fakeSafetyQuerier{values: map[string][]float64{
+			"legacy":  {0},
+			"pending": {7},
+		}},
+	}, DrainConfig{
+		NodepoolName: nodepoolName,
+		Eviction:     testEvictionConfig(),
+	})
+	assert.NoError(t, err)
+	assert.Len(t, results, 0)
+	if assert.Len(t, blocked, 1) {
+		assert.Equal(t, "pending-pods", blocked[0].Name)
+		assert.Equal(t, 7.0, blocked[0].Observed)
+	}
+}
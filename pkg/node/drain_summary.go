@@ -0,0 +1,86 @@
+package node
+
+import (
+	"app/pkg/pod"
+	"app/types"
+	"sort"
+)
+
+// summaryTopErrorReasons는 요약에 남길 실패 사유 최대 개수입니다.
+const summaryTopErrorReasons = 3
+
+// recordSafetyStop은 안전 조건으로 드레인을 멈춘 사유와 차단한 안전 조건을 요약에 기록합니다.
+// 처음 멈춘 사유만 남깁니다.
+func recordSafetyStop(summary *types.NodeDrainSummary, decision SafetyDecision) {
+	if summary == nil || !decision.Blocked || summary.StoppedBySafety {
+		return
+	}
+	summary.StoppedBySafety = true
+	summary.StopSafetyReason = decision.Reason
+	if decision.Check != nil {
+		check := *decision.Check
+		summary.StopSafetyCheck = &check
+	}
+}
+
+// recordCircuitBreakerStop은 드레인을 멈춘 circuit breaker와 사유를 요약에 기록합니다.
+func recordCircuitBreakerStop(summary *types.NodeDrainSummary, tripped *CircuitBreakerError) {
+	if summary == nil || tripped == nil {
+		return
+	}
+	summary.StoppedByCircuitBreaker = tripped.Breaker
+	summary.CircuitBreakerReason = tripped.Reason
+}
+
+// recordCanaryStop은 드레인을 멈춘 canary 노드와 실패 사유를 요약에 기록합니다.
+func recordCanaryStop(summary *types.NodeDrainSummary, failed *CanaryFailedError) {
+	if summary == nil || failed == nil {
+		return
+	}
+	summary.StoppedByCanary = failed.NodeName
+	summary.CanaryFailureReason = failed.Reason
+}
+
+// summarizeDrainResults는 노드별 결과와 실행 전체 eviction 집계를 요약에 반영합니다.
+func summarizeDrainResults(summary *types.NodeDrainSummary, results []types.NodeDrainResult, stats *pod.EvictionStats) {
+	if summary == nil {
+		return
+	}
+	reasons := map[string]int{}
+	for _, r := range results {
+		if r.Success {
+			summary.DrainedNodeCount++
+			continue
+		}
+		if r.FailureReason != "" {
+			reasons[r.FailureReason]++
+		}
+	}
+	summary.TopErrorReasons = topErrorReasons(reasons, summaryTopErrorReasons)
+
+	summary.TotalPods = int(stats.Attempted())
+	summary.EvictedPods = int(stats.Evicted())
+	summary.DeletedPods = int(stats.Deleted())
+	summary.ForceDeletedPods = int(stats.ForceDeleted())
+	summary.PDBBlockedPods = int(stats.PDBBlockedPods())
+	summary.ForcedByFallback = int(stats.ForcedByFallback())
+	summary.ProblemPodsForced = int(stats.ProblemForced())
+}
+
+// topErrorReasons는 많이 발생한 실패 사유부터 최대 limit개를 반환합니다. 횟수가 같으면 사유 이름 순입니다.
+func topErrorReasons(reasons map[string]int, limit int) []string {
+	sorted := make([]string, 0, len(reasons))
+	for reason := range reasons {
+		sorted = append(sorted, reason)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if reasons[sorted[i]] != reasons[sorted[j]] {
+			return reasons[sorted[i]] > reasons[sorted[j]]
+		}
+		return sorted[i] < sorted[j]
+	})
+	if len(sorted) > limit {
+		sorted = sorted[:limit]
+	}
+	return sorted
+}
@@ -0,0 +1,169 @@
+package node
+
+import (
+	"app/pkg/pod"
+	"app/types"
+	"context"
+	"errors"
+	"testing"
+
+	prometheusModel "github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	coreV1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// sequenceSafetyQuerier는 호출 순서대로 값을 돌려주고, 마지막 값은 이후 호출에도 반복합니다.
+type sequenceSafetyQuerier struct {
+	values []float64
+	calls  int
+}
+
+func (f *sequenceSafetyQuerier) Query(ctx context.Context, query string) (prometheusModel.Vector, error) {
+	idx := f.calls
+	f.calls++
+	if idx >= len(f.values) {
+		idx = len(f.values) - 1
+	}
+	return prometheusModel.Vector{&prometheusModel.Sample{Value: prometheusModel.SampleValue(f.values[idx])}}, nil
+}
+
+func TestNodeDrainWithSummaryRecordsSafetyStop(t *testing.T) {
+	setDefaultDrainEnv(t)
+	t.Setenv("DRAIN_PROGRESSIVE", "true")
+	t.Setenv("DRAIN_SAFETY_CHECKS", `[{"name":"pending-pods","query":"pending","comparator":">=","threshold":3}]`)
+
+	clientSet := fake.NewSimpleClientset()
+	nodepoolName := "test-nodepool"
+	for i := 1; i <= 4; i++ {
+		if _, err := clientSet.CoreV1().Nodes().Create(context.Background(), newNode(nodepoolName, i), metaV1.CreateOptions{}); err != nil {
+			t.Fatalf("노드 생성 실패: %v", err)
+		}
+	}
+
+	// 실행 전 평가는 통과하고, 첫 노드 드레인 후 재평가에서 차단
+	var blocked []types.SafetyCheckResult
+	results, summary, err := NodeDrainWithSummary(context.Background(), clientSet, DrainDependencies{
+		AllocateRateProvider: fakeAllocateRateProvider{rates: map[string]int{"memory": 30, "cpu": 25}},
+		Notifier:             fakeSafetyNotifier{blocked: &blocked},
+		SafetyQuerier:        &sequenceSafetyQuerier{values: []float64{0, 7}},
+	}, DrainConfig{
+		NodepoolName: nodepoolName,
+		Eviction:     testEvictionConfig(),
+	})
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+
+	assert.Equal(t, nodepoolName, summary.TargetNodepool)
+	assert.Equal(t, 4, summary.TotalNodesInNodepool)
+	assert.Equal(t, 2, summary.PlannedDrainNodeCount)
+	assert.Equal(t, 1, summary.DrainedNodeCount)
+	assert.True(t, summary.StoppedBySafety)
+	assert.NotEmpty(t, summary.StopSafetyReason)
+	if assert.NotNil(t, summary.StopSafetyCheck) {
+		assert.Equal(t, "pending-pods", summary.StopSafetyCheck.Name)
+		assert.Equal(t, 7.0, summary.StopSafetyCheck.Observed)
+		assert.True(t, summary.StopSafetyCheck.Blocked)
+	}
+}
+
+func TestNodeDrainWithSummaryRecordsPlanningSafetyBlock(t *testing.T) {
+	setDefaultDrainEnv(t)
+	t.Setenv("DRAIN_SAFETY_CHECKS", `[{"name":"pending-pods","query":"pending","comparator":">=","threshold":3}]`)
+
+	clientSet := fake.NewSimpleClientset(newNode("test-nodepool", 1), newNode("test-nodepool", 2))
+	results, summary, err := NodeDrainWithSummary(context.Background(), clientSet, DrainDependencies{
+		AllocateRateProvider: fakeAllocateRateProvider{rates: map[string]int{"memory": 30, "cpu": 25}},
+		Notifier:             fakeNotifier{},
+		SafetyQuerier:        &sequenceSafetyQuerier{values: []float64{5}},
+	}, DrainConfig{
+		NodepoolName: "test-nodepool",
+		Eviction:     testEvictionConfig(),
+	})
+	assert.NoError(t, err)
+	assert.Len(t, results, 0)
+	assert.Equal(t, 0, summary.PlannedDrainNodeCount)
+	assert.True(t, summary.StoppedBySafety)
+	if assert.NotNil(t, summary.StopSafetyCheck) {
+		assert.Equal(t, "pending-pods", summary.StopSafetyCheck.Name)
+	}
+}
+
+func TestSummarizeDrainResults(t *testing.T) {
+	summary := types.NodeDrainSummary{}
+	summarizeDrainResults(&summary, []types.NodeDrainResult{
+		{NodeName: "node-1", Success: true},
+		{NodeName: "node-2", FailureReason: "pdb"},
+		{NodeName: "node-3", FailureReason: "timeout"},
+		{NodeName: "node-4", FailureReason: "pdb"},
+	}, &pod.EvictionStats{})
+
+	assert.Equal(t, 1, summary.DrainedNodeCount)
+	assert.Equal(t, []string{"pdb", "timeout"}, summary.TopErrorReasons)
+}
+
+func TestNodeDrainWithSummaryCountsPodRemovals(t *testing.T) {
+	setDefaultDrainEnv(t)
+	t.Setenv("DRAIN_MAX_ABSOLUTE", "1")
+
+	clientSet := fake.NewSimpleClientset(newNode("test-nodepool", 1), newNode("test-nodepool", 2))
+	for _, p := range []*coreV1.Pod{
+		{ObjectMeta: metaV1.ObjectMeta{Name: "evicted", Namespace: "default"}, Spec: coreV1.PodSpec{NodeName: "node-1"}},
+		{ObjectMeta: metaV1.ObjectMeta{Name: "legacy", Namespace: "default"}, Spec: coreV1.PodSpec{NodeName: "node-1"}},
+		{ObjectMeta: metaV1.ObjectMeta{Name: "stuck", Namespace: "default"}, Spec: coreV1.PodSpec{NodeName: "node-1"}},
+		{
+			ObjectMeta: metaV1.ObjectMeta{Name: "crashloop", Namespace: "default"},
+			Spec:       coreV1.PodSpec{NodeName: "node-1"},
+			Status: coreV1.PodStatus{ContainerStatuses: []coreV1.ContainerStatus{{
+				State: coreV1.ContainerState{Waiting: &coreV1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+			}}},
+		},
+	} {
+		if _, err := clientSet.CoreV1().Pods(p.Namespace).Create(context.Background(), p, metaV1.CreateOptions{}); err != nil {
+			t.Fatalf("파드 생성 실패: %v", err)
+		}
+	}
+	// legacy: eviction API 미지원 -> delete fallback, stuck: eviction 오류 -> --pod-force 강제 삭제
+	clientSet.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "eviction" {
+			return false, nil, nil
+		}
+		switch action.(k8stesting.CreateAction).GetObject().(*policyv1.Eviction).Name {
+		case "legacy":
+			return true, nil, apierrors.NewMethodNotSupported(schema.GroupResource{Resource: "pods/eviction"}, "create")
+		case "stuck":
+			return true, nil, apierrors.NewInternalError(errors.New("eviction webhook failed"))
+		}
+		return true, nil, nil
+	})
+
+	cfg := testEvictionConfig()
+	cfg.EvictionMode = pod.EvictionModeEvict
+	cfg.Force = true
+	cfg.ForceProblemPods = true
+	results, summary, err := NodeDrainWithSummary(context.Background(), clientSet, DrainDependencies{
+		AllocateRateProvider: fakeAllocateRateProvider{rates: map[string]int{"memory": 30, "cpu": 25}},
+		Notifier:             fakeNotifier{},
+	}, DrainConfig{
+		NodepoolName: "test-nodepool",
+		Eviction:     cfg,
+	})
+	assert.NoError(t, err)
+	if assert.Len(t, results, 1) {
+		assert.Equal(t, "node-1", results[0].NodeName)
+	}
+
+	assert.Equal(t, 4, summary.TotalPods)
+	assert.Equal(t, 1, summary.EvictedPods)
+	assert.Equal(t, 1, summary.DeletedPods)
+	assert.Equal(t, 1, summary.ForcedByFallback)
+	assert.Equal(t, 1, summary.ProblemPodsForced)
+	assert.Equal(t, 2, summary.ForceDeletedPods)
+	assert.Equal(t, 0, summary.PDBBlockedPods)
+}
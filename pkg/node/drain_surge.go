@@ -0,0 +1,320 @@
+package node
+
+import (
+	"app/pkg/pod"
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	coreV1 "k8s.io/api/core/v1"
+	schedulingV1 "k8s.io/api/scheduling/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	surgePlaceholderLabel     = "node-drain/surge-placeholder"
+	surgePriorityValue        = int32(-10)
+	defaultSurgeImage         = "registry.k8s.io/pause:3.9"
+	defaultSurgePriorityClass = "node-drain-surge"
+)
+
+// SurgeOptions는 cordon 전에 낮은 우선순위 placeholder 파드로 용량을 미리 확보하는 옵션입니다.
+type SurgeOptions struct {
+	Enabled           bool
+	Namespace         string
+	PriorityClassName string
+	Image             string
+	Timeout           time.Duration // placeholder가 Ready가 될 때까지 대기 시간
+	Interval          time.Duration
+}
+
+// GetSurgeOptionsFromEnv는 surge 관련 환경 변수를 파싱합니다. 기본값은 비활성입니다.
+func GetSurgeOptionsFromEnv() SurgeOptions {
+	opts := SurgeOptions{
+		Enabled:           parseEnvBool("DRAIN_SURGE", false),
+		Namespace:         strings.TrimSpace(os.Getenv("DRAIN_SURGE_NAMESPACE")),
+		PriorityClassName: strings.TrimSpace(os.Getenv("DRAIN_SURGE_PRIORITY_CLASS")),
+		Image:             strings.TrimSpace(os.Getenv("DRAIN_SURGE_IMAGE")),
+		Timeout:           parseEnvDuration("DRAIN_SURGE_TIMEOUT", 10*time.Minute),
+		Interval:          parseEnvDuration("DRAIN_SURGE_INTERVAL", 10*time.Second),
+	}
+	if opts.Namespace == "" {
+		opts.Namespace = metaV1.NamespaceDefault
+	}
+	if opts.PriorityClassName == "" {
+		opts.PriorityClassName = defaultSurgePriorityClass
+	}
+	if opts.Image == "" {
+		opts.Image = defaultSurgeImage
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = 10 * time.Second
+	}
+	return opts
+}
+
+// ensureSurgePriorityClass는 placeholder용 음수 우선순위 PriorityClass가 없으면 만듭니다.
+// 드레인으로 밀려난 파드가 placeholder를 선점(preempt)할 수 있도록 기본 우선순위(0)보다 낮아야 합니다.
+func ensureSurgePriorityClass(ctx context.Context, clientSet kubernetes.Interface, name string) error {
+	_, err := clientSet.SchedulingV1().PriorityClasses().Get(ctx, name, metaV1.GetOptions{})
+	if err == nil {
+		return nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return fmt.Errorf("PriorityClass %s 조회 실패: %w", name, err)
+	}
+
+	preemptNever := coreV1.PreemptNever
+	_, err = clientSet.SchedulingV1().PriorityClasses().Create(ctx, &schedulingV1.PriorityClass{
+		ObjectMeta:       metaV1.ObjectMeta{Name: name},
+		Value:            surgePriorityValue,
+		PreemptionPolicy: &preemptNever,
+		Description:      "node-drain surge placeholder pods",
+	}, metaV1.CreateOptions{})
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("PriorityClass %s 생성 실패: %w", name, err)
+	}
+	return nil
+}
+
+// buildSurgePlaceholders는 노드에서 제거될 파드마다 같은 CPU/Memory request를 가진 pause 파드를 만듭니다.
+// placeholder는 같은 nodepool에만 배치되고, 이번 실행에서 드레인할 노드(excludeNodes)에는 배치되지 않습니다.
+// 원래 파드가 실제로 들어갈 수 있는 곳에 용량을 잡도록 nodeSelector, toleration, 필수 affinity/anti-affinity, topology spread 조건을 그대로 따릅니다.
+func buildSurgePlaceholders(nodeName string, nodepoolName string, pods []coreV1.Pod, excludeNodes []string, opts SurgeOptions) []coreV1.Pod {
+	placeholders := make([]coreV1.Pod, 0, len(pods))
+	for i, p := range pods {
+		if p.Spec.NodeName != nodeName || p.Labels[surgePlaceholderLabel] == "true" {
+			continue
+		}
+		requests := coreV1.ResourceList{}
+		for _, name := range []coreV1.ResourceName{coreV1.ResourceCPU, coreV1.ResourceMemory} {
+			if q := podRequest(p, name); !q.IsZero() {
+				requests[name] = q
+			}
+		}
+		if len(requests) == 0 {
+			continue
+		}
+
+		nodeSelector := map[string]string{}
+		for k, v := range p.Spec.NodeSelector {
+			nodeSelector[k] = v
+		}
+		nodeSelector[nodepoolLabel] = nodepoolName
+
+		zero := int64(0)
+		placeholders = append(placeholders, coreV1.Pod{
+			ObjectMeta: metaV1.ObjectMeta{
+				Name:      fmt.Sprintf("surge-%s-%d", nodeName, i),
+				Namespace: opts.Namespace,
+				Labels: map[string]string{
+					surgePlaceholderLabel: "true",
+					nodepoolLabel:         nodepoolName,
+				},
+			},
+			Spec: coreV1.PodSpec{
+				PriorityClassName:             opts.PriorityClassName,
+				TerminationGracePeriodSeconds: &zero,
+				NodeSelector:                  nodeSelector,
+				Tolerations:                   p.Spec.Tolerations,
+				Affinity:                      surgeAffinity(p, excludeNodes),
+				TopologySpreadConstraints:     surgeTopologySpreadConstraints(p),
+				Containers: []coreV1.Container{{
+					Name:  "pause",
+					Image: opts.Image,
+					Resources: coreV1.ResourceRequirements{
+						Requests: requests,
+						Limits:   surgeLimits(requests),
+					},
+				}},
+			},
+		})
+	}
+	return placeholders
+}
+
+// surgeAffinity는 원래 파드의 필수 nodeAffinity 각 term에 드레인 대상 노드 제외 조건을 더하고(AND), 필수 pod affinity/anti-affinity를 옮깁니다.
+// placeholder는 다른 namespace에 만들어지므로 namespace를 지정하지 않은 pod (anti-)affinity term은 원래 파드의 namespace로 고정합니다.
+func surgeAffinity(p coreV1.Pod, excludeNodes []string) *coreV1.Affinity {
+	excludeDrained := coreV1.NodeSelectorRequirement{
+		Key:      "metadata.name",
+		Operator: coreV1.NodeSelectorOpNotIn,
+		Values:   excludeNodes,
+	}
+
+	var terms []coreV1.NodeSelectorTerm
+	if a := p.Spec.Affinity; a != nil && a.NodeAffinity != nil && a.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution != nil {
+		for _, term := range a.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+			merged := *term.DeepCopy()
+			merged.MatchFields = append(merged.MatchFields, excludeDrained)
+			terms = append(terms, merged)
+		}
+	}
+	if len(terms) == 0 {
+		terms = []coreV1.NodeSelectorTerm{{MatchFields: []coreV1.NodeSelectorRequirement{excludeDrained}}}
+	}
+
+	affinity := &coreV1.Affinity{
+		NodeAffinity: &coreV1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &coreV1.NodeSelector{NodeSelectorTerms: terms},
+		},
+	}
+	if a := p.Spec.Affinity; a != nil {
+		if a.PodAffinity != nil && len(a.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution) > 0 {
+			affinity.PodAffinity = &coreV1.PodAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: surgePodAffinityTerms(a.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution, p.Namespace),
+			}
+		}
+		if a.PodAntiAffinity != nil && len(a.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution) > 0 {
+			affinity.PodAntiAffinity = &coreV1.PodAntiAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: surgePodAffinityTerms(a.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution, p.Namespace),
+			}
+		}
+	}
+	return affinity
+}
+
+func surgePodAffinityTerms(terms []coreV1.PodAffinityTerm, namespace string) []coreV1.PodAffinityTerm {
+	copied := make([]coreV1.PodAffinityTerm, 0, len(terms))
+	for _, term := range terms {
+		c := *term.DeepCopy()
+		if len(c.Namespaces) == 0 && c.NamespaceSelector == nil {
+			c.Namespaces = []string{namespace}
+		}
+		copied = append(copied, c)
+	}
+	return copied
+}
+
+// surgeTopologySpreadConstraints는 원래 파드의 topology spread 조건 중 필수 조건(DoNotSchedule)만 옮깁니다.
+// spread는 placeholder namespace의 파드로 계산되므로, 원래 파드와 같은 namespace를 DRAIN_SURGE_NAMESPACE로 쓰면 가장 정확합니다.
+func surgeTopologySpreadConstraints(p coreV1.Pod) []coreV1.TopologySpreadConstraint {
+	var constraints []coreV1.TopologySpreadConstraint
+	for _, c := range p.Spec.TopologySpreadConstraints {
+		if c.WhenUnsatisfiable != coreV1.DoNotSchedule {
+			continue
+		}
+		constraints = append(constraints, *c.DeepCopy())
+	}
+	return constraints
+}
+
+// surgeLimits는 memory limit만 request와 같게 설정합니다. (pause 컨테이너는 실제로 거의 사용하지 않음)
+func surgeLimits(requests coreV1.ResourceList) coreV1.ResourceList {
+	limits := coreV1.ResourceList{}
+	if q, ok := requests[coreV1.ResourceMemory]; ok {
+		limits[coreV1.ResourceMemory] = q.DeepCopy()
+	}
+	if len(limits) == 0 {
+		return nil
+	}
+	return limits
+}
+
+// prepareSurgeCapacity는 노드를 cordon하기 전에 placeholder 파드를 만들고 모두 Ready가 될 때까지 기다립니다.
+// placeholder는 실행이 끝날 때 cleanupSurgePlaceholders로 정리합니다.
+func prepareSurgeCapacity(ctx context.Context, clientSet kubernetes.Interface, nodeName string, nodepoolName string, excludeNodes []string, opts SurgeOptions) error {
+	pods, err := pod.GetNonCriticalPods(ctx, clientSet, nodeName)
+	if err != nil {
+		return fmt.Errorf("노드 %s 파드 조회 실패: %w", nodeName, err)
+	}
+
+	placeholders := buildSurgePlaceholders(nodeName, nodepoolName, pods, excludeNodes, opts)
+	if len(placeholders) == 0 {
+		return nil
+	}
+
+	var cpu, memory resource.Quantity
+	names := make([]string, 0, len(placeholders))
+	for i := range placeholders {
+		if _, err := clientSet.CoreV1().Pods(opts.Namespace).Create(ctx, &placeholders[i], metaV1.CreateOptions{}); err != nil && !apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("surge placeholder %s 생성 실패: %w", placeholders[i].Name, err)
+		}
+		names = append(names, placeholders[i].Name)
+		cpu.Add(podRequest(placeholders[i], coreV1.ResourceCPU))
+		memory.Add(podRequest(placeholders[i], coreV1.ResourceMemory))
+	}
+	slog.Info("surge placeholder 생성", "nodeName", nodeName, "placeholders", len(names), "cpu", cpu.String(), "memory", memory.String())
+
+	return waitForSurgeCapacity(ctx, clientSet, opts.Namespace, names, opts)
+}
+
+// waitForSurgeCapacity는 placeholder 파드가 모두 Running/Ready(=용량 확보)가 될 때까지 기다립니다.
+func waitForSurgeCapacity(ctx context.Context, clientSet kubernetes.Interface, namespace string, names []string, opts SurgeOptions) error {
+	waitCtx := ctx
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	ticker := time.NewTicker(opts.Interval)
+	defer ticker.Stop()
+
+	pending := names
+	for {
+		var notReady []string
+		for _, name := range pending {
+			p, err := clientSet.CoreV1().Pods(namespace).Get(waitCtx, name, metaV1.GetOptions{})
+			if err != nil || !isPodReady(p) {
+				notReady = append(notReady, name)
+			}
+		}
+		if len(notReady) == 0 {
+			slog.Info("surge 용량 확보 완료", "placeholders", len(names))
+			return nil
+		}
+		pending = notReady
+		slog.Info("surge 용량 확보 대기 중", "remaining", len(pending))
+
+		select {
+		case <-waitCtx.Done():
+			return fmt.Errorf("surge 용량 확보 대기 타임아웃 (%s): %w", strings.Join(pending, ", "), waitCtx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+func isPodReady(p *coreV1.Pod) bool {
+	if p.Status.Phase != coreV1.PodRunning {
+		return false
+	}
+	for _, cond := range p.Status.Conditions {
+		if cond.Type == coreV1.PodReady {
+			return cond.Status == coreV1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// cleanupSurgePlaceholders는 nodepool의 surge placeholder를 모두 삭제합니다. 이전 실행에서 남은 placeholder도 함께 정리합니다.
+func cleanupSurgePlaceholders(ctx context.Context, clientSet kubernetes.Interface, nodepoolName string, opts SurgeOptions) {
+	list, err := clientSet.CoreV1().Pods(opts.Namespace).List(ctx, metaV1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=true,%s=%s", surgePlaceholderLabel, nodepoolLabel, nodepoolName),
+	})
+	if err != nil {
+		slog.Warn("surge placeholder 조회 실패(정리 생략)", "error", err)
+		return
+	}
+
+	deleted := 0
+	for _, p := range list.Items {
+		if p.Labels[surgePlaceholderLabel] != "true" || p.Labels[nodepoolLabel] != nodepoolName {
+			continue
+		}
+		if err := clientSet.CoreV1().Pods(p.Namespace).Delete(ctx, p.Name, metaV1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			slog.Warn("surge placeholder 삭제 실패", "pod", p.Name, "error", err)
+			continue
+		}
+		deleted++
+	}
+	if deleted > 0 {
+		slog.Info("surge placeholder 정리 완료", "deleted", deleted)
+	}
+}
@@ -0,0 +1,231 @@
+package node
+
+import (
+	"app/pkg/pod"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func requestPod(name string, nodeName string, cpu string, memory string) *coreV1.Pod {
+	return &coreV1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: coreV1.PodSpec{
+			NodeName: nodeName,
+			Containers: []coreV1.Container{{
+				Name: "app",
+				Resources: coreV1.ResourceRequirements{Requests: coreV1.ResourceList{
+					coreV1.ResourceCPU:    resource.MustParse(cpu),
+					coreV1.ResourceMemory: resource.MustParse(memory),
+				}},
+			}},
+		},
+		Status: coreV1.PodStatus{Phase: coreV1.PodRunning},
+	}
+}
+
+// markSurgePlaceholdersReady는 placeholder 생성 시 바로 Running/Ready 상태로 만들어 용량 확보를 흉내 냅니다.
+func markSurgePlaceholdersReady(clientSet *fake.Clientset) {
+	clientSet.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		p, ok := action.(k8stesting.CreateAction).GetObject().(*coreV1.Pod)
+		if ok && p.Labels[surgePlaceholderLabel] == "true" {
+			p.Status.Phase = coreV1.PodRunning
+			p.Status.Conditions = []coreV1.PodCondition{{Type: coreV1.PodReady, Status: coreV1.ConditionTrue}}
+		}
+		return false, nil, nil
+	})
+}
+
+func countSurgePlaceholders(t *testing.T, clientSet *fake.Clientset) int {
+	t.Helper()
+	list, err := clientSet.CoreV1().Pods("default").List(context.Background(), metaV1.ListOptions{})
+	if err != nil {
+		t.Fatalf("파드 조회 실패: %v", err)
+	}
+	count := 0
+	for _, p := range list.Items {
+		if p.Labels[surgePlaceholderLabel] == "true" {
+			count++
+		}
+	}
+	return count
+}
+
+func TestGetSurgeOptionsFromEnv(t *testing.T) {
+	t.Setenv("DRAIN_SURGE", "")
+	t.Setenv("DRAIN_SURGE_NAMESPACE", "")
+	t.Setenv("DRAIN_SURGE_PRIORITY_CLASS", "")
+	t.Setenv("DRAIN_SURGE_IMAGE", "")
+	t.Setenv("DRAIN_SURGE_TIMEOUT", "")
+	t.Setenv("DRAIN_SURGE_INTERVAL", "")
+
+	assert.Equal(t, SurgeOptions{
+		Enabled:           false,
+		Namespace:         "default",
+		PriorityClassName: defaultSurgePriorityClass,
+		Image:             defaultSurgeImage,
+		Timeout:           10 * time.Minute,
+		Interval:          10 * time.Second,
+	}, GetSurgeOptionsFromEnv())
+}
+
+func TestBuildSurgePlaceholders(t *testing.T) {
+	withRequests := *requestPod("api-1", "node-1", "500m", "1Gi")
+	withRequests.Spec.NodeSelector = map[string]string{"kubernetes.io/arch": "arm64"}
+	withRequests.Spec.Tolerations = []coreV1.Toleration{{Key: "dedicated", Operator: coreV1.TolerationOpExists}}
+	noRequests := coreV1.Pod{ObjectMeta: metaV1.ObjectMeta{Name: "sidecar"}, Spec: coreV1.PodSpec{NodeName: "node-1", Containers: []coreV1.Container{{Name: "c"}}}}
+	otherNode := *requestPod("api-2", "node-2", "1", "1Gi")
+
+	opts := SurgeOptions{Namespace: "surge", PriorityClassName: "low", Image: "pause"}
+	placeholders := buildSurgePlaceholders("node-1", "pool", []coreV1.Pod{withRequests, noRequests, otherNode}, []string{"node-1", "node-2"}, opts)
+	if !assert.Len(t, placeholders, 1) {
+		return
+	}
+
+	p := placeholders[0]
+	assert.Equal(t, "surge-node-1-0", p.Name)
+	assert.Equal(t, "surge", p.Namespace)
+	assert.Equal(t, "low", p.Spec.PriorityClassName)
+	assert.Equal(t, map[string]string{"kubernetes.io/arch": "arm64", nodepoolLabel: "pool"}, p.Spec.NodeSelector)
+	assert.Equal(t, withRequests.Spec.Tolerations, p.Spec.Tolerations)
+	assert.Equal(t, []string{"node-1", "node-2"}, p.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchFields[0].Values)
+	cpu := p.Spec.Containers[0].Resources.Requests[coreV1.ResourceCPU]
+	memory := p.Spec.Containers[0].Resources.Requests[coreV1.ResourceMemory]
+	assert.Equal(t, "500m", cpu.String())
+	assert.Equal(t, "1Gi", memory.String())
+}
+
+func TestBuildSurgePlaceholdersCarriesSchedulingConstraints(t *testing.T) {
+	src := *requestPod("api-1", "node-1", "500m", "1Gi")
+	src.Namespace = "prod"
+	antiAffinity := coreV1.PodAffinityTerm{
+		LabelSelector: &metaV1.LabelSelector{MatchLabels: map[string]string{"app": "api"}},
+		TopologyKey:   "kubernetes.io/hostname",
+	}
+	src.Spec.Affinity = &coreV1.Affinity{
+		NodeAffinity: &coreV1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &coreV1.NodeSelector{NodeSelectorTerms: []coreV1.NodeSelectorTerm{
+				{MatchExpressions: []coreV1.NodeSelectorRequirement{{Key: "topology.kubernetes.io/zone", Operator: coreV1.NodeSelectorOpIn, Values: []string{"a"}}}},
+				{MatchExpressions: []coreV1.NodeSelectorRequirement{{Key: "topology.kubernetes.io/zone", Operator: coreV1.NodeSelectorOpIn, Values: []string{"b"}}}},
+			}},
+		},
+		PodAntiAffinity: &coreV1.PodAntiAffinity{RequiredDuringSchedulingIgnoredDuringExecution: []coreV1.PodAffinityTerm{antiAffinity}},
+	}
+	spread := coreV1.TopologySpreadConstraint{
+		MaxSkew:           1,
+		TopologyKey:       "topology.kubernetes.io/zone",
+		WhenUnsatisfiable: coreV1.DoNotSchedule,
+		LabelSelector:     &metaV1.LabelSelector{MatchLabels: map[string]string{"app": "api"}},
+	}
+	src.Spec.TopologySpreadConstraints = []coreV1.TopologySpreadConstraint{
+		spread,
+		{MaxSkew: 1, TopologyKey: "kubernetes.io/hostname", WhenUnsatisfiable: coreV1.ScheduleAnyway},
+	}
+
+	placeholders := buildSurgePlaceholders("node-1", "pool", []coreV1.Pod{src}, []string{"node-1", "node-2"}, SurgeOptions{Namespace: "surge"})
+	if !assert.Len(t, placeholders, 1) {
+		return
+	}
+	affinity := placeholders[0].Spec.Affinity
+
+	// 원래 term(OR) 각각에 드레인 대상 노드 제외 조건을 AND로 더함
+	excludeDrained := coreV1.NodeSelectorRequirement{Key: "metadata.name", Operator: coreV1.NodeSelectorOpNotIn, Values: []string{"node-1", "node-2"}}
+	terms := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	if assert.Len(t, terms, 2) {
+		for i, term := range terms {
+			assert.Equal(t, src.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[i].MatchExpressions, term.MatchExpressions)
+			assert.Equal(t, []coreV1.NodeSelectorRequirement{excludeDrained}, term.MatchFields)
+		}
+	}
+	assert.Empty(t, src.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchFields)
+
+	// anti-affinity는 원래 파드 namespace 기준으로 고정
+	antiAffinity.Namespaces = []string{"prod"}
+	assert.Nil(t, affinity.PodAffinity)
+	assert.Equal(t, []coreV1.PodAffinityTerm{antiAffinity}, affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution)
+
+	// 필수 spread 조건만 옮김
+	assert.Equal(t, []coreV1.TopologySpreadConstraint{spread}, placeholders[0].Spec.TopologySpreadConstraints)
+}
+
+func TestEnsureSurgePriorityClass(t *testing.T) {
+	clientSet := fake.NewSimpleClientset()
+	assert.NoError(t, ensureSurgePriorityClass(context.Background(), clientSet, "node-drain-surge"))
+	assert.NoError(t, ensureSurgePriorityClass(context.Background(), clientSet, "node-drain-surge"))
+
+	pc, err := clientSet.SchedulingV1().PriorityClasses().Get(context.Background(), "node-drain-surge", metaV1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, surgePriorityValue, pc.Value)
+	assert.Equal(t, coreV1.PreemptNever, *pc.PreemptionPolicy)
+}
+
+func TestPrepareSurgeCapacity(t *testing.T) {
+	opts := SurgeOptions{Enabled: true, Namespace: "default", PriorityClassName: "low", Image: "pause", Timeout: 50 * time.Millisecond, Interval: 10 * time.Millisecond}
+
+	// placeholder가 Ready가 되면 성공
+	clientSet := fake.NewSimpleClientset(requestPod("api-1", "node-1", "500m", "1Gi"), requestPod("api-2", "node-1", "1", "2Gi"))
+	markSurgePlaceholdersReady(clientSet)
+	assert.NoError(t, prepareSurgeCapacity(context.Background(), clientSet, "node-1", "pool", []string{"node-1"}, opts))
+	assert.Equal(t, 2, countSurgePlaceholders(t, clientSet))
+
+	// 용량이 확보되지 않으면 타임아웃
+	clientSet = fake.NewSimpleClientset(requestPod("api-1", "node-1", "500m", "1Gi"))
+	err := prepareSurgeCapacity(context.Background(), clientSet, "node-1", "pool", []string{"node-1"}, opts)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Contains(t, err.Error(), "surge-node-1-0")
+
+	cleanupSurgePlaceholders(context.Background(), clientSet, "pool", opts)
+	assert.Equal(t, 0, countSurgePlaceholders(t, clientSet))
+}
+
+func TestNodeDrainWithSurge(t *testing.T) {
+	setDefaultDrainEnv(t)
+	t.Setenv("DRAIN_SURGE", "true")
+	t.Setenv("DRAIN_SURGE_TIMEOUT", "1s")
+	t.Setenv("DRAIN_SURGE_INTERVAL", "10ms")
+
+	nodepoolName := "test-nodepool"
+	var objects []runtime.Object
+	for i := 1; i <= 4; i++ {
+		objects = append(objects, newNode(nodepoolName, i))
+		objects = append(objects, requestPod(fmt.Sprintf("app-%d", i), fmt.Sprintf("node-%d", i), "250m", "512Mi"))
+	}
+	clientSet := fake.NewSimpleClientset(objects...)
+	markSurgePlaceholdersReady(clientSet)
+
+	evictionCfg := testEvictionConfig()
+	evictionCfg.EvictionMode = pod.EvictionModeDelete
+	results, err := NodeDrain(context.Background(), clientSet, DrainDependencies{
+		AllocateRateProvider: fakeAllocateRateProvider{rates: map[string]int{"memory": 30, "cpu": 25}},
+		Notifier:             fakeNotifier{},
+	}, DrainConfig{
+		NodepoolName: nodepoolName,
+		Eviction:     evictionCfg,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+
+	created := 0
+	for _, action := range clientSet.Actions() {
+		if create, ok := action.(k8stesting.CreateAction); ok {
+			if p, ok := create.GetObject().(*coreV1.Pod); ok && p.Labels[surgePlaceholderLabel] == "true" {
+				created++
+			}
+		}
+	}
+	// fake clientset은 field selector를 무시해 node-1 드레인 시 다른 노드 파드도 함께 삭제되므로 node-1 placeholder만 생성됩니다.
+	assert.Equal(t, 1, created)
+	assert.Equal(t, 0, countSurgePlaceholders(t, clientSet))
+
+	_, err = clientSet.SchedulingV1().PriorityClasses().Get(context.Background(), defaultSurgePriorityClass, metaV1.GetOptions{})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,162 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	capacityTypeLabel    = "karpenter.sh/capacity-type"
+	capacityTypeSpot     = "spot"
+	capacityTypeOnDemand = "on-demand"
+
+	// spotInterruptedEventReason은 Karpenter interruption controller가 spot 중단 알림을 받았을 때 남기는 이벤트 reason입니다.
+	spotInterruptedEventReason = "SpotInterrupted"
+)
+
+// CapacityTypeOptions는 karpenter.sh/capacity-type(spot/on-demand)별 드레인 설정입니다.
+type CapacityTypeOptions struct {
+	Order       []string // 앞에 올수록 먼저 드레인 (예: spot,on-demand). 비어 있으면 순서 보정 없음
+	MaxSpot     int      // 0 이면 비활성
+	MaxOnDemand int      // 0 이면 비활성
+
+	ProtectOnDemandWhenSpotUnstable bool          // spot이 불안정하면 on-demand 노드는 드레인하지 않음
+	SpotUnstableWindow              time.Duration // SpotInterrupted 이벤트 집계 window
+	SpotUnstableThreshold           int           // window 내 SpotInterrupted 이벤트가 이 값 이상이면 불안정
+}
+
+// GetCapacityTypeOptionsFromEnv는 capacity-type 관련 환경 변수를 파싱합니다.
+// 기본값은 "기존 동작 유지"(순서/상한 보정 없음)입니다.
+func GetCapacityTypeOptionsFromEnv() CapacityTypeOptions {
+	opts := CapacityTypeOptions{
+		SpotUnstableWindow:    time.Hour,
+		SpotUnstableThreshold: 1,
+	}
+
+	if v := strings.TrimSpace(os.Getenv("DRAIN_CAPACITY_TYPE_ORDER")); v != "" {
+		for _, t := range splitList(v) {
+			opts.Order = append(opts.Order, strings.ToLower(t))
+		}
+	}
+	opts.MaxSpot = parseEnvInt("DRAIN_MAX_SPOT", opts.MaxSpot)
+	opts.MaxOnDemand = parseEnvInt("DRAIN_MAX_ON_DEMAND", opts.MaxOnDemand)
+	opts.ProtectOnDemandWhenSpotUnstable = parseEnvBool("DRAIN_PROTECT_ON_DEMAND_WHEN_SPOT_UNSTABLE", opts.ProtectOnDemandWhenSpotUnstable)
+	opts.SpotUnstableWindow = parseEnvDuration("DRAIN_SPOT_UNSTABLE_WINDOW", opts.SpotUnstableWindow)
+	opts.SpotUnstableThreshold = parseEnvInt("DRAIN_SPOT_UNSTABLE_THRESHOLD", opts.SpotUnstableThreshold)
+	if opts.SpotUnstableThreshold <= 0 {
+		opts.SpotUnstableThreshold = 1
+	}
+
+	return opts
+}
+
+func nodeCapacityType(n coreV1.Node) string {
+	return n.Labels[capacityTypeLabel]
+}
+
+// capacityTypeRank는 Order에서의 위치를 반환합니다. Order에 없는 타입은 가장 뒤로 보냅니다.
+func capacityTypeRank(capacityType string, order []string) int {
+	for i, t := range order {
+		if t == capacityType {
+			return i
+		}
+	}
+	return len(order)
+}
+
+// annotateCapacityTypes는 후보에 capacity-type과 정렬 순위를 기록합니다.
+func annotateCapacityTypes(candidates []drainCandidate, opts CapacityTypeOptions) []drainCandidate {
+	for i := range candidates {
+		candidates[i].capacityType = nodeCapacityType(candidates[i].node)
+		candidates[i].capacityTypeRank = capacityTypeRank(candidates[i].capacityType, opts.Order)
+	}
+	return candidates
+}
+
+// capacityTypeDrainCaps는 capacity-type별 최대 드레인 노드 수를 반환합니다. 상한이 없으면 nil입니다.
+func capacityTypeDrainCaps(opts CapacityTypeOptions) map[string]int {
+	if opts.MaxSpot <= 0 && opts.MaxOnDemand <= 0 {
+		return nil
+	}
+	caps := map[string]int{}
+	if opts.MaxSpot > 0 {
+		caps[capacityTypeSpot] = opts.MaxSpot
+	}
+	if opts.MaxOnDemand > 0 {
+		caps[capacityTypeOnDemand] = opts.MaxOnDemand
+	}
+	return caps
+}
+
+// spotInterruptionScope는 SpotInterrupted 이벤트를 셀 대상 nodepool의 노드 이름 집합을 반환합니다.
+// spot 중단으로 Node가 이미 삭제됐어도 NodeClaim이 남아 있으면 status.nodeName으로 포함합니다.
+func spotInterruptionScope(ctx context.Context, dynamicClient dynamic.Interface, nodepoolName string, nodes []coreV1.Node) map[string]bool {
+	scope := make(map[string]bool, len(nodes))
+	for _, n := range nodes {
+		scope[n.Name] = true
+	}
+	if dynamicClient == nil {
+		return scope
+	}
+
+	list, err := dynamicClient.Resource(nodeClaimGVR).List(ctx, metaV1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", nodepoolLabel, nodepoolName),
+	})
+	if err != nil {
+		slog.Warn("NodeClaim 조회 실패, 현재 nodepool 노드의 SpotInterrupted 이벤트만 셉니다.", "error", err)
+		return scope
+	}
+	for _, item := range list.Items {
+		if nodeName, _, _ := unstructured.NestedString(item.Object, "status", "nodeName"); nodeName != "" {
+			scope[nodeName] = true
+		}
+	}
+	return scope
+}
+
+// isSpotCapacityUnstable은 window 내 대상 nodepool 노드(scope)의 SpotInterrupted 이벤트 수로 spot 용량이 불안정한지 판단합니다.
+// 다른 nodepool의 spot 중단은 세지 않습니다.
+func isSpotCapacityUnstable(ctx context.Context, clientSet kubernetes.Interface, scope map[string]bool, opts CapacityTypeOptions) (bool, int, error) {
+	counts, err := countRecentEvents(ctx, clientSet, "Node", spotInterruptedEventReason, opts.SpotUnstableWindow)
+	if err != nil {
+		return false, 0, err
+	}
+	total := 0
+	for nodeName, c := range counts {
+		if scope[nodeName] {
+			total += c
+		}
+	}
+	return total >= opts.SpotUnstableThreshold, total, nil
+}
+
+// excludedCapacityTypes는 이번 실행에서 드레인하지 않을 capacity-type 목록을 반환합니다.
+// scope는 spot 중단 이벤트를 셀 nodepool 노드 이름 집합입니다. (spotInterruptionScope 참고)
+func excludedCapacityTypes(ctx context.Context, clientSet kubernetes.Interface, scope map[string]bool, opts CapacityTypeOptions) map[string]bool {
+	if !opts.ProtectOnDemandWhenSpotUnstable {
+		return nil
+	}
+
+	unstable, interruptions, err := isSpotCapacityUnstable(ctx, clientSet, scope, opts)
+	if err != nil {
+		// 불안정 여부를 알 수 없으면 보수적으로 on-demand를 보호
+		slog.Warn("spot 안정성 확인 실패, on-demand 노드를 드레인하지 않습니다.", "error", err)
+		return map[string]bool{capacityTypeOnDemand: true}
+	}
+	if !unstable {
+		return nil
+	}
+
+	slog.Warn("spot 용량이 불안정하여 on-demand 노드를 드레인하지 않습니다.", "spotInterruptions", interruptions, "window", opts.SpotUnstableWindow)
+	return map[string]bool{capacityTypeOnDemand: true}
+}
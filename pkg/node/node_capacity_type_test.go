@@ -0,0 +1,95 @@
+package node
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestOrderDrainCandidatesByCapacityType(t *testing.T) {
+	candidates := annotateCapacityTypes([]drainCandidate{
+		{node: *newCapacityTypeNode("np", 1, "on-demand")},
+		{node: *newCapacityTypeNode("np", 2, "spot")},
+		{node: *newCapacityTypeNode("np", 3, "on-demand"), unhealthyCondition: "NotReady"},
+		{node: *newCapacityTypeNode("np", 4, "spot")},
+	}, CapacityTypeOptions{Order: []string{"spot", "on-demand"}})
+
+	got := orderDrainCandidates(candidates, false)
+	assert.Equal(t, []string{"node-3", "node-2", "node-4", "node-1"}, candidateNames(got))
+}
+
+func TestPickDrainCandidatesAppliesCapacityTypeLimits(t *testing.T) {
+	candidates := annotateCapacityTypes([]drainCandidate{
+		{node: *newCapacityTypeNode("np", 1, "spot")},
+		{node: *newCapacityTypeNode("np", 2, "spot")},
+		{node: *newCapacityTypeNode("np", 3, "on-demand")},
+		{node: *newCapacityTypeNode("np", 4, "on-demand")},
+	}, CapacityTypeOptions{})
+
+	got := pickDrainCandidates(candidates, 3, drainCandidateLimits{
+		capacityTypeCaps: capacityTypeDrainCaps(CapacityTypeOptions{MaxSpot: 1}),
+	})
+	assert.Equal(t, []string{"node-1", "node-3", "node-4"}, candidateNames(got))
+
+	got = pickDrainCandidates(candidates, 3, drainCandidateLimits{
+		excludedCapacityTypes: map[string]bool{"on-demand": true},
+	})
+	assert.Equal(t, []string{"node-1", "node-2"}, candidateNames(got))
+}
+
+func TestExcludedCapacityTypesWhenSpotUnstable(t *testing.T) {
+	opts := CapacityTypeOptions{
+		ProtectOnDemandWhenSpotUnstable: true,
+		SpotUnstableWindow:              time.Hour,
+		SpotUnstableThreshold:           2,
+	}
+	scope := map[string]bool{"node-a": true, "node-b": true}
+
+	stable := fake.NewSimpleClientset(newSpotInterruptedEvent("node-a", 1))
+	assert.Nil(t, excludedCapacityTypes(context.Background(), stable, scope, opts))
+
+	unstable := fake.NewSimpleClientset(newSpotInterruptedEvent("node-a", 1), newSpotInterruptedEvent("node-b", 1))
+	assert.Equal(t, map[string]bool{"on-demand": true}, excludedCapacityTypes(context.Background(), unstable, scope, opts))
+
+	// 다른 nodepool 노드의 spot 중단은 세지 않음
+	otherPool := fake.NewSimpleClientset(newSpotInterruptedEvent("node-a", 1), newSpotInterruptedEvent("other-1", 5))
+	assert.Nil(t, excludedCapacityTypes(context.Background(), otherPool, scope, opts))
+
+	opts.ProtectOnDemandWhenSpotUnstable = false
+	assert.Nil(t, excludedCapacityTypes(context.Background(), unstable, scope, opts))
+}
+
+func TestSpotInterruptionScope(t *testing.T) {
+	poolClaim := newNodeClaim("np-abcde", "node-gone", "", true)
+	poolClaim.SetLabels(map[string]string{nodepoolLabel: "np"})
+	otherClaim := newNodeClaim("other-fghij", "other-1", "", false)
+	otherClaim.SetLabels(map[string]string{nodepoolLabel: "other"})
+
+	scope := spotInterruptionScope(context.Background(), newNodeClaimDynamicClient(poolClaim, otherClaim), "np", []coreV1.Node{*newNode("np", 1)})
+	assert.Equal(t, map[string]bool{"node-1": true, "node-gone": true}, scope)
+
+	// NodeClaim을 조회할 수 없으면 현재 노드만
+	scope = spotInterruptionScope(context.Background(), nil, "np", []coreV1.Node{*newNode("np", 1)})
+	assert.Equal(t, map[string]bool{"node-1": true}, scope)
+}
+
+func newCapacityTypeNode(nodepool string, order int, capacityType string) *coreV1.Node {
+	node := newNode(nodepool, order)
+	node.Labels[capacityTypeLabel] = capacityType
+	return node
+}
+
+func newSpotInterruptedEvent(nodeName string, count int32) *coreV1.Event {
+	return &coreV1.Event{
+		ObjectMeta:     metaV1.ObjectMeta{Name: nodeName + ".spot", Namespace: "default"},
+		InvolvedObject: coreV1.ObjectReference{Kind: "Node", Name: nodeName},
+		Reason:         "SpotInterrupted",
+		Count:          count,
+		LastTimestamp:  metaV1.NewTime(time.Now().Add(-5 * time.Minute)),
+	}
+}
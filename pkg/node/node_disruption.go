@@ -0,0 +1,121 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+const (
+	// karpenterDisruptedTaintKey는 Karpenter가 중단(consolidation/drift/expiration)을 시작한 노드에 붙이는 taint입니다.
+	karpenterDisruptedTaintKey = "karpenter.sh/disrupted"
+
+	// nodeClaimConditionDisruptionReason은 Karpenter가 NodeClaim을 중단 대상으로 표시할 때 설정하는 condition입니다.
+	nodeClaimConditionDisruptionReason = "DisruptionReason"
+	// nodeClaimConditionInstanceTerminating은 NodeClaim의 인스턴스가 종료 중임을 의미하는 condition입니다.
+	nodeClaimConditionInstanceTerminating = "InstanceTerminating"
+
+	disruptingReasonTaint                = "disrupted-taint"
+	disruptingReasonDeleting             = "deleting"
+	disruptingReasonNodeClaimTerminating = "nodeclaim-terminating"
+	disruptingReasonNodeClaimDisrupted   = "nodeclaim-disrupted"
+)
+
+var nodeClaimGVR = schema.GroupVersionResource{Group: "karpenter.sh", Version: "v1", Resource: "nodeclaims"}
+
+// karpenterDisruptingReason은 노드 자체의 상태(taint, deletionTimestamp)로 Karpenter 중단 여부를 판단합니다.
+func karpenterDisruptingReason(n coreV1.Node) string {
+	if n.DeletionTimestamp != nil {
+		return disruptingReasonDeleting
+	}
+	for _, taint := range n.Spec.Taints {
+		if taint.Key == karpenterDisruptedTaintKey {
+			return disruptingReasonTaint
+		}
+	}
+	return ""
+}
+
+// disruptingNodeClaims는 종료 중이거나 중단 대상으로 표시된 NodeClaim의 노드 이름과 사유를 반환합니다.
+func disruptingNodeClaims(ctx context.Context, dynamicClient dynamic.Interface) (map[string]string, error) {
+	list, err := dynamicClient.Resource(nodeClaimGVR).List(ctx, metaV1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("NodeClaim 조회 실패: %w", err)
+	}
+
+	disrupting := map[string]string{}
+	for _, item := range list.Items {
+		nodeName, _, _ := unstructured.NestedString(item.Object, "status", "nodeName")
+		if nodeName == "" {
+			continue
+		}
+		if reason := nodeClaimDisruptingReason(item); reason != "" {
+			disrupting[nodeName] = reason
+		}
+	}
+	return disrupting, nil
+}
+
+func nodeClaimDisruptingReason(nodeClaim unstructured.Unstructured) string {
+	if nodeClaim.GetDeletionTimestamp() != nil {
+		return disruptingReasonNodeClaimTerminating
+	}
+
+	conditions, _, _ := unstructured.NestedSlice(nodeClaim.Object, "status", "conditions")
+	for _, c := range conditions {
+		cond, ok := c.(map[string]interface{})
+		if !ok || cond["status"] != string(metaV1.ConditionTrue) {
+			continue
+		}
+		switch cond["type"] {
+		case nodeClaimConditionInstanceTerminating:
+			return disruptingReasonNodeClaimTerminating
+		case nodeClaimConditionDisruptionReason:
+			return disruptingReasonNodeClaimDisrupted
+		}
+	}
+
+	taints, _, _ := unstructured.NestedSlice(nodeClaim.Object, "spec", "taints")
+	for _, t := range taints {
+		if taint, ok := t.(map[string]interface{}); ok && taint["key"] == karpenterDisruptedTaintKey {
+			return disruptingReasonNodeClaimDisrupted
+		}
+	}
+	return ""
+}
+
+// excludeKarpenterDisruptingNodes는 Karpenter가 이미 중단 중인 노드를 후보에서 제외합니다.
+// 제외된 노드(이름 -> 사유)는 드레인 상한 계산에 포함하기 위해 함께 반환합니다.
+// NodeClaim 조회에 실패하면 노드 상태 기반 확인만 적용하고 진행합니다.
+func excludeKarpenterDisruptingNodes(ctx context.Context, dynamicClient dynamic.Interface, nodes []coreV1.Node) ([]coreV1.Node, map[string]string) {
+	var nodeClaimReasons map[string]string
+	if dynamicClient != nil {
+		reasons, err := disruptingNodeClaims(ctx, dynamicClient)
+		if err != nil {
+			slog.Warn("NodeClaim 기반 Karpenter 중단 확인 실패(노드 상태만으로 판단)", "error", err)
+		}
+		nodeClaimReasons = reasons
+	}
+
+	disrupting := map[string]string{}
+	remaining := make([]coreV1.Node, 0, len(nodes))
+	for _, n := range nodes {
+		reason := karpenterDisruptingReason(n)
+		if reason == "" {
+			reason = nodeClaimReasons[n.Name]
+		}
+		if reason != "" {
+			slog.Info("Karpenter가 중단 중인 노드를 후보에서 제외", "nodeName", n.Name, "reason", reason)
+			disrupting[n.Name] = reason
+			continue
+		}
+		remaining = append(remaining, n)
+	}
+	return remaining, disrupting
+}
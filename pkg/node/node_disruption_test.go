@@ -0,0 +1,128 @@
+package node
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestExcludeKarpenterDisruptingNodes(t *testing.T) {
+	nodes := []coreV1.Node{
+		*newNode("np", 1),
+		*newNode("np", 2),
+		*newNode("np", 3),
+		*newNode("np", 4),
+		*newNode("np", 5),
+	}
+	nodes[0].Spec.Taints = []coreV1.Taint{{Key: karpenterDisruptedTaintKey, Effect: coreV1.TaintEffectNoSchedule}}
+	now := metaV1.Now()
+	nodes[1].DeletionTimestamp = &now
+
+	dynamicClient := newNodeClaimDynamicClient(
+		newNodeClaim("nc-3", "node-3", "DisruptionReason", false),
+		newNodeClaim("nc-4", "node-4", "", true),
+		newNodeClaim("nc-5", "node-5", "Drifted", false),
+	)
+
+	remaining, disrupting := excludeKarpenterDisruptingNodes(context.Background(), dynamicClient, nodes)
+	assert.Equal(t, []string{"node-5"}, nodeNames(remaining))
+	assert.Equal(t, map[string]string{
+		"node-1": disruptingReasonTaint,
+		"node-2": disruptingReasonDeleting,
+		"node-3": disruptingReasonNodeClaimDisrupted,
+		"node-4": disruptingReasonNodeClaimTerminating,
+	}, disrupting)
+
+	// dynamic client가 없으면 노드 상태만으로 판단
+	remaining, disrupting = excludeKarpenterDisruptingNodes(context.Background(), nil, nodes)
+	assert.Equal(t, []string{"node-3", "node-4", "node-5"}, nodeNames(remaining))
+	assert.Len(t, disrupting, 2)
+}
+
+func TestNodeDrainCountsKarpenterDisruptingNodesTowardCap(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxAbsolute string
+		expected    []string
+	}{
+		// 동시 중단 상한 2대 중 Karpenter가 1대를 중단 중이므로 1대만 드레인
+		{name: "상한에서 중단 중인 노드 차감", maxAbsolute: "2", expected: []string{"node-2"}},
+		// 상한이 여유 있으면 정책 계산(2대)을 다시 줄이지 않음
+		{name: "상한 여유가 있으면 정책 계산 유지", maxAbsolute: "0", expected: []string{"node-2", "node-3"}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			setDefaultDrainEnv(t)
+			t.Setenv("DRAIN_MAX_ABSOLUTE", tt.maxAbsolute)
+
+			clientSet := fake.NewSimpleClientset()
+			nodepoolName := "test-nodepool"
+			for i := 1; i <= 4; i++ {
+				node := newNode(nodepoolName, i)
+				if i == 1 {
+					node.Spec.Taints = []coreV1.Taint{{Key: karpenterDisruptedTaintKey, Effect: coreV1.TaintEffectNoSchedule}}
+				}
+				if _, err := clientSet.CoreV1().Nodes().Create(context.Background(), node, metaV1.CreateOptions{}); err != nil {
+					t.Fatalf("노드 생성 실패: %v", err)
+				}
+			}
+
+			// lenNodes=4, memory=30,cpu=25 => 정책 계산 2대
+			// (Drifted는 중단 후보일 뿐 중단 시작 전이므로 제외하지 않음)
+			results, err := NodeDrain(context.Background(), clientSet, DrainDependencies{
+				AllocateRateProvider: fakeAllocateRateProvider{rates: map[string]int{"memory": 30, "cpu": 25}},
+				Notifier:             fakeNotifier{},
+				DynamicClient:        newNodeClaimDynamicClient(newNodeClaim("nc-2", "node-2", "Drifted", false)),
+			}, DrainConfig{
+				NodepoolName: nodepoolName,
+				Eviction:     testEvictionConfig(),
+			})
+			if err != nil {
+				t.Fatalf("NodeDrain 실패: %v", err)
+			}
+			drained := make([]string, 0, len(results))
+			for _, r := range results {
+				drained = append(drained, r.NodeName)
+			}
+			assert.Equal(t, tt.expected, drained)
+			assertNodeUnschedulable(t, clientSet, "node-4", false)
+		})
+	}
+}
+
+func newNodeClaimDynamicClient(objects ...runtime.Object) *dynamicFake.FakeDynamicClient {
+	return dynamicFake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		nodeClaimGVR: "NodeClaimList",
+		nodePoolGVR:  "NodePoolList",
+	}, objects...)
+}
+
+func newNodeClaim(name string, nodeName string, conditionType string, deleting bool) *unstructured.Unstructured {
+	nodeClaim := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "karpenter.sh/v1",
+		"kind":       "NodeClaim",
+		"metadata":   map[string]interface{}{"name": name},
+		"status":     map[string]interface{}{"nodeName": nodeName},
+	}}
+	if conditionType != "" {
+		_ = unstructured.SetNestedSlice(nodeClaim.Object, []interface{}{
+			map[string]interface{}{"type": conditionType, "status": "True"},
+		}, "status", "conditions")
+	}
+	if deleting {
+		now := metaV1.Now()
+		nodeClaim.SetDeletionTimestamp(&now)
+		nodeClaim.SetFinalizers([]string{"karpenter.sh/termination"})
+	}
+	return nodeClaim
+}
@@ -1,22 +1,25 @@
 package node
 
 import (
+	"app/pkg/karpenter"
 	"app/pkg/notification"
 	"app/pkg/pod"
 	"app/types"
 	"context"
 	"fmt"
 	"log/slog"
-	"math"
 	"os"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	coreV1 "k8s.io/api/core/v1"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	policyv1listers "k8s.io/client-go/listers/policy/v1"
 )
 
 type allocateRateProvider interface {
@@ -27,6 +30,12 @@ type allocateRateProvider interface {
 type DrainDependencies struct {
 	AllocateRateProvider allocateRateProvider
 	Notifier             notification.Notifier
+	// DynamicClient는 Karpenter NodeClaim 조회에 사용합니다. nil 이면 NodeClaim 기반 확인을 건너뜁니다.
+	DynamicClient dynamic.Interface
+	// SafetyQuerier는 안전 조건 PromQL 조회에 사용합니다. nil 이면 환경 변수 기반 Prometheus 클라이언트를 생성합니다.
+	SafetyQuerier karpenter.MetricsQuerier
+	// PDBLister는 eviction 시 PDB 조회에 사용합니다. nil 이면 실행 동안 PDB informer를 시작합니다.
+	PDBLister policyv1listers.PodDisruptionBudgetLister
 }
 
 // DrainConfig defines node drain behavior.
@@ -45,6 +54,18 @@ func DefaultDrainConfig(nodepoolName string) DrainConfig {
 
 // NodeDrain cordons and drains selected nodes from a nodepool.
 func NodeDrain(ctx context.Context, clientSet kubernetes.Interface, deps DrainDependencies, cfg DrainConfig) ([]types.NodeDrainResult, error) {
+	results, _, err := NodeDrainWithSummary(ctx, clientSet, deps, cfg)
+	return results, err
+}
+
+// NodeDrainWithSummary drains like NodeDrain and also returns the run summary (planned/drained node counts, pod counts and why the run stopped).
+func NodeDrainWithSummary(ctx context.Context, clientSet kubernetes.Interface, deps DrainDependencies, cfg DrainConfig) ([]types.NodeDrainResult, types.NodeDrainSummary, error) {
+	summary := types.NodeDrainSummary{TargetNodepool: cfg.NodepoolName}
+	results, err := nodeDrain(ctx, clientSet, deps, cfg, &summary)
+	return results, summary, err
+}
+
+func nodeDrain(ctx context.Context, clientSet kubernetes.Interface, deps DrainDependencies, cfg DrainConfig, summary *types.NodeDrainSummary) ([]types.NodeDrainResult, error) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
@@ -55,29 +76,209 @@ func NodeDrain(ctx context.Context, clientSet kubernetes.Interface, deps DrainDe
 	if deps.AllocateRateProvider == nil {
 		return nil, fmt.Errorf("allocate rate provider is required")
 	}
+	if err := validateNodeConcurrency(GetNodeConcurrencyFromEnv(), parseEnvBool("DRAIN_PROGRESSIVE", true), GetWorkloadRecoveryOptionsFromEnv()); err != nil {
+		return nil, err
+	}
+	policyOpts := GetDrainPolicyOptionsFromEnv()
+	if policyOpts.AllocateResourcesErr != nil {
+		// 잘못된 리소스 설정을 memory/cpu로 대신 판단하면 GPU nodepool 등에서 잘못된 신호로 드레인하므로 실행하지 않습니다.
+		return nil, policyOpts.AllocateResourcesErr
+	}
 
 	nodepoolNodes, err := getNodepoolNodes(ctx, clientSet, cfg.NodepoolName)
 	if err != nil {
 		return nil, err
 	}
 
-	sort.Slice(nodepoolNodes, func(i, j int) bool {
-		return nodepoolNodes[i].CreationTimestamp.Before(&nodepoolNodes[j].CreationTimestamp)
-	})
+	allNodepoolNodes := nodepoolNodes
+
+	var spotScope map[string]bool
+	capacityTypeOpts := GetCapacityTypeOptionsFromEnv()
+	if capacityTypeOpts.ProtectOnDemandWhenSpotUnstable {
+		spotScope = spotInterruptionScope(ctx, deps.DynamicClient, cfg.NodepoolName, allNodepoolNodes)
+	}
+
+	nodepoolNodes, disrupting := excludeKarpenterDisruptingNodes(ctx, deps.DynamicClient, nodepoolNodes)
 
-	drainNodeCount, err := getDrainNodeCount(ctx, deps, len(nodepoolNodes))
+	nodepoolNodes, scores := scoreNodes(ctx, clientSet, nodepoolNodes, GetScoringOptionsFromEnv())
+
+	candidates := classifyUnhealthyNodes(ctx, clientSet, nodepoolNodes, GetUnhealthyOptionsFromEnv())
+	candidates = annotateScores(candidates, scores)
+	candidates = annotateCapacityTypes(candidates, capacityTypeOpts)
+	filterOpts := GetNodeFilterOptionsFromEnv()
+	candidates, err = filterDrainCandidates(candidates, filterOpts)
+	if err != nil {
+		return nil, err
+	}
+	candidates = orderDrainCandidates(candidates, policyOpts.ZoneInterleave)
+
+	var budget *types.DrainBudgetStatus
+	if budgetOpts := GetRollingBudgetOptionsFromEnv(); budgetOpts.Enabled() {
+		status, err := GetRollingBudgetStatus(ctx, clientSet, cfg.NodepoolName, budgetOpts)
+		if err != nil {
+			return nil, err
+		}
+		slog.Info("드레인 예산", "maxNodes", status.MaxNodes, "window", status.Window, "drainedInWindow", status.DrainedInWindow, "remaining", status.Remaining)
+		notifyDrainBudget(ctx, deps, status)
+		budget = &status
+	}
+
+	totalNodes := len(nodepoolNodes) + len(disrupting)
+	summary.TotalNodesInNodepool = totalNodes
+	drainNodeCount, forecast, err := getDrainNodeCount(ctx, clientSet, deps, cfg.NodepoolName, totalNodes, len(disrupting), countUnhealthyCandidates(candidates), budget, summary)
 	if err != nil {
 		return nil, err
 	}
-	slog.Info("드레인 할 노드 개수", "drainNodeCount", drainNodeCount)
 
-	nodesToDrain := nodepoolNodes[:drainNodeCount]
-	return handleDrain(ctx, clientSet, nodesToDrain, deps, cfg)
+	var karpenterBudget *karpenterBudgetLimit
+	if karpenterBudgetOpts := GetKarpenterBudgetOptionsFromEnv(); karpenterBudgetOpts.Enabled {
+		karpenterBudget, err = loadKarpenterBudgetLimit(ctx, deps.DynamicClient, clientSet, cfg.NodepoolName, len(disrupting), karpenterBudgetOpts, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		if karpenterBudget != nil && drainNodeCount > karpenterBudget.remaining() {
+			slog.Info("Karpenter disruption budget으로 드레인 노드 수 축소", "drainNodeCount", drainNodeCount, "adjusted", karpenterBudget.remaining())
+			drainNodeCount = karpenterBudget.remaining()
+		}
+	}
+	if policyOpts.Policy == DrainPolicyTarget {
+		slog.Info("드레인 노드 수 상한(target 정책은 예상 사용률로 후보 선택)", "maxDrainNodeCount", drainNodeCount)
+	} else {
+		slog.Info("드레인 할 노드 개수", "drainNodeCount", drainNodeCount)
+	}
+
+	var target *targetAllocateBudget
+	if policyOpts.Policy == DrainPolicyTarget && drainNodeCount > 0 {
+		target, err = loadTargetAllocateBudget(ctx, clientSet, deps, cfg.NodepoolName, disrupting, policyOpts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	plan := drainPlan{
+		nodepoolName:    cfg.NodepoolName,
+		filter:          filterOpts,
+		totalNodes:      totalNodes,
+		drainNodeCount:  drainNodeCount,
+		disrupting:      disrupting,
+		budget:          budget,
+		karpenterBudget: karpenterBudget,
+		forecast:        forecast,
+		limits: drainCandidateLimits{
+			zoneCaps:              zoneDrainCaps(allNodepoolNodes, disrupting, policyOpts),
+			capacityTypeCaps:      capacityTypeDrainCaps(capacityTypeOpts),
+			excludedCapacityTypes: excludedCapacityTypes(ctx, clientSet, spotScope, capacityTypeOpts),
+			target:                target,
+		},
+	}
+	plan.selected = pickDrainCandidates(candidates, drainNodeCount, plan.limits)
+	if target != nil {
+		// target 정책의 drainNodeCount는 상한일 뿐이므로 실제로 선택한 노드 수로 기록합니다.
+		plan.drainNodeCount = len(plan.selected)
+	}
+	if parseEnvBool("DRAIN_FEASIBILITY_CHECK", false) {
+		plan.selected, plan.infeasible, err = checkSchedulingFeasibility(ctx, clientSet, plan.selected, disrupting)
+		if err != nil {
+			return nil, err
+		}
+	}
+	plan.log()
+	summary.PlannedDrainNodeCount = len(plan.selected)
+
+	return handleDrain(ctx, clientSet, plan.selected, deps, cfg, summary)
+}
+
+// drainCandidate는 드레인 후보 노드와 선택 사유를 담습니다.
+type drainCandidate struct {
+	node               coreV1.Node
+	unhealthyCondition string
+	capacityType       string
+	capacityTypeRank   int
+	score              nodeScore
+	// projectedAllocateRate는 target 정책에서 이 후보까지 드레인했을 때의 예상 사용률(%)입니다.
+	projectedAllocateRate int
+}
+
+// drainCandidateLimits는 후보 선택 단계에서 적용하는 상한/제외 조건입니다. nil 맵은 제한 없음을 의미합니다.
+type drainCandidateLimits struct {
+	zoneCaps              map[string]int
+	capacityTypeCaps      map[string]int
+	excludedCapacityTypes map[string]bool
+	target                *targetAllocateBudget // target 정책일 때만 설정
+}
+
+// orderDrainCandidates는 비정상 노드 → capacity-type 순위 순으로 안정 정렬한 뒤, 필요하면 존을 번갈아 배치합니다.
+// 같은 우선순위 안에서는 기존 순서(점수가 높은 노드부터)를 유지합니다.
+func orderDrainCandidates(candidates []drainCandidate, zoneInterleave bool) []drainCandidate {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return lessDrainPriority(candidates[i], candidates[j])
+	})
+	if zoneInterleave {
+		candidates = interleaveByZone(candidates)
+	}
+	return candidates
+}
+
+func lessDrainPriority(a, b drainCandidate) bool {
+	aUnhealthy, bUnhealthy := a.unhealthyCondition != "", b.unhealthyCondition != ""
+	if aUnhealthy != bUnhealthy {
+		return aUnhealthy
+	}
+	return a.capacityTypeRank < b.capacityTypeRank
 }
 
+// pickDrainCandidates는 정렬된 후보 중 앞에서부터 count개를 고르되, 상한을 넘거나 제외된 후보는 건너뜁니다.
+func pickDrainCandidates(candidates []drainCandidate, count int, limits drainCandidateLimits) []drainCandidate {
+	picked := make([]drainCandidate, 0, count)
+	pickedByZone := map[string]int{}
+	pickedByCapacityType := map[string]int{}
+	targetRemoved := map[coreV1.ResourceName]float64{}
+	if limits.target != nil {
+		candidates = limits.target.orderBySize(candidates)
+	}
+	for _, c := range candidates {
+		if len(picked) >= count {
+			break
+		}
+		if limits.excludedCapacityTypes[c.capacityType] {
+			slog.Info("capacity-type 보호로 후보 제외", "nodeName", c.node.Name, "capacityType", c.capacityType)
+			continue
+		}
+		if capValue, ok := limits.capacityTypeCaps[c.capacityType]; ok && pickedByCapacityType[c.capacityType] >= capValue {
+			slog.Info("capacity-type별 드레인 상한으로 후보 제외", "nodeName", c.node.Name, "capacityType", c.capacityType, "cap", capValue)
+			continue
+		}
+		zone := nodeZone(c.node)
+		if limits.zoneCaps != nil && pickedByZone[zone] >= limits.zoneCaps[zone] {
+			slog.Info("존별 드레인 상한으로 후보 제외", "nodeName", c.node.Name, "zone", zone, "zoneCap", limits.zoneCaps[zone])
+			continue
+		}
+		if limits.target != nil {
+			ok, projected := limits.target.admits(c.node, targetRemoved)
+			if !ok {
+				slog.Info("target 사용률 초과로 후보 제외", "nodeName", c.node.Name, "projectedAllocateRate", projected, "targetAllocateRate", limits.target.targetRate)
+				continue
+			}
+			limits.target.remove(c.node, targetRemoved)
+			c.projectedAllocateRate = projected
+		}
+		pickedByZone[zone]++
+		pickedByCapacityType[c.capacityType]++
+		picked = append(picked, c)
+	}
+
+	if limits.target != nil {
+		slog.Info("target 정책으로 드레인 노드 선택", "selected", len(picked), "maxDrainNodeCount", count, "projectedAllocateRate", limits.target.projectedRate(targetRemoved), "targetAllocateRate", limits.target.targetRate)
+	} else if len(picked) < count {
+		slog.Info("후보 선택 상한으로 드레인 노드 수 축소", "drainNodeCount", count, "picked", len(picked))
+	}
+	return picked
+}
+
+// getNodepoolNodes는 nodepool의 모든 노드를 조회합니다. 노드 필터는 후보 선택 단계(filterDrainCandidates)에서 적용합니다.
 func getNodepoolNodes(ctx context.Context, clientSet kubernetes.Interface, nodepoolName string) ([]coreV1.Node, error) {
 	nodes, err := clientSet.CoreV1().Nodes().List(ctx, metaV1.ListOptions{
-		LabelSelector: fmt.Sprintf("karpenter.sh/nodepool=%s", nodepoolName),
+		LabelSelector: fmt.Sprintf("%s=%s", nodepoolLabel, nodepoolName),
 	})
 	if err != nil {
 		return nil, err
@@ -85,7 +286,11 @@ func getNodepoolNodes(ctx context.Context, clientSet kubernetes.Interface, nodep
 	return nodes.Items, nil
 }
 
-func getDrainNodeCount(ctx context.Context, deps DrainDependencies, lenNodes int) (int, error) {
+// getDrainNodeCount는 정책에 따라 드레인할 노드 수를 계산합니다.
+// unhealthyCount는 비정상 노드 우선 드레인 모드에서 감지된 비정상 노드 수이며, 안전 조건에 걸리지 않는 한 상한 내에서 최소 드레인 수로 사용합니다.
+// budget이 있으면 기간 내 이미 드레인한 노드 수만큼 상한을 줄이고, disruptingCount(Karpenter가 중단 중인 노드 수)만큼 동시 중단 상한을 줄입니다.
+// 안전 조건으로 0대가 되면 summary에 사유를 기록합니다.
+func getDrainNodeCount(ctx context.Context, clientSet kubernetes.Interface, deps DrainDependencies, nodepoolName string, lenNodes int, disruptingCount int, unhealthyCount int, budget *types.DrainBudgetStatus, summary *types.NodeDrainSummary) (int, *allocateForecast, error) {
 	slog.Info("노드 사용률 조회 중")
 	slog.Info("현재 노드 개수", "lenNodes", lenNodes)
 
@@ -95,125 +300,437 @@ func getDrainNodeCount(ctx context.Context, deps DrainDependencies, lenNodes int
 		}
 	}
 
-	memoryAllocateRate, err := deps.AllocateRateProvider.GetAllocateRate(ctx, "memory")
-	if err != nil {
-		return 0, err
+	opts := GetDrainPolicyOptionsFromEnv()
+
+	// 쿠버네티스 기반 안전 조건은 Prometheus 장애와 무관하게 먼저 평가
+	if clusterSafety, err := ShouldBlockDrainByClusterConditions(ctx, clientSet, nodepoolName, GetClusterSafetyOptionsFromEnv(), opts.SafetyFailClosed, time.Now()); clusterSafety.Blocked {
+		slog.Warn("쿠버네티스 안전 조건에 의해 드레인을 수행하지 않습니다.", "reason", clusterSafety.Reason, "error", err)
+		notifySafetyBlocked(ctx, deps, clusterSafety)
+		recordSafetyStop(summary, clusterSafety)
+		return 0, nil, nil
 	}
-	cpuAllocateRate, err := deps.AllocateRateProvider.GetAllocateRate(ctx, "cpu")
+	rates, err := getAllocateRates(ctx, deps.AllocateRateProvider, opts.AllocateResources)
 	if err != nil {
-		return 0, err
+		return 0, nil, err
 	}
 
-	if deps.Notifier != nil {
-		if err := deps.Notifier.SendKarpenterAllocateRate(ctx, memoryAllocateRate, cpuAllocateRate); err != nil {
-			slog.Error("Karpenter 사용률 알림 전송 실패", "error", err)
-		}
+	notifyAllocateRates(ctx, deps, opts.AllocateResources, rates)
+
+	for _, r := range opts.AllocateResources {
+		slog.Info("리소스 사용률", "resource", r.Name, "allocateRate", rates[r.Name], "weight", r.Weight, "threshold", r.Threshold)
 	}
 
-	slog.Info("Memory 사용률", "memoryAllocateRate", memoryAllocateRate)
-	slog.Info("Cpu 사용률", "cpuAllocateRate", cpuAllocateRate)
+	maxAllocateRate, bottleneck := bottleneckAllocateRate(rates, opts.AllocateResources)
+	slog.Info("최대 사용률", "maxAllocateRate", maxAllocateRate, "bottleneck", bottleneck)
 
-	maxAllocateRate := int(math.Max(float64(memoryAllocateRate), float64(cpuAllocateRate)))
-	slog.Info("최대 사용률", "maxAllocateRate", maxAllocateRate)
+	if blocked, reason := ShouldBlockDrainByResourceThresholds(rates, opts.AllocateResources); blocked {
+		slog.Warn("리소스 임계값에 의해 드레인을 수행하지 않습니다.", "reason", reason)
+		recordSafetyStop(summary, SafetyDecision{Blocked: true, Reason: reason})
+		return 0, nil, nil
+	}
 
-	opts := GetDrainPolicyOptionsFromEnv()
-	blocked, reason, safetyErr := ShouldBlockDrainBySafetyConditions(maxAllocateRate, opts)
-	if safetyErr != nil {
-		slog.Warn("드레인 안전 조건 평가 중 오류", "error", safetyErr, "blocked", blocked, "reason", reason)
+	blocked, reason, hysteresisErr := ShouldBlockDrainByHysteresis(ctx, deps.AllocateRateProvider, opts)
+	if hysteresisErr != nil {
+		slog.Warn("hysteresis 평가 중 오류", "error", hysteresisErr, "blocked", blocked, "reason", reason)
 	}
 	if blocked {
-		slog.Warn("안전 조건에 의해 드레인을 수행하지 않습니다.", "reason", reason)
-		return 0, nil
+		slog.Warn("hysteresis 조건에 의해 드레인을 수행하지 않습니다.", "reason", reason)
+		stopReason := "hysteresis: " + reason
+		if hysteresisErr != nil {
+			stopReason = fmt.Sprintf("hysteresis 평가 실패(fail-closed): %s: %v", reason, hysteresisErr)
+		}
+		recordSafetyStop(summary, SafetyDecision{Blocked: true, Reason: stopReason})
+		return 0, nil, nil
+	}
+
+	safety, safetyErr := ShouldBlockDrainBySafetyConditions(ctx, deps.SafetyQuerier, maxAllocateRate, opts)
+	if safetyErr != nil {
+		slog.Warn("드레인 안전 조건 평가 중 오류", "error", safetyErr, "blocked", safety.Blocked, "reason", safety.Reason)
+	}
+	if safety.Blocked {
+		slog.Warn("안전 조건에 의해 드레인을 수행하지 않습니다.", "reason", safety.Reason)
+		notifySafetyBlocked(ctx, deps, safety)
+		recordSafetyStop(summary, safety)
+		return 0, nil, nil
 	}
 
-	drainNodeCount := CalculateDrainNodeCount(lenNodes, maxAllocateRate, opts)
-	slog.Info("드레인 정책", "policy", opts.Policy, "rounding", opts.Rounding, "minDrain", opts.MinDrain, "maxAbs", opts.MaxDrainAbsolute, "maxFraction", opts.MaxDrainFraction)
+	var forecast *allocateForecast
+	if opts.Policy == DrainPolicyForecast {
+		forecast, err = loadAllocateForecast(ctx, deps.AllocateRateProvider, opts)
+		if err != nil {
+			if opts.SafetyFailClosed {
+				slog.Warn("forecast 조회 실패로 드레인을 수행하지 않습니다.", "error", err)
+				recordSafetyStop(summary, SafetyDecision{Blocked: true, Reason: fmt.Sprintf("forecast fail-closed: %v", err)})
+				return 0, nil, nil
+			}
+			slog.Warn("forecast 조회 실패로 현재 사용률을 사용합니다.", "error", err)
+		} else {
+			maxAllocateRate = forecast.predict(rates, opts.AllocateResources)
+		}
+	}
+
+	drainNodeCount := CalculateDrainNodeCount(lenNodes, maxAllocateRate, opts, budget, disruptingCount)
+	if unhealthyCount > drainNodeCount {
+		drainNodeCount = clampInt(applyCaps(lenNodes, unhealthyCount, opts, budget, disruptingCount), 0, lenNodes)
+		slog.Info("비정상 노드 우선 드레인으로 드레인 노드 수 보정", "unhealthyCount", unhealthyCount, "drainNodeCount", drainNodeCount)
+	}
+	slog.Info("드레인 정책", "policy", opts.Policy, "rounding", opts.Rounding, "minDrain", opts.MinDrain, "maxAbs", opts.MaxDrainAbsolute, "maxFraction", opts.MaxDrainFraction, "maxPerZone", opts.MaxDrainPerZone, "maxFractionPerZone", opts.MaxDrainFractionPerZone, "zoneInterleave", opts.ZoneInterleave, "targetAllocateRate", opts.TargetAllocateRate)
 	slog.Info("드레인 할 노드 개수(정책 적용)", "drainNodeCount", drainNodeCount)
 
-	return drainNodeCount, nil
+	return drainNodeCount, forecast, nil
+}
+
+// nodeDrainOutcome은 노드 하나의 드레인 결과입니다. stop이면 이후 노드를 시작하지 않습니다.
+type nodeDrainOutcome struct {
+	result    types.NodeDrainResult
+	workloads []workloadRef
+	err       error
+	stop      bool
+}
+
+// GetNodeConcurrencyFromEnv는 동시에 드레인할 노드 수를 반환합니다. 기본값 1은 기존처럼 한 노드씩 드레인합니다.
+func GetNodeConcurrencyFromEnv() int {
+	concurrency := parseEnvInt("DRAIN_NODE_CONCURRENCY", 1)
+	if concurrency < 1 {
+		return 1
+	}
+	return concurrency
+}
+
+// validateNodeConcurrency는 여러 노드를 동시에 드레인할 때 함께 쓸 수 없는 설정을 거부합니다.
+// 워크로드 복구 대기는 "이전 노드의 워크로드가 복구된 뒤 다음 노드를 시작"하는 순차 보장이므로, 다른 노드가 이미 파드를 제거하는 동안에는 의미가 없습니다.
+// 동시 드레인에서는 새 노드를 시작하기 전에만 안전 조건을 재평가하고, 이미 진행 중인 노드는 끝까지 처리합니다.
+func validateNodeConcurrency(concurrency int, progressive bool, recovery WorkloadRecoveryOptions) error {
+	if concurrency > 1 && progressive && recovery.Enabled {
+		return fmt.Errorf("DRAIN_WAIT_WORKLOAD_RECOVERY는 DRAIN_NODE_CONCURRENCY=1 에서만 사용할 수 있습니다 (현재 %d)", concurrency)
+	}
+	return nil
 }
 
-func handleDrain(ctx context.Context, clientSet kubernetes.Interface, nodes []coreV1.Node, deps DrainDependencies, cfg DrainConfig) ([]types.NodeDrainResult, error) {
-	results := make([]types.NodeDrainResult, 0, len(nodes))
+// pdbInformerSyncTimeout은 PDB informer 캐시 동기화를 기다리는 최대 시간입니다.
+const pdbInformerSyncTimeout = 30 * time.Second
+
+// handleDrain은 선택한 노드를 드레인하고, 결과와 중단 사유를 summary에 기록합니다.
+func handleDrain(ctx context.Context, clientSet kubernetes.Interface, candidates []drainCandidate, deps DrainDependencies, cfg DrainConfig, summary *types.NodeDrainSummary) ([]types.NodeDrainResult, error) {
 	opts := GetDrainPolicyOptionsFromEnv()
 	progressive := parseEnvBool("DRAIN_PROGRESSIVE", true)
-	shouldSafetyRecheck := progressive && (opts.SafetyMaxAllocateRate > 0 || len(opts.SafetyQueries) > 0)
-
-	for i, n := range nodes {
-		if strings.TrimSpace(n.Labels["karpenter.sh/nodepool"]) != cfg.NodepoolName {
-			continue
+	shouldSafetyRecheck := progressive && (opts.SafetyMaxAllocateRate > 0 || len(opts.SafetyChecks) > 0 || opts.SafetyChecksErr != nil || hasResourceThreshold(opts.AllocateResources))
+	budgetOpts := GetRollingBudgetOptionsFromEnv()
+	clusterSafetyOpts := GetClusterSafetyOptionsFromEnv()
+	shouldSafetyRecheck = shouldSafetyRecheck || (progressive && clusterSafetyOpts.Enabled())
+	recoveryOpts := GetWorkloadRecoveryOptionsFromEnv()
+	shouldWaitRecovery := progressive && recoveryOpts.Enabled
+	concurrency := GetNodeConcurrencyFromEnv()
+	canaryOpts := GetCanaryOptionsFromEnv()
+	surgeOpts := GetSurgeOptionsFromEnv()
+	breakerOpts := GetCircuitBreakerOptionsFromEnv()
+	pacingOpts := GetPacingOptionsFromEnv()
+	unhealthyOpts := GetUnhealthyOptionsFromEnv()
+
+	// 여러 노드를 동시에 드레인하면 MaxConcurrentEvictions를 노드 전체에서 공유합니다. (PDB 토큰은 이미 프로세스 전역)
+	// MaxConcurrentEvictions는 normalizeDrainEvictionConfig에서 1 이상으로 보정되어 있습니다.
+	if concurrency > 1 {
+		shared := *cfg.Eviction
+		shared.EvictionSemaphore = make(chan struct{}, shared.MaxConcurrentEvictions)
+		cfg.Eviction = &shared
+	}
+	// 실행 전체의 eviction 결과를 집계해 circuit breaker 판단과 summary에 씁니다.
+	evictionStats := &pod.EvictionStats{}
+	withStats := *cfg.Eviction
+	withStats.Stats = evictionStats
+	cfg.Eviction = &withStats
+	// 적응형 pacing을 쓰면 고정 대기(PostEvictionNodeDelay) 대신 재스케줄링 상황에 맞춰 기다립니다.
+	if pacingOpts.Enabled {
+		paced := *cfg.Eviction
+		paced.PostEvictionNodeDelay = 0
+		cfg.Eviction = &paced
+	}
+	// PDB는 실행 동안 informer 캐시로 조회해 DisruptionsAllowed 변화를 바로 반영합니다. 시작에 실패하면 TTL 캐시를 사용합니다.
+	if cfg.Eviction.PDBLister == nil && len(candidates) > 0 {
+		lister := deps.PDBLister
+		if lister == nil {
+			pdbCtx, stopPDBInformer := context.WithCancel(ctx)
+			defer stopPDBInformer()
+			var err error
+			lister, err = pod.StartPDBInformer(pdbCtx, clientSet, pdbInformerSyncTimeout)
+			if err != nil {
+				slog.Warn("PDB informer 시작 실패(TTL 캐시로 조회)", "error", err)
+				stopPDBInformer()
+			}
 		}
-
-		start := time.Now()
-		result := types.NodeDrainResult{
-			NodeName:     n.Name,
-			InstanceType: n.Labels["beta.kubernetes.io/instance-type"],
-			NodepoolName: cfg.NodepoolName,
-			Age:          n.CreationTimestamp.Format(time.RFC3339),
-			StartedAt:    start.Format(time.RFC3339),
+		if lister != nil {
+			withLister := *cfg.Eviction
+			withLister.PDBLister = lister
+			cfg.Eviction = &withLister
 		}
+	}
+	unhealthyEviction := unhealthyEvictionConfig(cfg.Eviction, unhealthyOpts)
 
-		if err := CordonNode(ctx, clientSet, n.Name); err != nil {
-			result.Success = false
-			result.FailureReason = err.Error()
-			result.DurationSeconds = int64(time.Since(start).Seconds())
-			results = append(results, result)
-			return results, fmt.Errorf("노드 %s cordon 실패: %w", n.Name, err)
+	targets := make([]drainCandidate, 0, len(candidates))
+	for _, c := range candidates {
+		if strings.TrimSpace(c.node.Labels[nodepoolLabel]) == cfg.NodepoolName {
+			targets = append(targets, c)
 		}
+	}
+	shouldCanary := canaryOpts.Enabled && len(targets) > 1
 
-		if err := drainSingleNode(ctx, clientSet, n.Name, cfg.Eviction); err != nil {
-			result.Success = false
-			result.FailureReason = err.Error()
-			result.DurationSeconds = int64(time.Since(start).Seconds())
-			results = append(results, result)
-			return results, fmt.Errorf("노드 %s 드레인 실패: %w", n.Name, err)
+	// surge: 노드마다 cordon 전에 placeholder로 용량을 먼저 확보하고, 실행이 끝나면 placeholder를 정리합니다.
+	targetNames := make([]string, 0, len(targets))
+	for _, c := range targets {
+		targetNames = append(targetNames, c.node.Name)
+	}
+	if surgeOpts.Enabled && len(targets) > 0 {
+		if err := ensureSurgePriorityClass(ctx, clientSet, surgeOpts.PriorityClassName); err != nil {
+			slog.Warn("surge PriorityClass 준비 실패(surge 생략)", "error", err)
+			surgeOpts.Enabled = false
+		} else {
+			defer cleanupSurgePlaceholders(context.WithoutCancel(ctx), clientSet, cfg.NodepoolName, surgeOpts)
 		}
+	}
+	if concurrency > 1 {
+		slog.Info("노드 병렬 드레인", "nodeConcurrency", concurrency, "nodes", len(targets))
+	}
 
-		result.Success = true
-		result.DurationSeconds = int64(time.Since(start).Seconds())
-		results = append(results, result)
+	outcomes := make([]*nodeDrainOutcome, len(targets))
+	slots := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	stopped := false
+	failedNodes := 0
+	var tripped *CircuitBreakerError
+	var canaryFailed *CanaryFailedError
+
+	for i, c := range targets {
+		// 빈 슬롯이 생길 때까지 기다립니다. concurrency가 1이면 이전 노드(복구 대기 포함)가 끝날 때까지 기다립니다.
+		// 안전 조건 재평가는 새 노드 시작만 막으며, concurrency가 1보다 크면 진행 중인 다른 노드는 계속 드레인합니다.
+		slots <- struct{}{}
+
+		mu.Lock()
+		stop := stopped
+		mu.Unlock()
+		if stop {
+			<-slots
+			break
+		}
+		if shouldSafetyRecheck && i > 0 {
+			if decision := recheckDrainSafety(ctx, clientSet, deps, cfg, opts, clusterSafetyOpts); decision.Blocked {
+				notifySafetyBlocked(ctx, deps, decision)
+				recordSafetyStop(summary, decision)
+				<-slots
+				break
+			}
+		}
 
-		if shouldSafetyRecheck && i < len(nodes)-1 {
-			memoryAllocateRate, memErr := deps.AllocateRateProvider.GetAllocateRate(ctx, "memory")
-			if memErr != nil {
-				slog.Warn("안전 재평가 중 Memory allocate rate 조회 실패(계속 진행)", "error", memErr)
+		evictionCfg := cfg.Eviction
+		if c.unhealthyCondition != "" {
+			evictionCfg = unhealthyEviction
+		}
+		waitRecovery := shouldWaitRecovery && i < len(targets)-1
+		pace := pacingOpts.Enabled && i < len(targets)-1 && !(c.unhealthyCondition != "" && unhealthyOpts.SkipPostEvictionDelay)
+		collectWorkloads := waitRecovery || pace || (shouldCanary && i == 0)
+
+		wg.Add(1)
+		go func(i int, c drainCandidate) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			if surgeOpts.Enabled {
+				if err := prepareSurgeCapacity(ctx, clientSet, c.node.Name, cfg.NodepoolName, targetNames, surgeOpts); err != nil {
+					slog.Warn("surge 용량 확보 실패(드레인 계속 진행)", "nodeName", c.node.Name, "error", err)
+				}
 			}
-			cpuAllocateRate, cpuErr := deps.AllocateRateProvider.GetAllocateRate(ctx, "cpu")
-			if cpuErr != nil {
-				slog.Warn("안전 재평가 중 CPU allocate rate 조회 실패(계속 진행)", "error", cpuErr)
+			outcome := drainCandidateNode(ctx, clientSet, c, cfg.NodepoolName, evictionCfg, collectWorkloads, waitRecovery, recoveryOpts, budgetOpts, pace, pacingOpts)
+
+			mu.Lock()
+			defer mu.Unlock()
+			// circuit breaker가 설정되면 노드 실패만으로는 중단하지 않고 breaker가 판단합니다.
+			// 실패한 노드는 결과와 반환 오류에 그대로 남습니다.
+			nodeFailed := outcome.err != nil && !outcome.result.Success
+			if nodeFailed {
+				failedNodes++
+				if breakerOpts.Enabled() {
+					slog.Warn("노드 드레인 실패(circuit breaker로 판단, 계속 진행)", "nodeName", c.node.Name, "failedNodes", failedNodes, "error", outcome.err)
+				}
 			}
-
-			maxRate := int(math.Max(float64(memoryAllocateRate), float64(cpuAllocateRate)))
-			blocked, reason, safetyErr := ShouldBlockDrainBySafetyConditions(maxRate, opts)
-			if safetyErr != nil {
-				slog.Warn("안전 재평가 중 오류", "error", safetyErr, "blocked", blocked, "reason", reason)
+			if (outcome.err != nil && !(nodeFailed && breakerOpts.Enabled())) || outcome.stop {
+				stopped = true
+			}
+			if breakerOpts.Enabled() && tripped == nil {
+				counts := newCircuitBreakerCounts(evictionStats, failedNodes)
+				logCircuitBreakerCounts(counts)
+				if tripped = checkCircuitBreakers(breakerOpts, counts); tripped != nil {
+					slog.Warn("circuit breaker 동작으로 추가 드레인을 중단합니다.", "breaker", tripped.Breaker, "reason", tripped.Reason)
+					stopped = true
+				}
+			}
+			outcomes[i] = &outcome
+		}(i, c)
+
+		// canary: 첫 노드가 끝나면 soak 기간 동안 점검하고, 통과해야 나머지 노드를 시작합니다.
+		if shouldCanary && i == 0 {
+			wg.Wait()
+			canary := outcomes[0]
+			if canary.err != nil || canary.stop || !canary.result.Success {
+				break
 			}
-			if blocked {
-				slog.Warn("안전 조건에 의해 추가 드레인을 중단합니다.", "reason", reason)
+			canaryResult, err := soakCanary(ctx, clientSet, canary.result.NodeName, canary.workloads, canaryOpts, func(ctx context.Context) SafetyDecision {
+				return recheckDrainSafety(ctx, clientSet, deps, cfg, opts, clusterSafetyOpts)
+			})
+			if err != nil {
+				canary.err = fmt.Errorf("canary 노드 %s soak 실패: %w", canary.result.NodeName, err)
+				break
+			}
+			if !canaryResult.Passed {
+				slog.Warn("canary 점검 실패로 추가 드레인을 중단합니다.", "nodeName", canaryResult.NodeName, "reason", canaryResult.Reason)
+				notifyCanaryFailed(ctx, deps, canaryResult)
+				canaryFailed = &CanaryFailedError{NodeName: canaryResult.NodeName, Reason: canaryResult.Reason}
 				break
 			}
 		}
 	}
+	wg.Wait()
 
-	memoryAllocateRate, err := deps.AllocateRateProvider.GetAllocateRate(ctx, "memory")
-	if err != nil {
-		return results, err
+	results := make([]types.NodeDrainResult, 0, len(targets))
+	var firstErr error
+	for _, outcome := range outcomes {
+		if outcome == nil {
+			continue
+		}
+		results = append(results, outcome.result)
+		if outcome.err != nil && firstErr == nil {
+			firstErr = outcome.err
+		}
+	}
+	summarizeDrainResults(summary, results, evictionStats)
+	if tripped != nil {
+		recordCircuitBreakerStop(summary, tripped)
+		if firstErr != nil {
+			return results, fmt.Errorf("%w (첫 실패: %w)", tripped, firstErr)
+		}
+		return results, tripped
+	}
+	if canaryFailed != nil {
+		recordCanaryStop(summary, canaryFailed)
+		return results, canaryFailed
+	}
+	if failedNodes > 1 {
+		return results, fmt.Errorf("노드 %d대 드레인 실패: %w", failedNodes, firstErr)
 	}
-	cpuAllocateRate, err := deps.AllocateRateProvider.GetAllocateRate(ctx, "cpu")
+	if firstErr != nil {
+		return results, firstErr
+	}
+
+	rates, err := getAllocateRates(ctx, deps.AllocateRateProvider, opts.AllocateResources)
 	if err != nil {
 		return results, err
 	}
+	for _, r := range opts.AllocateResources {
+		slog.Info("드레인 후 리소스 사용률", "resource", r.Name, "allocateRate", rates[r.Name])
+	}
+	notifyAllocateRates(ctx, deps, opts.AllocateResources, rates)
+
+	return results, nil
+}
 
-	slog.Info("Memory 사용률", "memoryAllocateRate", memoryAllocateRate)
-	slog.Info("Cpu 사용률", "cpuAllocateRate", cpuAllocateRate)
+// drainCandidateNode는 노드 하나를 cordon → 드레인 → 이력 기록 → (옵션) 워크로드 복구 대기 → (옵션) 적응형 pacing 순서로 처리합니다.
+// collectWorkloads면 드레인 전에 노드의 워크로드를 조회해 결과에 담습니다.
+func drainCandidateNode(ctx context.Context, clientSet kubernetes.Interface, c drainCandidate, nodepoolName string, evictionCfg *pod.EvictionConfig, collectWorkloads bool, waitRecovery bool, recoveryOpts WorkloadRecoveryOptions, budgetOpts RollingBudgetOptions, pace bool, pacingOpts PacingOptions) nodeDrainOutcome {
+	n := c.node
+	start := time.Now()
+	result := types.NodeDrainResult{
+		NodeName:           n.Name,
+		InstanceType:       nodeInstanceType(n),
+		NodepoolName:       nodepoolName,
+		Age:                n.CreationTimestamp.Format(time.RFC3339),
+		StartedAt:          start.Format(time.RFC3339),
+		Zone:               nodeZone(n),
+		CapacityType:       c.capacityType,
+		UnhealthyCondition: c.unhealthyCondition,
+		Score:              c.score.total,
+		ScoreBreakdown:     c.score.breakdown,
+	}
 
-	if deps.Notifier != nil {
-		if err := deps.Notifier.SendKarpenterAllocateRate(ctx, memoryAllocateRate, cpuAllocateRate); err != nil {
-			slog.Error("Karpenter 사용률 알림 전송 실패", "error", err)
+	if err := CordonNode(ctx, clientSet, n.Name); err != nil {
+		result.Success = false
+		result.FailureReason = err.Error()
+		result.DurationSeconds = int64(time.Since(start).Seconds())
+		return nodeDrainOutcome{result: result, err: fmt.Errorf("노드 %s cordon 실패: %w", n.Name, err)}
+	}
+
+	var workloads []workloadRef
+	if collectWorkloads {
+		var collectErr error
+		workloads, collectErr = collectNodeWorkloads(ctx, clientSet, n.Name)
+		if collectErr != nil {
+			slog.Warn("드레인 대상 워크로드 조회 실패(복구 확인 생략)", "nodeName", n.Name, "error", collectErr)
 		}
 	}
 
-	return results, nil
+	if err := drainSingleNode(ctx, clientSet, n.Name, evictionCfg); err != nil {
+		result.Success = false
+		result.FailureReason = err.Error()
+		result.DurationSeconds = int64(time.Since(start).Seconds())
+		return nodeDrainOutcome{result: result, err: fmt.Errorf("노드 %s 드레인 실패: %w", n.Name, err)}
+	}
+
+	result.Success = true
+	result.DurationSeconds = int64(time.Since(start).Seconds())
+
+	if budgetOpts.Enabled() {
+		if err := recordDrainHistory(ctx, clientSet, nodepoolName, n.Name, time.Now(), budgetOpts); err != nil {
+			slog.Warn("드레인 이력 기록 실패(계속 진행)", "nodeName", n.Name, "error", err)
+		}
+	}
+
+	if waitRecovery {
+		if err := waitForWorkloadRecovery(ctx, clientSet, workloads, recoveryOpts); err != nil {
+			switch recoveryOpts.FailPolicy {
+			case WorkloadRecoveryFailContinue:
+				slog.Warn("워크로드 복구 대기 실패(다음 노드 진행)", "nodeName", n.Name, "error", err)
+			case WorkloadRecoveryFailError:
+				return nodeDrainOutcome{result: result, workloads: workloads, err: fmt.Errorf("노드 %s 이후 %w", n.Name, err)}
+			default:
+				slog.Warn("워크로드 복구 대기 실패로 추가 드레인을 중단합니다.", "nodeName", n.Name, "error", err)
+				return nodeDrainOutcome{result: result, workloads: workloads, stop: true}
+			}
+		}
+	}
+
+	if pace {
+		waitAdaptivePacing(ctx, clientSet, n.Name, workloads, pacingOpts)
+	}
+
+	return nodeDrainOutcome{result: result, workloads: workloads}
+}
+
+// recheckDrainSafety는 다음 노드를 시작하기 전에 안전 조건을 다시 평가합니다.
+// 차단 알림은 보내지 않으므로, 호출하는 쪽에서 필요하면 notifySafetyBlocked를 호출합니다. (canary soak는 canary 실패 알림만 보냄)
+func recheckDrainSafety(ctx context.Context, clientSet kubernetes.Interface, deps DrainDependencies, cfg DrainConfig, opts DrainPolicyOptions, clusterSafetyOpts ClusterSafetyOptions) SafetyDecision {
+	clusterSafety, clusterErr := ShouldBlockDrainByClusterConditions(ctx, clientSet, cfg.NodepoolName, clusterSafetyOpts, opts.SafetyFailClosed, time.Now())
+	if clusterSafety.Blocked {
+		slog.Warn("쿠버네티스 안전 조건에 의해 추가 드레인을 중단합니다.", "reason", clusterSafety.Reason, "error", clusterErr)
+		return clusterSafety
+	}
+
+	rates, rateErr := getAllocateRates(ctx, deps.AllocateRateProvider, opts.AllocateResources)
+	if rateErr != nil {
+		slog.Warn("안전 재평가 중 allocate rate 조회 실패(계속 진행)", "error", rateErr)
+	}
+
+	maxRate, _ := bottleneckAllocateRate(rates, opts.AllocateResources)
+	if thresholdBlocked, thresholdReason := ShouldBlockDrainByResourceThresholds(rates, opts.AllocateResources); thresholdBlocked {
+		slog.Warn("리소스 임계값에 의해 추가 드레인을 중단합니다.", "reason", thresholdReason)
+		return SafetyDecision{Blocked: true, Reason: thresholdReason}
+	}
+	safety, safetyErr := ShouldBlockDrainBySafetyConditions(ctx, deps.SafetyQuerier, maxRate, opts)
+	if safetyErr != nil {
+		slog.Warn("안전 재평가 중 오류", "error", safetyErr, "blocked", safety.Blocked, "reason", safety.Reason)
+	}
+	if safety.Blocked {
+		slog.Warn("안전 조건에 의해 추가 드레인을 중단합니다.", "reason", safety.Reason)
+		return safety
+	}
+	return SafetyDecision{}
 }
 
 func drainSingleNode(ctx context.Context, clientSet kubernetes.Interface, nodeName string, cfg *pod.EvictionConfig) error {
@@ -244,32 +761,26 @@ func waitForPodsToTerminate(ctx context.Context, clientSet kubernetes.Interface,
 	slog.Info("노드에서 데몬셋 제외 파드 종료 대기 시작", "nodeName", nodeName)
 	cfg = normalizeDrainEvictionConfig(cfg)
 
+	// NodeTerminationTimeout은 노드에 남은 파드 중 가장 긴 grace period만큼 늘립니다.
 	waitCtx := ctx
 	if cfg.NodeTerminationTimeout > 0 {
+		timeout := cfg.NodeTerminationTimeout
+		if pods, err := pod.GetNonCriticalPods(ctx, clientSet, nodeName); err == nil {
+			timeout += pod.MaxGracePeriod(pods, cfg)
+		}
 		var cancel context.CancelFunc
-		waitCtx, cancel = context.WithTimeout(ctx, cfg.NodeTerminationTimeout)
+		waitCtx, cancel = context.WithTimeout(ctx, timeout)
 		defer cancel()
 	}
 
-	ticker := time.NewTicker(cfg.NodeTerminationCheckTick)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-waitCtx.Done():
+	// 삭제는 watch로 바로 관찰하고, NodeTerminationCheckTick은 watch를 쓸 수 없을 때의 폴링 주기로만 씁니다.
+	if err := pod.WaitForNonCriticalPodsTerminated(waitCtx, clientSet, nodeName, cfg.NodeTerminationCheckTick); err != nil {
+		if waitCtx.Err() != nil {
 			return fmt.Errorf("노드 %s 파드 종료 대기 타임아웃: %w", nodeName, waitCtx.Err())
-		case <-ticker.C:
-			pods, err := pod.GetNonCriticalPods(waitCtx, clientSet, nodeName)
-			if err != nil {
-				return fmt.Errorf("노드 %s 파드 조회 실패: %w", nodeName, err)
-			}
-			if len(pods) == 0 {
-				slog.Info("데몬셋 제외 모든 Pod 종료 완료", "nodeName", nodeName)
-				return nil
-			}
-			slog.Info("Pod 종료 대기 중", "nodeName", nodeName, "remainingPods", len(pods))
 		}
+		return fmt.Errorf("노드 %s 파드 조회 실패: %w", nodeName, err)
 	}
+	return nil
 }
 
 func normalizeDrainEvictionConfig(cfg *pod.EvictionConfig) *pod.EvictionConfig {
@@ -279,6 +790,9 @@ func normalizeDrainEvictionConfig(cfg *pod.EvictionConfig) *pod.EvictionConfig {
 	}
 
 	normalized := *cfg
+	if normalized.MaxConcurrentEvictions <= 0 {
+		normalized.MaxConcurrentEvictions = defaults.MaxConcurrentEvictions
+	}
 	if normalized.NodeTerminationTimeout <= 0 {
 		normalized.NodeTerminationTimeout = defaults.NodeTerminationTimeout
 	}
@@ -9,8 +9,11 @@ import (
 	"time"
 
 	coreV1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
+	policyv1listers "k8s.io/client-go/listers/policy/v1"
+	"k8s.io/client-go/tools/cache"
 )
 
 type fakeAllocateRateProvider struct {
@@ -207,6 +210,230 @@ func TestNodeDrainProgressiveDoesNotPreCordonRemainingNodes(t *testing.T) {
 	assertNodeUnschedulable(t, clientSet, "node-3", false)
 }
 
+func TestNodeDrainWithNodeConcurrency(t *testing.T) {
+	setDefaultDrainEnv(t)
+	t.Setenv("DRAIN_NODE_CONCURRENCY", "3")
+
+	clientSet := fake.NewSimpleClientset()
+	nodepoolName := "test-nodepool"
+	for i := 1; i <= 5; i++ {
+		if _, err := clientSet.CoreV1().Nodes().Create(context.Background(), newNode(nodepoolName, i), metaV1.CreateOptions{}); err != nil {
+			t.Fatalf("노드 생성 실패: %v", err)
+		}
+	}
+
+	results, err := NodeDrain(context.Background(), clientSet, DrainDependencies{
+		AllocateRateProvider: fakeAllocateRateProvider{rates: map[string]int{"memory": 9, "cpu": 9}},
+		Notifier:             fakeNotifier{},
+	}, DrainConfig{
+		NodepoolName: nodepoolName,
+		Eviction:     testEvictionConfig(),
+	})
+	if err != nil {
+		t.Fatalf("NodeDrain 실패: %v", err)
+	}
+
+	// 병렬로 드레인해도 결과는 노드 순서대로 보고
+	if len(results) != 4 {
+		t.Fatalf("drain 결과 개수 불일치: got=%d want=4", len(results))
+	}
+	for idx, result := range results {
+		if want := fmt.Sprintf("node-%d", idx+1); result.NodeName != want || !result.Success {
+			t.Fatalf("drain 결과 불일치: got=%+v want=%s", result, want)
+		}
+	}
+	assertNodeUnschedulable(t, clientSet, "node-4", true)
+	assertNodeUnschedulable(t, clientSet, "node-5", false)
+}
+
+func TestNodeDrainWithNodeConcurrencyNormalizesSharedEvictionLimit(t *testing.T) {
+	setDefaultDrainEnv(t)
+	t.Setenv("DRAIN_NODE_CONCURRENCY", "2")
+
+	clientSet := fake.NewSimpleClientset()
+	nodepoolName := "test-nodepool"
+	for i := 1; i <= 3; i++ {
+		if _, err := clientSet.CoreV1().Nodes().Create(context.Background(), newNode(nodepoolName, i), metaV1.CreateOptions{}); err != nil {
+			t.Fatalf("노드 생성 실패: %v", err)
+		}
+		if _, err := clientSet.CoreV1().Pods("default").Create(context.Background(), &coreV1.Pod{
+			ObjectMeta: metaV1.ObjectMeta{Name: fmt.Sprintf("pod-%d", i), Namespace: "default"},
+			Spec:       coreV1.PodSpec{NodeName: fmt.Sprintf("node-%d", i)},
+		}, metaV1.CreateOptions{}); err != nil {
+			t.Fatalf("파드 생성 실패: %v", err)
+		}
+	}
+
+	// MaxConcurrentEvictions가 0이어도 공유 세마포어가 막히지 않아야 함
+	cfg := testEvictionConfig()
+	cfg.MaxConcurrentEvictions = 0
+	results, err := NodeDrain(context.Background(), clientSet, DrainDependencies{
+		AllocateRateProvider: fakeAllocateRateProvider{rates: map[string]int{"memory": 9, "cpu": 9}},
+		Notifier:             fakeNotifier{},
+	}, DrainConfig{
+		NodepoolName: nodepoolName,
+		Eviction:     cfg,
+	})
+	if err != nil {
+		t.Fatalf("NodeDrain 실패: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("drain 결과 개수 불일치: got=%d want=2", len(results))
+	}
+	for _, result := range results {
+		if !result.Success {
+			t.Fatalf("drain 실패: %+v", result)
+		}
+	}
+}
+
+func TestNodeDrainConcurrencySafetyRecheckGatesNewStarts(t *testing.T) {
+	setDefaultDrainEnv(t)
+	t.Setenv("DRAIN_NODE_CONCURRENCY", "2")
+	t.Setenv("DRAIN_PROGRESSIVE", "true")
+	t.Setenv("DRAIN_SAFETY_MAX_ALLOCATE_RATE", "90")
+
+	clientSet := fake.NewSimpleClientset()
+	nodepoolName := "test-nodepool"
+	for i := 1; i <= 5; i++ {
+		if _, err := clientSet.CoreV1().Nodes().Create(context.Background(), newNode(nodepoolName, i), metaV1.CreateOptions{}); err != nil {
+			t.Fatalf("노드 생성 실패: %v", err)
+		}
+	}
+
+	// 계획 1회 + 노드 시작 전 재평가(node-2, node-3, node-4)
+	provider := &sequenceAllocateRateProvider{
+		rates: map[string][]int{
+			"memory": {9, 9, 9, 95},
+			"cpu":    {9, 9, 9, 95},
+		},
+		calls: map[string]int{},
+	}
+
+	results, err := NodeDrain(context.Background(), clientSet, DrainDependencies{
+		AllocateRateProvider: provider,
+		Notifier:             fakeNotifier{},
+	}, DrainConfig{
+		NodepoolName: nodepoolName,
+		Eviction:     testEvictionConfig(),
+	})
+	if err != nil {
+		t.Fatalf("NodeDrain 실패: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("drain 결과 개수 불일치: got=%d want=3", len(results))
+	}
+	assertNodeUnschedulable(t, clientSet, "node-3", true)
+	assertNodeUnschedulable(t, clientSet, "node-4", false)
+}
+
+func TestNodeDrainRejectsWorkloadRecoveryWithNodeConcurrency(t *testing.T) {
+	setDefaultDrainEnv(t)
+	t.Setenv("DRAIN_NODE_CONCURRENCY", "2")
+	t.Setenv("DRAIN_PROGRESSIVE", "true")
+	t.Setenv("DRAIN_WAIT_WORKLOAD_RECOVERY", "true")
+
+	clientSet := fake.NewSimpleClientset(newNode("test-nodepool", 1), newNode("test-nodepool", 2), newNode("test-nodepool", 3))
+	results, err := NodeDrain(context.Background(), clientSet, DrainDependencies{
+		AllocateRateProvider: fakeAllocateRateProvider{rates: map[string]int{"memory": 9, "cpu": 9}},
+		Notifier:             fakeNotifier{},
+	}, DrainConfig{
+		NodepoolName: "test-nodepool",
+		Eviction:     testEvictionConfig(),
+	})
+	if err == nil {
+		t.Fatalf("복구 대기와 노드 동시성을 함께 쓰면 오류여야 합니다")
+	}
+	if len(results) != 0 {
+		t.Fatalf("drain 결과 개수 불일치: got=%d want=0", len(results))
+	}
+	assertNodeUnschedulable(t, clientSet, "node-1", false)
+
+	// 점진적 드레인이 아니면 복구 대기를 하지 않으므로 허용
+	if err := validateNodeConcurrency(2, false, WorkloadRecoveryOptions{Enabled: true}); err != nil {
+		t.Fatalf("점진적 드레인 비활성 시 오류가 없어야 합니다: %v", err)
+	}
+}
+
+func TestGetNodeConcurrencyFromEnv(t *testing.T) {
+	t.Setenv("DRAIN_NODE_CONCURRENCY", "")
+	if got := GetNodeConcurrencyFromEnv(); got != 1 {
+		t.Fatalf("기본 동시성 불일치: got=%d want=1", got)
+	}
+	t.Setenv("DRAIN_NODE_CONCURRENCY", "0")
+	if got := GetNodeConcurrencyFromEnv(); got != 1 {
+		t.Fatalf("0 이하 동시성 보정 불일치: got=%d want=1", got)
+	}
+	t.Setenv("DRAIN_NODE_CONCURRENCY", "4")
+	if got := GetNodeConcurrencyFromEnv(); got != 4 {
+		t.Fatalf("동시성 불일치: got=%d want=4", got)
+	}
+}
+
+func TestNodeDrainUsesInjectedPDBLister(t *testing.T) {
+	setDefaultDrainEnv(t)
+
+	nodepoolName := "test-nodepool"
+	clientSet := fake.NewSimpleClientset(
+		newNode(nodepoolName, 1),
+		newNode(nodepoolName, 2),
+		newNode(nodepoolName, 3),
+		newNode(nodepoolName, 4),
+		&coreV1.Pod{
+			ObjectMeta: metaV1.ObjectMeta{Name: "api-0", Namespace: "default", Labels: map[string]string{"app": "api"}},
+			Spec:       coreV1.PodSpec{NodeName: "node-1"},
+			Status:     coreV1.PodStatus{Phase: coreV1.PodRunning},
+		},
+	)
+
+	// clientset에는 없고 주입한 lister에만 있는 PDB가 eviction을 막아야 합니다.
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	if err := indexer.Add(&policyv1.PodDisruptionBudget{
+		ObjectMeta: metaV1.ObjectMeta{Name: "api-pdb", Namespace: "default"},
+		Spec:       policyv1.PodDisruptionBudgetSpec{Selector: &metaV1.LabelSelector{MatchLabels: map[string]string{"app": "api"}}},
+		Status:     policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: 0},
+	}); err != nil {
+		t.Fatalf("PDB 추가 실패: %v", err)
+	}
+
+	results, err := NodeDrain(context.Background(), clientSet, DrainDependencies{
+		AllocateRateProvider: fakeAllocateRateProvider{rates: map[string]int{"memory": 30, "cpu": 25}},
+		Notifier:             fakeNotifier{},
+		PDBLister:            policyv1listers.NewPodDisruptionBudgetLister(indexer),
+	}, DrainConfig{
+		NodepoolName: nodepoolName,
+		Eviction:     testEvictionConfig(),
+	})
+	if err == nil {
+		t.Fatalf("PDB 차단 시 오류가 반환되어야 합니다")
+	}
+	if len(results) != 1 || results[0].Success {
+		t.Fatalf("첫 노드 드레인이 실패해야 합니다: %+v", results)
+	}
+}
+
+func TestWaitForPodsToTerminateExtendsTimeoutByGracePeriod(t *testing.T) {
+	grace := int64(2)
+	terminating := &coreV1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{Name: "api-0", Namespace: "default"},
+		Spec:       coreV1.PodSpec{NodeName: "node-1", TerminationGracePeriodSeconds: &grace},
+		Status:     coreV1.PodStatus{Phase: coreV1.PodRunning},
+	}
+	clientSet := fake.NewSimpleClientset(terminating)
+
+	// 파드가 NodeTerminationTimeout보다 늦게, grace period 안에 종료됨
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		_ = clientSet.CoreV1().Pods("default").Delete(context.Background(), "api-0", metaV1.DeleteOptions{})
+	}()
+
+	cfg := testEvictionConfig()
+	cfg.NodeTerminationTimeout = 20 * time.Millisecond
+	if err := waitForPodsToTerminate(context.Background(), clientSet, "node-1", cfg); err != nil {
+		t.Fatalf("grace period 안의 종료는 타임아웃이 아니어야 합니다: %v", err)
+	}
+}
+
 func testEvictionConfig() *pod.EvictionConfig {
 	return &pod.EvictionConfig{
 		MaxConcurrentEvictions:   2,
@@ -246,3 +473,58 @@ func newNode(nodepool string, order int) *coreV1.Node {
 		},
 	}
 }
+
+func setDefaultDrainEnv(t *testing.T) {
+	t.Helper()
+
+	t.Setenv("DRAIN_POLICY", "formula")
+	t.Setenv("DRAIN_ROUNDING", "floor")
+	t.Setenv("DRAIN_MIN", "0")
+	t.Setenv("DRAIN_MAX_ABSOLUTE", "0")
+	t.Setenv("DRAIN_MAX_FRACTION", "0")
+	t.Setenv("DRAIN_STEP_RULES", "")
+	t.Setenv("DRAIN_SAFETY_MAX_ALLOCATE_RATE", "0")
+	t.Setenv("DRAIN_SAFETY_QUERIES", "")
+	t.Setenv("DRAIN_SAFETY_CHECKS", "")
+	t.Setenv("DRAIN_SAFETY_CHECKS_FILE", "")
+	t.Setenv("DRAIN_SAFETY_MAX_PENDING_PODS", "0")
+	t.Setenv("DRAIN_SAFETY_MAX_UNAVAILABLE_WORKLOADS", "0")
+	t.Setenv("DRAIN_SAFETY_MAX_NOT_READY_NODES", "0")
+	t.Setenv("DRAIN_SAFETY_MAX_FAILED_SCHEDULING_EVENTS", "0")
+	t.Setenv("DRAIN_WAIT_WORKLOAD_RECOVERY", "false")
+	t.Setenv("DRAIN_NODE_CONCURRENCY", "1")
+	t.Setenv("DRAIN_CANARY", "false")
+	t.Setenv("DRAIN_SURGE", "false")
+	t.Setenv("DRAIN_ADAPTIVE_PACING", "false")
+	t.Setenv("DRAIN_BREAKER_MAX_EVICTION_FAILURE_RATE", "0")
+	t.Setenv("DRAIN_BREAKER_MAX_FORCE_DELETES", "0")
+	t.Setenv("DRAIN_BREAKER_MAX_FAILED_NODES", "0")
+	t.Setenv("DRAIN_SAFETY_FAIL_CLOSED", "true")
+	t.Setenv("DRAIN_PROGRESSIVE", "false")
+	t.Setenv("DRAIN_UNHEALTHY", "false")
+	t.Setenv("DRAIN_MAX_PER_ZONE", "0")
+	t.Setenv("DRAIN_MAX_FRACTION_PER_ZONE", "0")
+	t.Setenv("DRAIN_ZONE_INTERLEAVE", "true")
+	t.Setenv("DRAIN_CAPACITY_TYPE_ORDER", "")
+	t.Setenv("DRAIN_MAX_SPOT", "0")
+	t.Setenv("DRAIN_MAX_ON_DEMAND", "0")
+	t.Setenv("DRAIN_PROTECT_ON_DEMAND_WHEN_SPOT_UNSTABLE", "false")
+	t.Setenv("DRAIN_NODE_SELECTOR", "")
+	t.Setenv("DRAIN_EXCLUDE_NODE_SELECTOR", "")
+	t.Setenv("DRAIN_INSTANCE_TYPES", "")
+	t.Setenv("DRAIN_EXCLUDE_NODES", "")
+	t.Setenv("DRAIN_NODES", "")
+	t.Setenv("DRAIN_SCORE_WEIGHTS", "age=1")
+	t.Setenv("DRAIN_SCORE_PRICES", "")
+	t.Setenv("DRAIN_TARGET_ALLOCATE_RATE", "80")
+	t.Setenv("DRAIN_FEASIBILITY_CHECK", "false")
+	t.Setenv("DRAIN_ALLOCATE_RESOURCES", "memory,cpu")
+	t.Setenv("DRAIN_BUDGET_MAX_NODES", "0")
+	t.Setenv("DRAIN_HONOR_KARPENTER_BUDGETS", "false")
+	t.Setenv("DRAIN_KARPENTER_BUDGET_REASONS", "")
+	t.Setenv("DRAIN_HYSTERESIS_THRESHOLD", "0")
+	t.Setenv("DRAIN_HYSTERESIS_WINDOW", "1h")
+	t.Setenv("DRAIN_FORECAST_HORIZON", "4h")
+	t.Setenv("DRAIN_FORECAST_PERIOD", "24h")
+	t.Setenv("DRAIN_FORECAST_LOOKBACK", "7")
+}
@@ -0,0 +1,409 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/client-go/kubernetes"
+)
+
+// 스케줄링 불가 사유입니다. kube-scheduler의 predicate를 단순화해 request, nodeSelector, node affinity,
+// taint/toleration, pod anti-affinity만 확인합니다.
+const (
+	unschedulableReasonInsufficient    = "insufficient"
+	unschedulableReasonNodeSelector    = "node-selector"
+	unschedulableReasonNodeAffinity    = "node-affinity"
+	unschedulableReasonTaint           = "taint"
+	unschedulableReasonPodAntiAffinity = "pod-anti-affinity"
+)
+
+// simNode는 시뮬레이션 중 파드를 받을 수 있는 노드와 현재 배치된 파드입니다.
+type simNode struct {
+	node coreV1.Node
+	pods []coreV1.Pod
+}
+
+// podsWith는 기존 파드와 아직 commit되지 않은 배치 파드를 합친 복사본을 반환합니다.
+func (n *simNode) podsWith(pending map[*simNode][]coreV1.Pod) []coreV1.Pod {
+	pods := make([]coreV1.Pod, 0, len(n.pods)+len(pending[n]))
+	pods = append(pods, n.pods...)
+	return append(pods, pending[n]...)
+}
+
+// schedulingSimulator는 드레인할 노드의 파드를 남은 노드에 first-fit으로 배치해 보는 시뮬레이터입니다.
+type schedulingSimulator struct {
+	nodes []*simNode
+}
+
+// checkSchedulingFeasibility는 선택된 노드의 파드가 남은 스케줄 가능 노드에 모두 배치되는지 확인합니다.
+// 배치할 수 없는 파드가 있는 노드는 계획에서 제외하고, 노드 이름별 배치 불가 파드 목록을 함께 반환합니다.
+func checkSchedulingFeasibility(ctx context.Context, clientSet kubernetes.Interface, selected []drainCandidate, disrupting map[string]string) ([]drainCandidate, map[string][]string, error) {
+	if len(selected) == 0 {
+		return selected, nil, nil
+	}
+
+	nodeList, err := clientSet.CoreV1().Nodes().List(ctx, metaV1.ListOptions{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("스케줄링 시뮬레이션용 노드 조회 실패: %w", err)
+	}
+	podsByNode, err := listPodsByNode(ctx, clientSet)
+	if err != nil {
+		return nil, nil, fmt.Errorf("스케줄링 시뮬레이션용 파드 조회 실패: %w", err)
+	}
+
+	selectedNames := map[string]bool{}
+	for _, c := range selected {
+		selectedNames[c.node.Name] = true
+	}
+
+	sim := &schedulingSimulator{}
+	nodesByName := map[string]coreV1.Node{}
+	for _, n := range nodeList.Items {
+		nodesByName[n.Name] = n
+		if selectedNames[n.Name] || !isSchedulableDestination(n, disrupting) {
+			continue
+		}
+		sim.nodes = append(sim.nodes, &simNode{node: n, pods: podsByNode[n.Name]})
+	}
+	sort.Slice(sim.nodes, func(i, j int) bool { return sim.nodes[i].node.Name < sim.nodes[j].node.Name })
+
+	feasible := make([]drainCandidate, 0, len(selected))
+	infeasible := map[string][]string{}
+	for _, c := range selected {
+		pods := evictablePods(podsByNode[c.node.Name])
+		placements, blocking := sim.place(pods)
+		if len(blocking) > 0 {
+			slog.Warn("남은 노드에 배치할 수 없는 파드가 있어 드레인 계획에서 제외", "nodeName", c.node.Name, "blockingPods", blocking)
+			infeasible[c.node.Name] = blocking
+			// 드레인하지 않는 노드는 이후 노드의 파드를 받을 수 있음
+			if n, ok := nodesByName[c.node.Name]; ok && isSchedulableDestination(n, disrupting) {
+				sim.nodes = append(sim.nodes, &simNode{node: n, pods: podsByNode[n.Name]})
+			}
+			continue
+		}
+		sim.commit(placements)
+		feasible = append(feasible, c)
+	}
+	return feasible, infeasible, nil
+}
+
+func isSchedulableDestination(n coreV1.Node, disrupting map[string]string) bool {
+	if n.Spec.Unschedulable || karpenterDisruptingReason(n) != "" {
+		return false
+	}
+	if _, ok := disrupting[n.Name]; ok {
+		return false
+	}
+	for _, cond := range n.Status.Conditions {
+		if cond.Type == coreV1.NodeReady && cond.Status != coreV1.ConditionTrue {
+			return false
+		}
+	}
+	return true
+}
+
+// evictablePods는 드레인 시 다른 노드로 옮겨져야 하는 파드(DaemonSet 제외)를 request가 큰 순으로 반환합니다.
+func evictablePods(pods []coreV1.Pod) []coreV1.Pod {
+	out := make([]coreV1.Pod, 0, len(pods))
+	for _, p := range pods {
+		if isDaemonSetPod(p) {
+			continue
+		}
+		out = append(out, p)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		ci, cj := podRequest(out[i], coreV1.ResourceCPU), podRequest(out[j], coreV1.ResourceCPU)
+		if c := ci.Cmp(cj); c != 0 {
+			return c > 0
+		}
+		mi, mj := podRequest(out[i], coreV1.ResourceMemory), podRequest(out[j], coreV1.ResourceMemory)
+		return mi.Cmp(mj) > 0
+	})
+	return out
+}
+
+func isDaemonSetPod(p coreV1.Pod) bool {
+	for _, ref := range p.OwnerReferences {
+		if ref.Kind == "DaemonSet" {
+			return true
+		}
+	}
+	return false
+}
+
+type podPlacement struct {
+	pod  coreV1.Pod
+	dest *simNode
+}
+
+// place는 파드를 순서대로 first-fit 배치해 봅니다. 하나라도 배치할 수 없으면 배치 불가 파드와 사유를 반환합니다.
+// 배치 결과는 commit 전까지 시뮬레이터에 반영되지 않습니다.
+func (s *schedulingSimulator) place(pods []coreV1.Pod) ([]podPlacement, []string) {
+	placements := make([]podPlacement, 0, len(pods))
+	pending := map[*simNode][]coreV1.Pod{}
+	var blocking []string
+
+	for _, p := range pods {
+		reasons := map[string]int{}
+		var dest *simNode
+		for _, n := range s.nodes {
+			reason := s.fitPod(p, n, pending)
+			if reason == "" {
+				dest = n
+				break
+			}
+			reasons[reason]++
+		}
+		if dest == nil {
+			blocking = append(blocking, fmt.Sprintf("%s/%s(%s)", p.Namespace, p.Name, formatUnschedulableReasons(len(s.nodes), reasons)))
+			continue
+		}
+		placed := *p.DeepCopy()
+		placed.Spec.NodeName = dest.node.Name
+		pending[dest] = append(pending[dest], placed)
+		placements = append(placements, podPlacement{pod: placed, dest: dest})
+	}
+	return placements, blocking
+}
+
+func (s *schedulingSimulator) commit(placements []podPlacement) {
+	for _, pl := range placements {
+		pl.dest.pods = append(pl.dest.pods, pl.pod)
+	}
+}
+
+func formatUnschedulableReasons(total int, reasons map[string]int) string {
+	keys := make([]string, 0, len(reasons))
+	for k := range reasons {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("%s:%d", k, reasons[k]))
+	}
+	return fmt.Sprintf("0/%d nodes %s", total, strings.Join(parts, ","))
+}
+
+// fitPod는 파드를 노드에 배치할 수 없는 첫 번째 사유를 반환합니다. 배치 가능하면 빈 문자열입니다.
+func (s *schedulingSimulator) fitPod(p coreV1.Pod, n *simNode, pending map[*simNode][]coreV1.Pod) string {
+	if !matchesNodeSelector(p, n.node) {
+		return unschedulableReasonNodeSelector
+	}
+	if !matchesRequiredNodeAffinity(p, n.node) {
+		return unschedulableReasonNodeAffinity
+	}
+	if !toleratesNodeTaints(p, n.node) {
+		return unschedulableReasonTaint
+	}
+	if reason := fitsResources(p, n.node, n.podsWith(pending)); reason != "" {
+		return reason
+	}
+	if s.violatesPodAntiAffinity(p, n, pending) {
+		return unschedulableReasonPodAntiAffinity
+	}
+	return ""
+}
+
+func matchesNodeSelector(p coreV1.Pod, n coreV1.Node) bool {
+	for k, v := range p.Spec.NodeSelector {
+		if n.Labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesRequiredNodeAffinity는 requiredDuringSchedulingIgnoredDuringExecution을 확인합니다. term끼리는 OR, term 안은 AND입니다.
+func matchesRequiredNodeAffinity(p coreV1.Pod, n coreV1.Node) bool {
+	if p.Spec.Affinity == nil || p.Spec.Affinity.NodeAffinity == nil || p.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return true
+	}
+	terms := p.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	for _, term := range terms {
+		if matchesNodeSelectorTerm(term, n) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesNodeSelectorTerm(term coreV1.NodeSelectorTerm, n coreV1.Node) bool {
+	if len(term.MatchExpressions) == 0 && len(term.MatchFields) == 0 {
+		return false
+	}
+	for _, expr := range term.MatchExpressions {
+		if !matchesNodeSelectorRequirement(expr, labels.Set(n.Labels)) {
+			return false
+		}
+	}
+	for _, expr := range term.MatchFields {
+		if expr.Key != "metadata.name" || !matchesNodeSelectorRequirement(expr, labels.Set{"metadata.name": n.Name}) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchesNodeSelectorRequirement(expr coreV1.NodeSelectorRequirement, set labels.Set) bool {
+	var op selection.Operator
+	switch expr.Operator {
+	case coreV1.NodeSelectorOpIn:
+		op = selection.In
+	case coreV1.NodeSelectorOpNotIn:
+		op = selection.NotIn
+	case coreV1.NodeSelectorOpExists:
+		op = selection.Exists
+	case coreV1.NodeSelectorOpDoesNotExist:
+		op = selection.DoesNotExist
+	case coreV1.NodeSelectorOpGt:
+		op = selection.GreaterThan
+	case coreV1.NodeSelectorOpLt:
+		op = selection.LessThan
+	default:
+		return false
+	}
+	req, err := labels.NewRequirement(expr.Key, op, expr.Values)
+	if err != nil {
+		return false
+	}
+	return req.Matches(set)
+}
+
+func toleratesNodeTaints(p coreV1.Pod, n coreV1.Node) bool {
+	for i := range n.Spec.Taints {
+		taint := &n.Spec.Taints[i]
+		if taint.Effect == coreV1.TaintEffectPreferNoSchedule {
+			continue
+		}
+		tolerated := false
+		for j := range p.Spec.Tolerations {
+			if p.Spec.Tolerations[j].ToleratesTaint(taint) {
+				tolerated = true
+				break
+			}
+		}
+		if !tolerated {
+			return false
+		}
+	}
+	return true
+}
+
+// fitsResources는 파드 request가 노드의 남은 allocatable에 들어가는지 확인합니다.
+func fitsResources(p coreV1.Pod, n coreV1.Node, existing []coreV1.Pod) string {
+	if allocatablePods, ok := n.Status.Allocatable[coreV1.ResourcePods]; ok && int64(len(existing)+1) > allocatablePods.Value() {
+		return unschedulableReasonInsufficient + " pods"
+	}
+
+	requested := map[coreV1.ResourceName]bool{}
+	for _, c := range append(append([]coreV1.Container(nil), p.Spec.Containers...), p.Spec.InitContainers...) {
+		for name := range c.Resources.Requests {
+			requested[name] = true
+		}
+	}
+	names := make([]string, 0, len(requested))
+	for name := range requested {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		resourceName := coreV1.ResourceName(name)
+		need := podRequest(p, resourceName)
+		if need.IsZero() {
+			continue
+		}
+		allocatable := n.Status.Allocatable[resourceName]
+		free := allocatable.DeepCopy()
+		used := sumPodRequests(existing, resourceName)
+		free.Sub(used)
+		if need.Cmp(free) > 0 {
+			return unschedulableReasonInsufficient + " " + name
+		}
+	}
+	return ""
+}
+
+// violatesPodAntiAffinity는 required pod anti-affinity를 양방향으로 확인합니다.
+// (배치할 파드의 anti-affinity와, 같은 topology에 있는 기존 파드의 anti-affinity)
+func (s *schedulingSimulator) violatesPodAntiAffinity(p coreV1.Pod, dest *simNode, pending map[*simNode][]coreV1.Pod) bool {
+	for _, term := range requiredAntiAffinityTerms(p) {
+		for _, other := range s.podsInTopology(dest, term.TopologyKey, pending) {
+			if antiAffinityTermMatches(term, p, other) {
+				return true
+			}
+		}
+	}
+	for _, n := range s.nodes {
+		for _, other := range n.podsWith(pending) {
+			for _, term := range requiredAntiAffinityTerms(other) {
+				if !sameTopology(n.node, dest.node, term.TopologyKey) {
+					continue
+				}
+				if antiAffinityTermMatches(term, other, p) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func requiredAntiAffinityTerms(p coreV1.Pod) []coreV1.PodAffinityTerm {
+	if p.Spec.Affinity == nil || p.Spec.Affinity.PodAntiAffinity == nil {
+		return nil
+	}
+	return p.Spec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+}
+
+func (s *schedulingSimulator) podsInTopology(dest *simNode, topologyKey string, pending map[*simNode][]coreV1.Pod) []coreV1.Pod {
+	var pods []coreV1.Pod
+	for _, n := range s.nodes {
+		if sameTopology(n.node, dest.node, topologyKey) {
+			pods = append(pods, n.podsWith(pending)...)
+		}
+	}
+	return pods
+}
+
+func sameTopology(a, b coreV1.Node, topologyKey string) bool {
+	av, aok := a.Labels[topologyKey]
+	bv, bok := b.Labels[topologyKey]
+	return aok && bok && av == bv
+}
+
+// antiAffinityTermMatches는 owner 파드의 term이 target 파드를 가리키는지 확인합니다.
+// namespaceSelector는 네임스페이스 라벨을 조회하지 않고 모든 네임스페이스로 간주합니다(보수적 판단).
+func antiAffinityTermMatches(term coreV1.PodAffinityTerm, owner coreV1.Pod, target coreV1.Pod) bool {
+	if term.NamespaceSelector == nil {
+		namespaces := term.Namespaces
+		if len(namespaces) == 0 {
+			namespaces = []string{owner.Namespace}
+		}
+		if !containsString(namespaces, target.Namespace) {
+			return false
+		}
+	}
+	selector, err := metaV1.LabelSelectorAsSelector(term.LabelSelector)
+	if err != nil {
+		return false
+	}
+	return selector.Matches(labels.Set(target.Labels))
+}
+
+func containsString(values []string, v string) bool {
+	for _, s := range values {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
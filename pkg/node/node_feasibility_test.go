@@ -0,0 +1,134 @@
+package node
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestSchedulingSimulatorFitPod(t *testing.T) {
+	dest := &simNode{node: *newSizedNode("np", 1, "2", "4Gi")}
+	dest.node.Labels["team"] = "a"
+	dest.node.Labels["topology.kubernetes.io/zone"] = "a"
+	dest.node.Spec.Taints = []coreV1.Taint{{Key: "dedicated", Value: "a", Effect: coreV1.TaintEffectNoSchedule}}
+	dest.pods = []coreV1.Pod{*newSimPod("existing", "1500m", map[string]string{"app": "db"})}
+	sim := &schedulingSimulator{nodes: []*simNode{dest}}
+
+	tolerated := func(p *coreV1.Pod) *coreV1.Pod {
+		p.Spec.Tolerations = []coreV1.Toleration{{Key: "dedicated", Operator: coreV1.TolerationOpEqual, Value: "a", Effect: coreV1.TaintEffectNoSchedule}}
+		return p
+	}
+
+	assert.Equal(t, unschedulableReasonTaint, sim.fitPod(*newSimPod("p", "100m", nil), dest, nil))
+	assert.Equal(t, "", sim.fitPod(*tolerated(newSimPod("p", "100m", nil)), dest, nil))
+	assert.Equal(t, "insufficient cpu", sim.fitPod(*tolerated(newSimPod("p", "1", nil)), dest, nil))
+
+	p := tolerated(newSimPod("p", "100m", nil))
+	p.Spec.NodeSelector = map[string]string{"team": "b"}
+	assert.Equal(t, unschedulableReasonNodeSelector, sim.fitPod(*p, dest, nil))
+
+	p = tolerated(newSimPod("p", "100m", nil))
+	p.Spec.Affinity = &coreV1.Affinity{NodeAffinity: &coreV1.NodeAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: &coreV1.NodeSelector{NodeSelectorTerms: []coreV1.NodeSelectorTerm{
+			{MatchExpressions: []coreV1.NodeSelectorRequirement{{Key: "team", Operator: coreV1.NodeSelectorOpNotIn, Values: []string{"a"}}}},
+		}},
+	}}
+	assert.Equal(t, unschedulableReasonNodeAffinity, sim.fitPod(*p, dest, nil))
+
+	p = tolerated(newSimPod("p", "100m", map[string]string{"app": "db"}))
+	p.Spec.Affinity = &coreV1.Affinity{PodAntiAffinity: &coreV1.PodAntiAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: []coreV1.PodAffinityTerm{{
+			LabelSelector: &metaV1.LabelSelector{MatchLabels: map[string]string{"app": "db"}},
+			TopologyKey:   "topology.kubernetes.io/zone",
+		}},
+	}}
+	assert.Equal(t, unschedulableReasonPodAntiAffinity, sim.fitPod(*p, dest, nil))
+}
+
+func TestCheckSchedulingFeasibilityDropsBlockedNodes(t *testing.T) {
+	ctx := context.Background()
+	clientSet := fake.NewSimpleClientset()
+
+	// node-1, node-2 드레인 예정 / node-3만 남음(2 vCPU)
+	for i := 1; i <= 3; i++ {
+		if _, err := clientSet.CoreV1().Nodes().Create(ctx, newSizedNode("np", i, "2", "8Gi"), metaV1.CreateOptions{}); err != nil {
+			t.Fatalf("노드 생성 실패: %v", err)
+		}
+	}
+	createSimPod(t, clientSet, "small", "node-1", "1500m")
+	createSimPod(t, clientSet, "large", "node-2", "1")
+
+	selected := []drainCandidate{{node: *newSizedNode("np", 1, "2", "8Gi")}, {node: *newSizedNode("np", 2, "2", "8Gi")}}
+	feasible, infeasible, err := checkSchedulingFeasibility(ctx, clientSet, selected, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"node-1"}, candidateNames(feasible))
+	assert.Equal(t, map[string][]string{"node-2": {"default/large(0/1 nodes insufficient cpu:1)"}}, infeasible)
+}
+
+func TestNodeDrainDropsInfeasibleNodes(t *testing.T) {
+	setDefaultDrainEnv(t)
+	t.Setenv("DRAIN_FEASIBILITY_CHECK", "true")
+
+	ctx := context.Background()
+	clientSet := fake.NewSimpleClientset()
+	nodepoolName := "test-nodepool"
+	for i := 1; i <= 3; i++ {
+		node := newSizedNode(nodepoolName, i, "2", "8Gi")
+		if i == 1 {
+			node.Labels["dedicated"] = "gpu"
+		}
+		if _, err := clientSet.CoreV1().Nodes().Create(ctx, node, metaV1.CreateOptions{}); err != nil {
+			t.Fatalf("노드 생성 실패: %v", err)
+		}
+	}
+	p := newSimPod("pinned", "100m", nil)
+	p.Spec.NodeName = "node-1"
+	p.Spec.NodeSelector = map[string]string{"dedicated": "gpu"}
+	if _, err := clientSet.CoreV1().Pods("default").Create(ctx, p, metaV1.CreateOptions{}); err != nil {
+		t.Fatalf("파드 생성 실패: %v", err)
+	}
+
+	// lenNodes=3, memory=40,cpu=20 => 1대(node-1)지만 node-1 파드는 다른 노드에 배치 불가
+	results, err := NodeDrain(ctx, clientSet, DrainDependencies{
+		AllocateRateProvider: fakeAllocateRateProvider{rates: map[string]int{"memory": 40, "cpu": 20}},
+		Notifier:             fakeNotifier{},
+	}, DrainConfig{
+		NodepoolName: nodepoolName,
+		Eviction:     testEvictionConfig(),
+	})
+	if err != nil {
+		t.Fatalf("NodeDrain 실패: %v", err)
+	}
+	assert.Len(t, results, 0)
+	assertNodeUnschedulable(t, clientSet, "node-1", false)
+}
+
+func newSimPod(name string, cpu string, podLabels map[string]string) *coreV1.Pod {
+	return &coreV1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: "default", Labels: podLabels},
+		Spec: coreV1.PodSpec{
+			Containers: []coreV1.Container{{
+				Name: "app",
+				Resources: coreV1.ResourceRequirements{
+					Requests: coreV1.ResourceList{coreV1.ResourceCPU: resource.MustParse(cpu)},
+				},
+			}},
+		},
+		Status: coreV1.PodStatus{Phase: coreV1.PodRunning},
+	}
+}
+
+func createSimPod(t *testing.T, clientSet *fake.Clientset, name string, nodeName string, cpu string) {
+	t.Helper()
+
+	p := newSimPod(name, cpu, nil)
+	p.Spec.NodeName = nodeName
+	if _, err := clientSet.CoreV1().Pods("default").Create(context.Background(), p, metaV1.CreateOptions{}); err != nil {
+		t.Fatalf("파드 생성 실패: %v", err)
+	}
+}
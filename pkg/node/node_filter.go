@@ -0,0 +1,182 @@
+package node
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	nodepoolLabel         = "karpenter.sh/nodepool"
+	instanceTypeLabel     = "node.kubernetes.io/instance-type"
+	betaInstanceTypeLabel = "beta.kubernetes.io/instance-type"
+)
+
+// NodeFilterOptions는 nodepool 라벨 외에 드레인 대상 노드를 좁히는 필터입니다. 빈 값은 비활성입니다.
+type NodeFilterOptions struct {
+	NodeSelector        string   // 라벨 셀렉터에 매칭되는 노드만 대상
+	ExcludeNodeSelector string   // 이 라벨 셀렉터에 매칭되는 노드는 제외
+	InstanceTypes       []string // 인스턴스 타입 allowlist
+	ExcludeNodes        []string // 노드 이름 denylist
+	Nodes               []string // 노드 이름 allowlist (긴급 드레인용)
+}
+
+// GetNodeFilterOptionsFromEnv는 노드 필터 관련 환경 변수를 파싱합니다.
+func GetNodeFilterOptionsFromEnv() NodeFilterOptions {
+	opts := NodeFilterOptions{
+		NodeSelector:        strings.TrimSpace(os.Getenv("DRAIN_NODE_SELECTOR")),
+		ExcludeNodeSelector: strings.TrimSpace(os.Getenv("DRAIN_EXCLUDE_NODE_SELECTOR")),
+	}
+	if v := strings.TrimSpace(os.Getenv("DRAIN_INSTANCE_TYPES")); v != "" {
+		opts.InstanceTypes = splitList(v)
+	}
+	if v := strings.TrimSpace(os.Getenv("DRAIN_EXCLUDE_NODES")); v != "" {
+		opts.ExcludeNodes = splitList(v)
+	}
+	if v := strings.TrimSpace(os.Getenv("DRAIN_NODES")); v != "" {
+		opts.Nodes = splitList(v)
+	}
+	return opts
+}
+
+// IsEmpty는 설정된 필터가 없는지 반환합니다.
+func (o NodeFilterOptions) IsEmpty() bool {
+	return o.NodeSelector == "" && o.ExcludeNodeSelector == "" &&
+		len(o.InstanceTypes) == 0 && len(o.ExcludeNodes) == 0 && len(o.Nodes) == 0
+}
+
+// String은 계획 로그에 출력할 필터 요약을 반환합니다.
+func (o NodeFilterOptions) String() string {
+	if o.IsEmpty() {
+		return "none"
+	}
+	var parts []string
+	if o.NodeSelector != "" {
+		parts = append(parts, fmt.Sprintf("selector=%q", o.NodeSelector))
+	}
+	if o.ExcludeNodeSelector != "" {
+		parts = append(parts, fmt.Sprintf("excludeSelector=%q", o.ExcludeNodeSelector))
+	}
+	if len(o.InstanceTypes) > 0 {
+		parts = append(parts, fmt.Sprintf("instanceTypes=%s", strings.Join(o.InstanceTypes, ",")))
+	}
+	if len(o.ExcludeNodes) > 0 {
+		parts = append(parts, fmt.Sprintf("excludeNodes=%s", strings.Join(o.ExcludeNodes, ",")))
+	}
+	if len(o.Nodes) > 0 {
+		parts = append(parts, fmt.Sprintf("nodes=%s", strings.Join(o.Nodes, ",")))
+	}
+	return strings.Join(parts, " ")
+}
+
+// filterDrainCandidates는 노드 필터를 드레인 후보에만 적용합니다.
+// 드레인 수와 존 상한은 필터와 관계없이 nodepool 전체 기준으로 계산하므로, 필터는 어떤 노드를 고를지만 좁힙니다.
+func filterDrainCandidates(candidates []drainCandidate, opts NodeFilterOptions) ([]drainCandidate, error) {
+	if opts.IsEmpty() {
+		return candidates, nil
+	}
+	nodes := make([]coreV1.Node, 0, len(candidates))
+	for _, c := range candidates {
+		nodes = append(nodes, c.node)
+	}
+	matched, err := filterNodes(nodes, opts)
+	if err != nil {
+		return nil, err
+	}
+	matchedNames := make(map[string]bool, len(matched))
+	for _, n := range matched {
+		matchedNames[n.Name] = true
+	}
+
+	filtered := make([]drainCandidate, 0, len(matched))
+	for _, c := range candidates {
+		if matchedNames[c.node.Name] {
+			filtered = append(filtered, c)
+		}
+	}
+	return filtered, nil
+}
+
+// filterNodes는 노드 필터(셀렉터, 제외 셀렉터, 인스턴스 타입, 노드 이름)를 적용합니다.
+func filterNodes(nodes []coreV1.Node, opts NodeFilterOptions) ([]coreV1.Node, error) {
+	if opts.IsEmpty() {
+		return nodes, nil
+	}
+
+	var include labels.Selector
+	if opts.NodeSelector != "" {
+		parsed, err := labels.Parse(opts.NodeSelector)
+		if err != nil {
+			return nil, fmt.Errorf("잘못된 node selector %q: %w", opts.NodeSelector, err)
+		}
+		include = parsed
+	}
+	var exclude labels.Selector
+	if opts.ExcludeNodeSelector != "" {
+		parsed, err := labels.Parse(opts.ExcludeNodeSelector)
+		if err != nil {
+			return nil, fmt.Errorf("잘못된 exclude node selector %q: %w", opts.ExcludeNodeSelector, err)
+		}
+		exclude = parsed
+	}
+	instanceTypes := toSet(opts.InstanceTypes)
+	excludeNodes := toSet(opts.ExcludeNodes)
+	allowNodes := toSet(opts.Nodes)
+
+	filtered := make([]coreV1.Node, 0, len(nodes))
+	for _, n := range nodes {
+		reason := ""
+		switch {
+		case include != nil && !include.Matches(labels.Set(n.Labels)):
+			reason = "node-selector"
+		case exclude != nil && exclude.Matches(labels.Set(n.Labels)):
+			reason = "exclude-node-selector"
+		case len(instanceTypes) > 0 && !instanceTypes[nodeInstanceType(n)]:
+			reason = "instance-types"
+		case excludeNodes[n.Name]:
+			reason = "exclude-nodes"
+		case len(allowNodes) > 0 && !allowNodes[n.Name]:
+			reason = "nodes"
+		}
+		if reason != "" {
+			slog.Debug("노드 필터로 제외", "nodeName", n.Name, "filter", reason)
+			continue
+		}
+		filtered = append(filtered, n)
+	}
+
+	for name := range allowNodes {
+		if !containsNode(filtered, name) {
+			slog.Warn("--nodes 에 지정한 노드가 대상에 없습니다.", "nodeName", name)
+		}
+	}
+	return filtered, nil
+}
+
+func nodeInstanceType(n coreV1.Node) string {
+	if v := n.Labels[instanceTypeLabel]; v != "" {
+		return v
+	}
+	return n.Labels[betaInstanceTypeLabel]
+}
+
+func containsNode(nodes []coreV1.Node, name string) bool {
+	for _, n := range nodes {
+		if n.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
@@ -0,0 +1,121 @@
+package node
+
+import (
+	"app/types"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestFilterNodes(t *testing.T) {
+	nodes := []coreV1.Node{
+		*newNode("np", 1),
+		*newNode("np", 2),
+		*newNode("np", 3),
+		*newNode("np", 4),
+	}
+	nodes[1].Labels[instanceTypeLabel] = "m5.large"
+	nodes[2].Labels["drain.example.com/skip"] = "true"
+
+	got, err := filterNodes(nodes, NodeFilterOptions{ExcludeNodeSelector: "drain.example.com/skip=true"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"node-1", "node-2", "node-4"}, nodeNames(got))
+
+	// GA 라벨이 beta 라벨보다 우선
+	got, err = filterNodes(nodes, NodeFilterOptions{InstanceTypes: []string{"t3.large"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"node-1", "node-3", "node-4"}, nodeNames(got))
+
+	got, err = filterNodes(nodes, NodeFilterOptions{ExcludeNodes: []string{"node-1"}, Nodes: []string{"node-1", "node-4", "node-9"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"node-4"}, nodeNames(got))
+
+	got, err = filterNodes(nodes, NodeFilterOptions{NodeSelector: "drain.example.com/skip=true"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"node-3"}, nodeNames(got))
+
+	_, err = filterNodes(nodes, NodeFilterOptions{ExcludeNodeSelector: "a in ("})
+	assert.Error(t, err)
+	_, err = filterNodes(nodes, NodeFilterOptions{NodeSelector: "team in ("})
+	assert.Error(t, err)
+}
+
+func TestNodeDrainAppliesNodeFilters(t *testing.T) {
+	setDefaultDrainEnv(t)
+	t.Setenv("DRAIN_INSTANCE_TYPES", "t3.large")
+	t.Setenv("DRAIN_EXCLUDE_NODES", "node-2")
+
+	clientSet := fake.NewSimpleClientset()
+	nodepoolName := "test-nodepool"
+	for i := 1; i <= 5; i++ {
+		node := newNode(nodepoolName, i)
+		if i == 1 {
+			node.Labels[instanceTypeLabel] = "m5.large"
+		}
+		if _, err := clientSet.CoreV1().Nodes().Create(context.Background(), node, metaV1.CreateOptions{}); err != nil {
+			t.Fatalf("노드 생성 실패: %v", err)
+		}
+	}
+
+	// 드레인 수는 nodepool 전체(lenNodes=5) 기준으로 계산하고, 필터는 후보(node-3,4,5)만 좁힘
+	results, err := NodeDrain(context.Background(), clientSet, DrainDependencies{
+		AllocateRateProvider: fakeAllocateRateProvider{rates: map[string]int{"memory": 20, "cpu": 20}},
+		Notifier:             fakeNotifier{},
+	}, DrainConfig{
+		NodepoolName: nodepoolName,
+		Eviction:     testEvictionConfig(),
+	})
+	if err != nil {
+		t.Fatalf("NodeDrain 실패: %v", err)
+	}
+	assert.Equal(t, []string{"node-3", "node-4", "node-5"}, resultNodeNames(results))
+	assertNodeUnschedulable(t, clientSet, "node-1", false)
+	assertNodeUnschedulable(t, clientSet, "node-2", false)
+}
+
+func TestNodeDrainNodesFilterDoesNotShrinkDrainCount(t *testing.T) {
+	setDefaultDrainEnv(t)
+	t.Setenv("DRAIN_NODES", "node-4")
+	t.Setenv("DRAIN_MAX_FRACTION", "0.5")
+
+	clientSet := fake.NewSimpleClientset()
+	nodepoolName := "test-nodepool"
+	for i := 1; i <= 4; i++ {
+		if _, err := clientSet.CoreV1().Nodes().Create(context.Background(), newNode(nodepoolName, i), metaV1.CreateOptions{}); err != nil {
+			t.Fatalf("노드 생성 실패: %v", err)
+		}
+	}
+
+	// 후보가 1대뿐이어도 드레인 수(lenNodes=4, maxFraction=0.5 => 2)는 nodepool 전체 기준이므로 0이 되지 않음
+	results, err := NodeDrain(context.Background(), clientSet, DrainDependencies{
+		AllocateRateProvider: fakeAllocateRateProvider{rates: map[string]int{"memory": 50, "cpu": 50}},
+		Notifier:             fakeNotifier{},
+	}, DrainConfig{
+		NodepoolName: nodepoolName,
+		Eviction:     testEvictionConfig(),
+	})
+	if err != nil {
+		t.Fatalf("NodeDrain 실패: %v", err)
+	}
+	assert.Equal(t, []string{"node-4"}, resultNodeNames(results))
+}
+
+func resultNodeNames(results []types.NodeDrainResult) []string {
+	names := make([]string, 0, len(results))
+	for _, r := range results {
+		names = append(names, r.NodeName)
+	}
+	return names
+}
+
+func nodeNames(nodes []coreV1.Node) []string {
+	names := make([]string, 0, len(nodes))
+	for _, n := range nodes {
+		names = append(names, n.Name)
+	}
+	return names
+}
@@ -0,0 +1,243 @@
+package node
+
+import (
+	"app/pkg/pod"
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// nodeConditionNotReady는 Ready condition이 True가 아닌 상태를 의미하는 가상의 condition 이름입니다.
+	nodeConditionNotReady = "NotReady"
+	// nodeConditionReadyFlapping은 window 내 Ready 상태가 반복적으로 바뀐 노드를 의미합니다.
+	nodeConditionReadyFlapping = "ReadyFlapping"
+	// nodeNotReadyEventReason은 node lifecycle controller가 노드를 NotReady로 표시할 때 남기는 이벤트 reason입니다.
+	nodeNotReadyEventReason = "NodeNotReady"
+)
+
+var defaultUnhealthyConditions = []string{
+	nodeConditionNotReady,
+	string(coreV1.NodeMemoryPressure),
+	string(coreV1.NodeDiskPressure),
+	string(coreV1.NodePIDPressure),
+}
+
+// UnhealthyOptions는 비정상 노드 우선 드레인 모드 설정입니다.
+type UnhealthyOptions struct {
+	Enabled       bool
+	Conditions    []string      // "NotReady" 또는 status=True일 때 비정상으로 보는 condition type (예: KernelDeadlock)
+	FlapWindow    time.Duration // 0 이면 flapping 감지 비활성
+	FlapThreshold int           // window 내 NodeNotReady 이벤트가 이 값 이상이면 flapping으로 판단
+
+	// 비정상 노드 전용 eviction 설정 (0/빈 값이면 기본 eviction 설정 사용)
+	EvictionMode          pod.EvictionMode
+	Force                 bool
+	MaxRetries            int
+	PodDeletionTimeout    time.Duration
+	SkipPostEvictionDelay bool
+}
+
+// GetUnhealthyOptionsFromEnv는 비정상 노드 우선 드레인 관련 환경 변수를 파싱합니다.
+// 기본값은 비활성입니다.
+func GetUnhealthyOptionsFromEnv() UnhealthyOptions {
+	opts := UnhealthyOptions{
+		Enabled:       false,
+		Conditions:    append([]string(nil), defaultUnhealthyConditions...),
+		FlapWindow:    0,
+		FlapThreshold: 3,
+	}
+
+	opts.Enabled = parseEnvBool("DRAIN_UNHEALTHY", opts.Enabled)
+	opts.FlapWindow = parseEnvDuration("DRAIN_UNHEALTHY_FLAP_WINDOW", opts.FlapWindow)
+	opts.FlapThreshold = parseEnvInt("DRAIN_UNHEALTHY_FLAP_THRESHOLD", opts.FlapThreshold)
+	if opts.FlapThreshold <= 0 {
+		opts.FlapThreshold = 1
+	}
+
+	if v := strings.TrimSpace(os.Getenv("DRAIN_UNHEALTHY_CONDITIONS")); v != "" {
+		opts.Conditions = splitList(v)
+	}
+
+	if v := strings.TrimSpace(os.Getenv("DRAIN_UNHEALTHY_POD_EVICTION_MODE")); v != "" {
+		switch pod.EvictionMode(strings.ToLower(v)) {
+		case pod.EvictionModeEvict, pod.EvictionModeDelete:
+			opts.EvictionMode = pod.EvictionMode(strings.ToLower(v))
+		}
+	}
+	opts.Force = parseEnvBool("DRAIN_UNHEALTHY_FORCE", opts.Force)
+	opts.MaxRetries = parseEnvInt("DRAIN_UNHEALTHY_POD_MAX_RETRIES", opts.MaxRetries)
+	opts.PodDeletionTimeout = parseEnvDuration("DRAIN_UNHEALTHY_POD_DELETION_TIMEOUT", opts.PodDeletionTimeout)
+	opts.SkipPostEvictionDelay = parseEnvBool("DRAIN_UNHEALTHY_SKIP_POST_EVICTION_DELAY", opts.SkipPostEvictionDelay)
+
+	return opts
+}
+
+// unhealthyNodeCondition은 노드가 비정상으로 판단된 condition 이름을 반환합니다. 정상이면 빈 문자열입니다.
+func unhealthyNodeCondition(node coreV1.Node, conditions []string) string {
+	for _, c := range conditions {
+		if c == nodeConditionNotReady {
+			for _, cond := range node.Status.Conditions {
+				if cond.Type == coreV1.NodeReady && cond.Status != coreV1.ConditionTrue {
+					return nodeConditionNotReady
+				}
+			}
+			continue
+		}
+		for _, cond := range node.Status.Conditions {
+			if string(cond.Type) == c && cond.Status == coreV1.ConditionTrue {
+				return c
+			}
+		}
+	}
+	return ""
+}
+
+// countRecentEvents는 window 내 reason 이벤트 발생 횟수를 involvedObject 이름별로 집계합니다.
+// 집계된 이벤트는 window에 걸친 비율만큼 셉니다. (eventOccurrencesSince 참고)
+func countRecentEvents(ctx context.Context, clientSet kubernetes.Interface, kind string, reason string, window time.Duration) (map[string]int, error) {
+	events, err := clientSet.CoreV1().Events("").List(ctx, metaV1.ListOptions{
+		FieldSelector: fmt.Sprintf("involvedObject.kind=%s,reason=%s", kind, reason),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s 이벤트 조회 실패: %w", reason, err)
+	}
+
+	since := time.Now().Add(-window)
+	counts := map[string]int{}
+	for _, ev := range events.Items {
+		if ev.InvolvedObject.Kind != kind || ev.Reason != reason {
+			continue
+		}
+		if n := eventOccurrencesSince(ev, since); n > 0 {
+			counts[ev.InvolvedObject.Name] += n
+		}
+	}
+	return counts, nil
+}
+
+// eventOccurrencesSince는 이벤트가 since 이후에 발생한 횟수를 반환합니다.
+// window 포함 여부는 마지막 발생 시각으로 판단합니다. 집계된 이벤트(Count, series)는 처음과 마지막 발생 시각만 알 수 있으므로,
+// 첫 발생이 since 이전이면 발생이 고르게 분포했다고 보고 window에 걸친 비율만큼 셉니다. (최소 1회)
+func eventOccurrencesSince(ev coreV1.Event, since time.Time) int {
+	lastSeen := eventLastSeen(ev)
+	if lastSeen.Before(since) {
+		return 0
+	}
+	count := int(ev.Count)
+	if ev.Series != nil {
+		count = int(ev.Series.Count)
+	}
+	if count < 1 {
+		count = 1
+	}
+	firstSeen := eventFirstSeen(ev)
+	if !firstSeen.Before(since) {
+		return count
+	}
+	span := lastSeen.Sub(firstSeen)
+	inWindow := int(math.Ceil(float64(count) * float64(lastSeen.Sub(since)) / float64(span)))
+	if inWindow < 1 {
+		inWindow = 1
+	}
+	return inWindow
+}
+
+func eventLastSeen(ev coreV1.Event) time.Time {
+	if ev.Series != nil && !ev.Series.LastObservedTime.IsZero() {
+		return ev.Series.LastObservedTime.Time
+	}
+	if !ev.LastTimestamp.IsZero() {
+		return ev.LastTimestamp.Time
+	}
+	if !ev.EventTime.IsZero() {
+		return ev.EventTime.Time
+	}
+	return ev.FirstTimestamp.Time
+}
+
+func eventFirstSeen(ev coreV1.Event) time.Time {
+	if !ev.FirstTimestamp.IsZero() {
+		return ev.FirstTimestamp.Time
+	}
+	if !ev.EventTime.IsZero() {
+		return ev.EventTime.Time
+	}
+	return eventLastSeen(ev)
+}
+
+// classifyUnhealthyNodes는 비정상 노드를 앞으로 보내고(기존 순서 유지), 선택 사유를 기록한 후보 목록을 반환합니다.
+func classifyUnhealthyNodes(ctx context.Context, clientSet kubernetes.Interface, nodes []coreV1.Node, opts UnhealthyOptions) []drainCandidate {
+	candidates := make([]drainCandidate, 0, len(nodes))
+	for _, n := range nodes {
+		candidates = append(candidates, drainCandidate{node: n})
+	}
+	if !opts.Enabled {
+		return candidates
+	}
+
+	var flapCounts map[string]int
+	if opts.FlapWindow > 0 {
+		counts, err := countRecentEvents(ctx, clientSet, "Node", nodeNotReadyEventReason, opts.FlapWindow)
+		if err != nil {
+			slog.Warn("노드 flapping 감지 실패(무시하고 진행)", "error", err)
+		}
+		flapCounts = counts
+	}
+
+	unhealthy := make([]drainCandidate, 0)
+	healthy := make([]drainCandidate, 0, len(candidates))
+	for _, c := range candidates {
+		c.unhealthyCondition = unhealthyNodeCondition(c.node, opts.Conditions)
+		if c.unhealthyCondition == "" && flapCounts[c.node.Name] >= opts.FlapThreshold {
+			c.unhealthyCondition = nodeConditionReadyFlapping
+		}
+		if c.unhealthyCondition == "" {
+			healthy = append(healthy, c)
+			continue
+		}
+		slog.Info("비정상 노드 감지", "nodeName", c.node.Name, "condition", c.unhealthyCondition)
+		unhealthy = append(unhealthy, c)
+	}
+
+	return append(unhealthy, healthy...)
+}
+
+func countUnhealthyCandidates(candidates []drainCandidate) int {
+	count := 0
+	for _, c := range candidates {
+		if c.unhealthyCondition != "" {
+			count++
+		}
+	}
+	return count
+}
+
+// unhealthyEvictionConfig는 기본 eviction 설정에 비정상 노드 전용 설정을 덮어쓴 복사본을 반환합니다.
+func unhealthyEvictionConfig(base *pod.EvictionConfig, opts UnhealthyOptions) *pod.EvictionConfig {
+	cfg := *normalizeDrainEvictionConfig(base)
+	if opts.EvictionMode != "" {
+		cfg.EvictionMode = opts.EvictionMode
+	}
+	if opts.Force {
+		cfg.Force = true
+	}
+	if opts.MaxRetries > 0 {
+		cfg.MaxRetries = opts.MaxRetries
+	}
+	if opts.PodDeletionTimeout > 0 {
+		cfg.PodDeletionTimeout = opts.PodDeletionTimeout
+	}
+	if opts.SkipPostEvictionDelay {
+		cfg.PostEvictionNodeDelay = 0
+	}
+	return &cfg
+}
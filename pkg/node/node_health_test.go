@@ -0,0 +1,258 @@
+package node
+
+import (
+	"app/pkg/pod"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestUnhealthyNodeCondition(t *testing.T) {
+	tests := []struct {
+		name       string
+		conditions []coreV1.NodeCondition
+		want       string
+	}{
+		{
+			name:       "condition 없음",
+			conditions: nil,
+			want:       "",
+		},
+		{
+			name: "Ready=Unknown",
+			conditions: []coreV1.NodeCondition{
+				{Type: coreV1.NodeReady, Status: coreV1.ConditionUnknown},
+			},
+			want: "NotReady",
+		},
+		{
+			name: "MemoryPressure=True",
+			conditions: []coreV1.NodeCondition{
+				{Type: coreV1.NodeReady, Status: coreV1.ConditionTrue},
+				{Type: coreV1.NodeMemoryPressure, Status: coreV1.ConditionTrue},
+			},
+			want: "MemoryPressure",
+		},
+		{
+			name: "설정되지 않은 custom condition은 무시",
+			conditions: []coreV1.NodeCondition{
+				{Type: coreV1.NodeReady, Status: coreV1.ConditionTrue},
+				{Type: "KernelDeadlock", Status: coreV1.ConditionTrue},
+			},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := coreV1.Node{Status: coreV1.NodeStatus{Conditions: tt.conditions}}
+			assert.Equal(t, tt.want, unhealthyNodeCondition(n, defaultUnhealthyConditions))
+		})
+	}
+
+	custom := coreV1.Node{Status: coreV1.NodeStatus{Conditions: []coreV1.NodeCondition{
+		{Type: "KernelDeadlock", Status: coreV1.ConditionTrue},
+	}}}
+	assert.Equal(t, "KernelDeadlock", unhealthyNodeCondition(custom, []string{"NotReady", "KernelDeadlock"}))
+}
+
+func TestClassifyUnhealthyNodesDetectsFlapping(t *testing.T) {
+	now := time.Now()
+	clientSet := fake.NewSimpleClientset(
+		&coreV1.Event{
+			ObjectMeta:     metaV1.ObjectMeta{Name: "node-2.flap", Namespace: "default"},
+			InvolvedObject: coreV1.ObjectReference{Kind: "Node", Name: "node-2"},
+			Reason:         "NodeNotReady",
+			Count:          3,
+			LastTimestamp:  metaV1.NewTime(now.Add(-10 * time.Minute)),
+		},
+		&coreV1.Event{
+			ObjectMeta:     metaV1.ObjectMeta{Name: "node-3.old", Namespace: "default"},
+			InvolvedObject: coreV1.ObjectReference{Kind: "Node", Name: "node-3"},
+			Reason:         "NodeNotReady",
+			Count:          5,
+			LastTimestamp:  metaV1.NewTime(now.Add(-3 * time.Hour)),
+		},
+	)
+
+	nodes := []coreV1.Node{*newNode("np", 1), *newNode("np", 2), *newNode("np", 3)}
+	candidates := classifyUnhealthyNodes(context.Background(), clientSet, nodes, UnhealthyOptions{
+		Enabled:       true,
+		Conditions:    defaultUnhealthyConditions,
+		FlapWindow:    time.Hour,
+		FlapThreshold: 3,
+	})
+
+	assert.Len(t, candidates, 3)
+	assert.Equal(t, "node-2", candidates[0].node.Name)
+	assert.Equal(t, "ReadyFlapping", candidates[0].unhealthyCondition)
+	assert.Equal(t, "node-1", candidates[1].node.Name)
+	assert.Equal(t, "node-3", candidates[2].node.Name)
+	assert.Equal(t, 1, countUnhealthyCandidates(candidates))
+}
+
+func TestUnhealthyEvictionConfigOverridesBase(t *testing.T) {
+	base := testEvictionConfig()
+	base.PostEvictionNodeDelay = time.Minute
+
+	cfg := unhealthyEvictionConfig(base, UnhealthyOptions{
+		EvictionMode:          pod.EvictionModeDelete,
+		Force:                 true,
+		MaxRetries:            5,
+		SkipPostEvictionDelay: true,
+	})
+
+	assert.Equal(t, pod.EvictionModeDelete, cfg.EvictionMode)
+	assert.True(t, cfg.Force)
+	assert.Equal(t, 5, cfg.MaxRetries)
+	assert.Equal(t, base.PodDeletionTimeout, cfg.PodDeletionTimeout)
+	assert.Equal(t, time.Duration(0), cfg.PostEvictionNodeDelay)
+	assert.Equal(t, time.Minute, base.PostEvictionNodeDelay)
+}
+
+func TestNodeDrainPrioritizesUnhealthyNodes(t *testing.T) {
+	setDefaultDrainEnv(t)
+	t.Setenv("DRAIN_UNHEALTHY", "true")
+
+	clientSet := fake.NewSimpleClientset()
+	nodepoolName := "test-nodepool"
+	for i := 1; i <= 3; i++ {
+		node := newNode(nodepoolName, i)
+		if i == 3 {
+			node.Status.Conditions = []coreV1.NodeCondition{
+				{Type: coreV1.NodeReady, Status: coreV1.ConditionFalse},
+			}
+		}
+		if _, err := clientSet.CoreV1().Nodes().Create(context.Background(), node, metaV1.CreateOptions{}); err != nil {
+			t.Fatalf("노드 생성 실패: %v", err)
+		}
+	}
+
+	// max=99 이면 정책상 0대지만 비정상 노드는 드레인 대상이 되어야 함
+	results, err := NodeDrain(context.Background(), clientSet, DrainDependencies{
+		AllocateRateProvider: fakeAllocateRateProvider{rates: map[string]int{"memory": 99, "cpu": 99}},
+		Notifier:             fakeNotifier{},
+	}, DrainConfig{
+		NodepoolName: nodepoolName,
+		Eviction:     testEvictionConfig(),
+	})
+	if err != nil {
+		t.Fatalf("NodeDrain 실패: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("drain 결과 개수 불일치: got=%d want=1", len(results))
+	}
+	assert.Equal(t, "node-3", results[0].NodeName)
+	assert.Equal(t, "NotReady", results[0].UnhealthyCondition)
+}
+
+func TestEventOccurrencesSinceCountsOnlyInsideWindow(t *testing.T) {
+	now := time.Now()
+	since := now.Add(-time.Hour)
+
+	tests := []struct {
+		name  string
+		event coreV1.Event
+		want  int
+	}{
+		{
+			name: "모든 발생이 window 안",
+			event: coreV1.Event{
+				Count:          4,
+				FirstTimestamp: metaV1.NewTime(now.Add(-50 * time.Minute)),
+				LastTimestamp:  metaV1.NewTime(now.Add(-5 * time.Minute)),
+			},
+			want: 4,
+		},
+		{
+			name: "오래 집계된 이벤트는 window 비율만큼, 최소 1회",
+			event: coreV1.Event{
+				Count:          20,
+				FirstTimestamp: metaV1.NewTime(now.Add(-7 * 24 * time.Hour)),
+				LastTimestamp:  metaV1.NewTime(now.Add(-5 * time.Minute)),
+			},
+			want: 1,
+		},
+		{
+			name: "오래 flapping 중인 노드는 window 비율만큼",
+			event: coreV1.Event{
+				Count:          30,
+				FirstTimestamp: metaV1.NewTime(now.Add(-3 * time.Hour)),
+				LastTimestamp:  metaV1.NewTime(now.Add(-time.Minute)),
+			},
+			want: 10,
+		},
+		{
+			name: "마지막 발생이 window 이전",
+			event: coreV1.Event{
+				Count:          20,
+				FirstTimestamp: metaV1.NewTime(now.Add(-7 * 24 * time.Hour)),
+				LastTimestamp:  metaV1.NewTime(since.Add(-time.Second)),
+			},
+			want: 0,
+		},
+		{
+			name: "첫 발생이 window 경계",
+			event: coreV1.Event{
+				Count:          3,
+				FirstTimestamp: metaV1.NewTime(since),
+				LastTimestamp:  metaV1.NewTime(now),
+			},
+			want: 3,
+		},
+		{
+			name: "series 집계",
+			event: coreV1.Event{
+				EventTime: metaV1.NewMicroTime(now.Add(-30 * time.Minute)),
+				Series: &coreV1.EventSeries{
+					Count:            5,
+					LastObservedTime: metaV1.NewMicroTime(now.Add(-time.Minute)),
+				},
+			},
+			want: 5,
+		},
+		{
+			name: "series 첫 발생이 window 이전",
+			event: coreV1.Event{
+				EventTime: metaV1.NewMicroTime(now.Add(-3 * time.Hour)),
+				Series: &coreV1.EventSeries{
+					Count:            5,
+					LastObservedTime: metaV1.NewMicroTime(now.Add(-time.Minute)),
+				},
+			},
+			want: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, eventOccurrencesSince(tt.event, since))
+		})
+	}
+}
+
+func TestClassifyUnhealthyNodesIgnoresOccurrencesBeforeWindow(t *testing.T) {
+	now := time.Now()
+	clientSet := fake.NewSimpleClientset(&coreV1.Event{
+		ObjectMeta:     metaV1.ObjectMeta{Name: "node-1.flap", Namespace: "default"},
+		InvolvedObject: coreV1.ObjectReference{Kind: "Node", Name: "node-1"},
+		Reason:         "NodeNotReady",
+		Count:          10,
+		FirstTimestamp: metaV1.NewTime(now.Add(-7 * 24 * time.Hour)),
+		LastTimestamp:  metaV1.NewTime(now.Add(-10 * time.Minute)),
+	})
+
+	candidates := classifyUnhealthyNodes(context.Background(), clientSet, []coreV1.Node{*newNode("np", 1)}, UnhealthyOptions{
+		Enabled:       true,
+		Conditions:    defaultUnhealthyConditions,
+		FlapWindow:    time.Hour,
+		FlapThreshold: 3,
+	})
+
+	assert.Equal(t, 0, countUnhealthyCandidates(candidates))
+}
@@ -0,0 +1,176 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+var nodePoolGVR = schema.GroupVersionResource{Group: "karpenter.sh", Version: "v1", Resource: "nodepools"}
+
+// karpenterDefaultBudgetNodes는 NodePool에 budgets가 없을 때 Karpenter가 적용하는 기본 budget입니다.
+const karpenterDefaultBudgetNodes = "10%"
+
+// KarpenterBudgetOptions는 Karpenter NodePool disruption budget 준수 옵션입니다.
+type KarpenterBudgetOptions struct {
+	Enabled bool
+	// Reasons가 비어 있으면 reasons 지정 여부와 관계없이 활성 budget을 모두 적용합니다(보수적).
+	// 지정하면 reasons가 없는 budget과, 지정한 reason 중 하나를 포함하는 budget만 적용합니다.
+	Reasons []string
+}
+
+// GetKarpenterBudgetOptionsFromEnv는 Karpenter budget 관련 환경 변수를 파싱합니다.
+func GetKarpenterBudgetOptionsFromEnv() KarpenterBudgetOptions {
+	opts := KarpenterBudgetOptions{
+		Enabled: parseEnvBool("DRAIN_HONOR_KARPENTER_BUDGETS", false),
+	}
+	if v := strings.TrimSpace(os.Getenv("DRAIN_KARPENTER_BUDGET_REASONS")); v != "" {
+		opts.Reasons = splitList(v)
+	}
+	return opts
+}
+
+// karpenterBudgetLimit은 현재 시각에 활성인 NodePool budget으로 계산한 드레인 허용 수입니다.
+type karpenterBudgetLimit struct {
+	allowed    int      // 활성 budget 중 가장 작은 허용 노드 수
+	disrupting int      // Karpenter가 이미 중단 중인 노드 수 (budget을 함께 사용)
+	active     []string // 적용된 budget 요약
+}
+
+// remaining은 이번 실행에서 추가로 드레인할 수 있는 노드 수입니다.
+func (l karpenterBudgetLimit) remaining() int {
+	return clampInt(l.allowed-l.disrupting, 0, l.allowed)
+}
+
+// loadKarpenterBudgetLimit은 NodePool CR의 spec.disruption.budgets를 읽어 지금 활성인 budget의 허용 수를 계산합니다.
+func loadKarpenterBudgetLimit(ctx context.Context, dynamicClient dynamic.Interface, clientSet kubernetes.Interface, nodepoolName string, disrupting int, opts KarpenterBudgetOptions, now time.Time) (*karpenterBudgetLimit, error) {
+	if dynamicClient == nil {
+		return nil, fmt.Errorf("Karpenter budget 확인에 dynamic client가 필요합니다")
+	}
+	nodePool, err := dynamicClient.Resource(nodePoolGVR).Get(ctx, nodepoolName, metaV1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("NodePool %s 조회 실패: %w", nodepoolName, err)
+	}
+
+	// percent budget은 필터와 관계없이 nodepool 전체 노드 수 기준입니다(Karpenter와 동일).
+	nodes, err := clientSet.CoreV1().Nodes().List(ctx, metaV1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", nodepoolLabel, nodepoolName),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	budgets, found, err := unstructured.NestedSlice(nodePool.Object, "spec", "disruption", "budgets")
+	if err != nil {
+		return nil, fmt.Errorf("NodePool %s budgets 파싱 실패: %w", nodepoolName, err)
+	}
+	if !found || len(budgets) == 0 {
+		budgets = []interface{}{map[string]interface{}{"nodes": karpenterDefaultBudgetNodes}}
+	}
+
+	limit, err := evaluateKarpenterBudgets(budgets, len(nodes.Items), opts.Reasons, now)
+	if err != nil {
+		return nil, fmt.Errorf("NodePool %s budgets 평가 실패: %w", nodepoolName, err)
+	}
+	if limit == nil {
+		slog.Info("활성 Karpenter disruption budget 없음", "nodepool", nodepoolName)
+		return nil, nil
+	}
+	limit.disrupting = disrupting
+	slog.Info("Karpenter disruption budget", "nodepool", nodepoolName, "allowed", limit.allowed, "disrupting", disrupting, "remaining", limit.remaining(), "activeBudgets", limit.active)
+	return limit, nil
+}
+
+// evaluateKarpenterBudgets는 활성 budget 중 가장 작은 허용 수를 반환합니다. 활성 budget이 없으면 nil 입니다.
+func evaluateKarpenterBudgets(budgets []interface{}, totalNodes int, reasons []string, now time.Time) (*karpenterBudgetLimit, error) {
+	var limit *karpenterBudgetLimit
+	for i, b := range budgets {
+		budget, ok := b.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("budgets[%d]: invalid budget", i)
+		}
+		nodes, _, _ := unstructured.NestedString(budget, "nodes")
+		schedule, _, _ := unstructured.NestedString(budget, "schedule")
+		duration, _, _ := unstructured.NestedString(budget, "duration")
+		budgetReasons, _, _ := unstructured.NestedStringSlice(budget, "reasons")
+
+		if !budgetAppliesToReasons(budgetReasons, reasons) {
+			continue
+		}
+		active, err := karpenterBudgetActive(schedule, duration, now)
+		if err != nil {
+			return nil, fmt.Errorf("budgets[%d]: %w", i, err)
+		}
+		if !active {
+			continue
+		}
+
+		if nodes == "" {
+			nodes = karpenterDefaultBudgetNodes
+		}
+		value := intstr.Parse(nodes)
+		allowed, err := intstr.GetScaledValueFromIntOrPercent(&value, totalNodes, true)
+		if err != nil {
+			return nil, fmt.Errorf("budgets[%d]: invalid nodes %q: %w", i, nodes, err)
+		}
+
+		desc := fmt.Sprintf("nodes=%s", nodes)
+		if schedule != "" {
+			desc += fmt.Sprintf(" schedule=%q duration=%s", schedule, duration)
+		}
+		if len(budgetReasons) > 0 {
+			desc += fmt.Sprintf(" reasons=%s", strings.Join(budgetReasons, ","))
+		}
+		if limit == nil {
+			limit = &karpenterBudgetLimit{allowed: allowed}
+		} else if allowed < limit.allowed {
+			limit.allowed = allowed
+		}
+		limit.active = append(limit.active, desc)
+	}
+	return limit, nil
+}
+
+func budgetAppliesToReasons(budgetReasons []string, reasons []string) bool {
+	if len(budgetReasons) == 0 || len(reasons) == 0 {
+		return true
+	}
+	for _, r := range budgetReasons {
+		for _, want := range reasons {
+			if strings.EqualFold(r, want) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// karpenterBudgetActive는 schedule/duration이 없으면 항상 활성이고, 있으면 UTC 기준 최근 schedule 시작 후 duration 안인지 판단합니다.
+// Karpenter(Budget.IsActive)와 같은 robfig/cron 표준 파서와 판단식을 써서 Karpenter가 적용하는 budget 기간과 일치시킵니다.
+func karpenterBudgetActive(schedule string, duration string, now time.Time) (bool, error) {
+	if schedule == "" {
+		return true, nil
+	}
+	d, err := time.ParseDuration(duration)
+	if err != nil || d <= 0 {
+		return false, fmt.Errorf("invalid duration %q", duration)
+	}
+	cronSchedule, err := cron.ParseStandard(fmt.Sprintf("TZ=UTC %s", schedule))
+	if err != nil {
+		return false, fmt.Errorf("invalid cron schedule %q: %w", schedule, err)
+	}
+	now = now.UTC()
+	nextHit := cronSchedule.Next(now.Add(-d))
+	return !nextHit.After(now), nil
+}
@@ -0,0 +1,175 @@
+package node
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newNodePool(name string, budgets ...map[string]interface{}) *unstructured.Unstructured {
+	items := make([]interface{}, 0, len(budgets))
+	for _, b := range budgets {
+		items = append(items, b)
+	}
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "karpenter.sh/v1",
+		"kind":       "NodePool",
+		"metadata":   map[string]interface{}{"name": name},
+		"spec": map[string]interface{}{
+			"disruption": map[string]interface{}{"budgets": items},
+		},
+	}}
+}
+
+func TestKarpenterBudgetActive(t *testing.T) {
+	monday := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	active, err := karpenterBudgetActive("", "", monday)
+	assert.NoError(t, err)
+	assert.True(t, active)
+
+	active, err = karpenterBudgetActive("0 9 * * mon-fri", "8h", monday.Add(12*time.Hour))
+	assert.NoError(t, err)
+	assert.True(t, active)
+
+	active, err = karpenterBudgetActive("0 9 * * mon-fri", "8h", monday.Add(17*time.Hour))
+	assert.NoError(t, err)
+	assert.False(t, active)
+
+	_, err = karpenterBudgetActive("0 9 * * *", "", monday)
+	assert.Error(t, err)
+
+	// schedule 시작 시각 자체와 duration 경계
+	active, err = karpenterBudgetActive("0 9 * * mon-fri", "8h", monday.Add(9*time.Hour))
+	assert.NoError(t, err)
+	assert.True(t, active)
+	active, err = karpenterBudgetActive("0 9 * * mon-fri", "8h", monday.Add(17*time.Hour-time.Second))
+	assert.NoError(t, err)
+	assert.True(t, active)
+
+	// 2024-01-06은 토요일
+	active, err = karpenterBudgetActive("0 9 * * mon-fri", "8h", time.Date(2024, 1, 6, 10, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.False(t, active)
+
+	// 매크로, 간격, 일/요일 지정(OR)
+	active, err = karpenterBudgetActive("@daily", "10m", time.Date(2024, 3, 7, 0, 5, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.True(t, active)
+	active, err = karpenterBudgetActive("*/15 * * * *", "1m", time.Date(2024, 1, 1, 3, 50, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.False(t, active)
+	// 1일 또는 월요일: 2024-01-15(월)은 요일로 활성
+	active, err = karpenterBudgetActive("0 0 1 * mon", "1h", time.Date(2024, 1, 15, 0, 30, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.True(t, active)
+
+	// schedule은 UTC 기준으로 평가 (now의 time zone과 무관)
+	kst := time.FixedZone("KST", 9*60*60)
+	active, err = karpenterBudgetActive("0 9 * * mon-fri", "8h", time.Date(2024, 1, 1, 19, 0, 0, 0, kst))
+	assert.NoError(t, err)
+	assert.True(t, active)
+
+	for _, invalid := range []string{"* * * *", "60 * * * *", "* * * * mon-xyz", "*/0 * * * *"} {
+		_, err := karpenterBudgetActive(invalid, "1h", monday)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestEvaluateKarpenterBudgets(t *testing.T) {
+	businessHours := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	budgets := []interface{}{
+		map[string]interface{}{"nodes": "20%"},
+		map[string]interface{}{"nodes": "0", "schedule": "0 9 * * mon-fri", "duration": "8h"},
+		map[string]interface{}{"nodes": "1", "reasons": []interface{}{"Drifted"}},
+	}
+
+	// 업무 시간에는 nodes=0 budget이 활성
+	limit, err := evaluateKarpenterBudgets(budgets, 12, nil, businessHours)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, limit.allowed)
+	assert.Len(t, limit.active, 3)
+
+	// 업무 시간 외: 20% of 12 = ceil(2.4) = 3, reasons 미지정이면 Drifted budget(1)도 적용
+	limit, err = evaluateKarpenterBudgets(budgets, 12, nil, businessHours.Add(8*time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, limit.allowed)
+
+	// reason을 Underutilized로 지정하면 Drifted 전용 budget은 제외
+	limit, err = evaluateKarpenterBudgets(budgets, 12, []string{"Underutilized"}, businessHours.Add(8*time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, limit.allowed)
+
+	// 활성 budget이 없으면 nil
+	limit, err = evaluateKarpenterBudgets(budgets[1:2], 12, nil, businessHours.Add(8*time.Hour))
+	assert.NoError(t, err)
+	assert.Nil(t, limit)
+}
+
+func TestNodeDrainHonorsKarpenterBudgets(t *testing.T) {
+	tests := []struct {
+		name          string
+		budgetNodes   string
+		node4Deleting bool
+		expectedDrain int
+	}{
+		{name: "budget이 넉넉하면 기존 계산 유지", budgetNodes: "100%", expectedDrain: 2},
+		{name: "budget 1대로 제한", budgetNodes: "1", expectedDrain: 1},
+		{name: "Karpenter 중단 중인 노드가 budget을 사용", budgetNodes: "1", node4Deleting: true, expectedDrain: 0},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			setDefaultDrainEnv(t)
+			t.Setenv("DRAIN_HONOR_KARPENTER_BUDGETS", "true")
+
+			nodepoolName := "test-nodepool"
+			clientSet := fake.NewSimpleClientset()
+			objects := []runtime.Object{newNodePool(nodepoolName, map[string]interface{}{"nodes": tt.budgetNodes})}
+			for i := 1; i <= 4; i++ {
+				if _, err := clientSet.CoreV1().Nodes().Create(context.Background(), newNode(nodepoolName, i), metaV1.CreateOptions{}); err != nil {
+					t.Fatalf("노드 생성 실패: %v", err)
+				}
+			}
+			if tt.node4Deleting {
+				objects = append(objects, newNodeClaim("claim-4", "node-4", "", true))
+			}
+
+			results, err := NodeDrain(context.Background(), clientSet, DrainDependencies{
+				AllocateRateProvider: fakeAllocateRateProvider{rates: map[string]int{"memory": 30, "cpu": 25}},
+				Notifier:             fakeNotifier{},
+				DynamicClient:        newNodeClaimDynamicClient(objects...),
+			}, DrainConfig{
+				NodepoolName: nodepoolName,
+				Eviction:     testEvictionConfig(),
+			})
+			if err != nil {
+				t.Fatalf("NodeDrain 실패: %v", err)
+			}
+			assert.Len(t, results, tt.expectedDrain)
+		})
+	}
+}
+
+func TestNodeDrainKarpenterBudgetsRequireNodePool(t *testing.T) {
+	setDefaultDrainEnv(t)
+	t.Setenv("DRAIN_HONOR_KARPENTER_BUDGETS", "true")
+
+	clientSet := fake.NewSimpleClientset(newNode("test-nodepool", 1))
+	_, err := NodeDrain(context.Background(), clientSet, DrainDependencies{
+		AllocateRateProvider: fakeAllocateRateProvider{rates: map[string]int{"memory": 30, "cpu": 25}},
+		Notifier:             fakeNotifier{},
+		DynamicClient:        newNodeClaimDynamicClient(),
+	}, DrainConfig{
+		NodepoolName: "test-nodepool",
+		Eviction:     testEvictionConfig(),
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,78 @@
+package node
+
+import (
+	"app/types"
+	"log/slog"
+)
+
+// drainPlan은 이번 실행의 드레인 계획(대상 범위, 드레인 수, 선택된 후보)입니다.
+type drainPlan struct {
+	nodepoolName    string
+	filter          NodeFilterOptions
+	totalNodes      int
+	drainNodeCount  int
+	disrupting      map[string]string        // Karpenter가 이미 중단 중이라 제외된 노드(이름 -> 사유)
+	budget          *types.DrainBudgetStatus // 여러 실행에 걸친 드레인 예산 (비활성이면 nil)
+	karpenterBudget *karpenterBudgetLimit    // Karpenter NodePool disruption budget (비활성이거나 활성 budget이 없으면 nil)
+	forecast        *allocateForecast        // forecast 정책의 예측 입력과 결과 (forecast 정책이 아니거나 조회 실패 시 nil)
+	limits          drainCandidateLimits
+	selected        []drainCandidate
+	infeasible      map[string][]string // 스케줄링 시뮬레이션에서 제외된 노드(이름 -> 배치 불가 파드)
+}
+
+// log는 드레인 계획과 선택된 노드별 선택 근거를 출력합니다.
+func (p drainPlan) log() {
+	slog.Info("드레인 계획",
+		"nodepool", p.nodepoolName,
+		"filter", p.filter.String(),
+		"totalNodes", p.totalNodes,
+		"drainNodeCount", p.drainNodeCount,
+		"selected", len(p.selected),
+		"infeasible", len(p.infeasible),
+		"karpenterDisrupting", p.disrupting,
+		"zoneCaps", p.limits.zoneCaps,
+		"capacityTypeCaps", p.limits.capacityTypeCaps,
+		"excludedCapacityTypes", p.limits.excludedCapacityTypes,
+	)
+	if p.budget != nil {
+		slog.Info("드레인 계획 예산",
+			"maxNodes", p.budget.MaxNodes,
+			"window", p.budget.Window,
+			"drainedInWindow", p.budget.DrainedInWindow,
+			"remainingAfterRun", p.budget.Remaining-len(p.selected),
+		)
+	}
+	if p.karpenterBudget != nil {
+		slog.Info("드레인 계획 Karpenter budget",
+			"allowed", p.karpenterBudget.allowed,
+			"disrupting", p.karpenterBudget.disrupting,
+			"remaining", p.karpenterBudget.remaining(),
+			"activeBudgets", p.karpenterBudget.active,
+		)
+	}
+	if p.forecast != nil {
+		slog.Info("드레인 계획 forecast",
+			"horizon", p.forecast.horizon.String(),
+			"currentAllocateRates", p.forecast.current,
+			"predictedAllocateRates", p.forecast.predicted,
+			"predictedMaxAllocateRate", p.forecast.predictedMax,
+			"bottleneck", p.forecast.bottleneck,
+		)
+	}
+	for i, c := range p.selected {
+		slog.Info("드레인 계획 노드",
+			"order", i+1,
+			"nodeName", c.node.Name,
+			"instanceType", nodeInstanceType(c.node),
+			"zone", nodeZone(c.node),
+			"capacityType", c.capacityType,
+			"unhealthyCondition", c.unhealthyCondition,
+			"score", c.score.total,
+			"scoreBreakdown", c.score.breakdown,
+			"projectedAllocateRate", c.projectedAllocateRate,
+		)
+	}
+	for nodeName, blocking := range p.infeasible {
+		slog.Warn("드레인 계획 제외 노드(스케줄링 불가)", "nodeName", nodeName, "blockingPods", blocking)
+	}
+}
@@ -0,0 +1,75 @@
+package node
+
+import (
+	"context"
+	"fmt"
+
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// listPodsByNode는 실행 중(Succeeded/Failed 제외)인 파드를 노드 이름별로 묶어 반환합니다.
+func listPodsByNode(ctx context.Context, clientSet kubernetes.Interface) (map[string][]coreV1.Pod, error) {
+	podList, err := clientSet.CoreV1().Pods("").List(ctx, metaV1.ListOptions{
+		FieldSelector: "status.phase!=Succeeded,status.phase!=Failed",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("파드 리스트 조회 실패: %w", err)
+	}
+
+	podsByNode := map[string][]coreV1.Pod{}
+	for _, p := range podList.Items {
+		if p.Spec.NodeName == "" || p.Status.Phase == coreV1.PodSucceeded || p.Status.Phase == coreV1.PodFailed {
+			continue
+		}
+		podsByNode[p.Spec.NodeName] = append(podsByNode[p.Spec.NodeName], p)
+	}
+	return podsByNode, nil
+}
+
+// podRequest는 파드의 유효 request(컨테이너 합과 init 컨테이너 최대값 중 큰 값 + overhead)를 반환합니다.
+func podRequest(p coreV1.Pod, name coreV1.ResourceName) resource.Quantity {
+	total := resource.Quantity{}
+	for _, c := range p.Spec.Containers {
+		if q, ok := c.Resources.Requests[name]; ok {
+			total.Add(q)
+		}
+	}
+	for _, c := range p.Spec.InitContainers {
+		if q, ok := c.Resources.Requests[name]; ok && q.Cmp(total) > 0 {
+			total = q.DeepCopy()
+		}
+	}
+	if q, ok := p.Spec.Overhead[name]; ok {
+		total.Add(q)
+	}
+	return total
+}
+
+// sumPodRequests는 파드 목록의 request 합계를 반환합니다.
+func sumPodRequests(pods []coreV1.Pod, name coreV1.ResourceName) resource.Quantity {
+	total := resource.Quantity{}
+	for _, p := range pods {
+		q := podRequest(p, name)
+		total.Add(q)
+	}
+	return total
+}
+
+// requestUtilization은 노드 allocatable 대비 파드 request 비율(0~1)을 CPU/Memory 중 큰 값으로 반환합니다.
+func requestUtilization(n coreV1.Node, pods []coreV1.Pod) float64 {
+	utilization := 0.0
+	for _, name := range []coreV1.ResourceName{coreV1.ResourceCPU, coreV1.ResourceMemory} {
+		allocatable, ok := n.Status.Allocatable[name]
+		if !ok || allocatable.IsZero() {
+			continue
+		}
+		requested := sumPodRequests(pods, name)
+		if ratio := float64(requested.MilliValue()) / float64(allocatable.MilliValue()); ratio > utilization {
+			utilization = ratio
+		}
+	}
+	return utilization
+}
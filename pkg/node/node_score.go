@@ -0,0 +1,251 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+// 노드 점수 신호 이름입니다. 각 신호는 0~1로 정규화되며 값이 클수록 먼저 드레인할 후보입니다.
+const (
+	scoreSignalAge         = "age"         // 오래된 노드일수록 높음
+	scoreSignalUtilization = "utilization" // request 사용률이 낮을수록 높음
+	scoreSignalPods        = "pods"        // 파드 수가 적을수록 높음
+	scoreSignalPDB         = "pdb"         // PDB 보호 파드가 적을수록 높음
+	scoreSignalZoneSkew    = "zoneSkew"    // 노드가 많이 몰린 존일수록 높음
+	scoreSignalPrice       = "price"       // 비싼 인스턴스일수록 높음
+)
+
+var scoreSignals = []string{
+	scoreSignalAge,
+	scoreSignalUtilization,
+	scoreSignalPods,
+	scoreSignalPDB,
+	scoreSignalZoneSkew,
+	scoreSignalPrice,
+}
+
+// ScoringOptions는 드레인 후보 점수 계산 설정입니다.
+// 기본값(age=1)은 오래된 노드부터 드레인하는 기존 동작과 같습니다.
+type ScoringOptions struct {
+	Weights map[string]float64 // 신호별 가중치 (0 이면 해당 신호 미사용)
+	Prices  map[string]float64 // 인스턴스 타입별 시간당 가격 (price 신호용)
+}
+
+// GetScoringOptionsFromEnv는 노드 점수 관련 환경 변수를 파싱합니다.
+func GetScoringOptionsFromEnv() ScoringOptions {
+	opts := ScoringOptions{
+		Weights: map[string]float64{scoreSignalAge: 1},
+	}
+
+	if v := strings.TrimSpace(os.Getenv("DRAIN_SCORE_WEIGHTS")); v != "" {
+		weights, err := parseKeyFloatList(v)
+		if err != nil {
+			slog.Warn("DRAIN_SCORE_WEIGHTS 파싱 실패(기본 가중치 사용)", "value", v, "error", err)
+		} else {
+			for key := range weights {
+				if !isScoreSignal(key) {
+					slog.Warn("알 수 없는 점수 신호(무시)", "signal", key)
+					delete(weights, key)
+				}
+			}
+			opts.Weights = weights
+		}
+	}
+
+	if v := strings.TrimSpace(os.Getenv("DRAIN_SCORE_PRICES")); v != "" {
+		prices, err := parseKeyFloatList(v)
+		if err != nil {
+			slog.Warn("DRAIN_SCORE_PRICES 파싱 실패(가격 신호 미사용)", "value", v, "error", err)
+		} else {
+			opts.Prices = prices
+		}
+	}
+
+	return opts
+}
+
+// parseKeyFloatList는 "key=value,key=value" 형식을 파싱합니다.
+func parseKeyFloatList(s string) (map[string]float64, error) {
+	out := map[string]float64{}
+	for _, part := range splitList(s) {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid key=value: %q", part)
+		}
+		f, err := strconv.ParseFloat(strings.TrimSpace(kv[1]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q: %w", part, err)
+		}
+		out[strings.TrimSpace(kv[0])] = f
+	}
+	return out, nil
+}
+
+func isScoreSignal(name string) bool {
+	for _, s := range scoreSignals {
+		if s == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (o ScoringOptions) uses(signal string) bool {
+	return o.Weights[signal] > 0
+}
+
+// nodeScore는 노드의 종합 점수와 신호별 가중 점수(가중치 x 정규화 값)입니다.
+type nodeScore struct {
+	total     float64
+	breakdown map[string]float64
+}
+
+// scoreNodes는 노드별 점수를 계산하고, 점수가 높은 순(동점이면 오래된 순)으로 정렬한 노드 목록을 반환합니다.
+func scoreNodes(ctx context.Context, clientSet kubernetes.Interface, nodes []coreV1.Node, opts ScoringOptions) ([]coreV1.Node, map[string]nodeScore) {
+	var podsByNode map[string][]coreV1.Pod
+	if opts.uses(scoreSignalUtilization) || opts.uses(scoreSignalPods) || opts.uses(scoreSignalPDB) {
+		pods, err := listPodsByNode(ctx, clientSet)
+		if err != nil {
+			slog.Warn("점수 계산용 파드 조회 실패(파드 기반 신호 미사용)", "error", err)
+		}
+		podsByNode = pods
+	}
+
+	var pdbSelectors map[string][]labels.Selector
+	if opts.uses(scoreSignalPDB) {
+		selectors, err := listPDBSelectors(ctx, clientSet)
+		if err != nil {
+			slog.Warn("점수 계산용 PDB 조회 실패(PDB 신호 미사용)", "error", err)
+		}
+		pdbSelectors = selectors
+	}
+
+	raw := make(map[string]map[string]float64, len(nodes))
+	nodesByZone := map[string]int{}
+	for _, n := range nodes {
+		nodesByZone[nodeZone(n)]++
+	}
+
+	now := time.Now()
+	for _, n := range nodes {
+		pods := podsByNode[n.Name]
+		raw[n.Name] = map[string]float64{
+			scoreSignalAge:         now.Sub(n.CreationTimestamp.Time).Hours(),
+			scoreSignalUtilization: requestUtilization(n, pods),
+			scoreSignalPods:        float64(len(pods)),
+			scoreSignalPDB:         float64(countPDBProtectedPods(pods, pdbSelectors)),
+			scoreSignalZoneSkew:    float64(nodesByZone[nodeZone(n)]),
+			scoreSignalPrice:       opts.Prices[nodeInstanceType(n)],
+		}
+	}
+
+	maxRaw := map[string]float64{}
+	for _, signals := range raw {
+		for signal, v := range signals {
+			maxRaw[signal] = math.Max(maxRaw[signal], v)
+		}
+	}
+
+	scores := make(map[string]nodeScore, len(nodes))
+	for _, n := range nodes {
+		score := nodeScore{breakdown: map[string]float64{}}
+		for _, signal := range scoreSignals {
+			weight := opts.Weights[signal]
+			if weight <= 0 {
+				continue
+			}
+			weighted := roundScore(weight * normalizeScoreSignal(signal, raw[n.Name][signal], maxRaw[signal]))
+			score.breakdown[signal] = weighted
+			score.total += weighted
+		}
+		score.total = roundScore(score.total)
+		scores[n.Name] = score
+	}
+
+	sorted := append([]coreV1.Node(nil), nodes...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		si, sj := scores[sorted[i].Name].total, scores[sorted[j].Name].total
+		if si != sj {
+			return si > sj
+		}
+		return sorted[i].CreationTimestamp.Before(&sorted[j].CreationTimestamp)
+	})
+	return sorted, scores
+}
+
+// normalizeScoreSignal은 신호 원시값을 0~1로 정규화합니다. 적을수록 좋은 신호는 뒤집습니다.
+func normalizeScoreSignal(signal string, v float64, maxValue float64) float64 {
+	switch signal {
+	case scoreSignalUtilization:
+		return clampFloat(1-v, 0, 1)
+	case scoreSignalPods, scoreSignalPDB:
+		if maxValue <= 0 {
+			return 1
+		}
+		return 1 - v/maxValue
+	default:
+		if maxValue <= 0 {
+			return 0
+		}
+		return v / maxValue
+	}
+}
+
+func clampFloat(v, min, max float64) float64 {
+	return math.Min(math.Max(v, min), max)
+}
+
+func roundScore(v float64) float64 {
+	return math.Round(v*1000) / 1000
+}
+
+// listPDBSelectors는 네임스페이스별 PDB 셀렉터를 반환합니다.
+func listPDBSelectors(ctx context.Context, clientSet kubernetes.Interface) (map[string][]labels.Selector, error) {
+	pdbList, err := clientSet.PolicyV1().PodDisruptionBudgets("").List(ctx, metaV1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("PDB 리스트 조회 실패: %w", err)
+	}
+
+	selectors := map[string][]labels.Selector{}
+	for _, pdb := range pdbList.Items {
+		selector, err := metaV1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil {
+			continue
+		}
+		selectors[pdb.Namespace] = append(selectors[pdb.Namespace], selector)
+	}
+	return selectors, nil
+}
+
+func countPDBProtectedPods(pods []coreV1.Pod, pdbSelectors map[string][]labels.Selector) int {
+	count := 0
+	for _, p := range pods {
+		for _, selector := range pdbSelectors[p.Namespace] {
+			if selector.Matches(labels.Set(p.Labels)) {
+				count++
+				break
+			}
+		}
+	}
+	return count
+}
+
+// annotateScores는 후보에 점수를 기록합니다.
+func annotateScores(candidates []drainCandidate, scores map[string]nodeScore) []drainCandidate {
+	for i := range candidates {
+		candidates[i].score = scores[candidates[i].node.Name]
+	}
+	return candidates
+}
@@ -0,0 +1,140 @@
+package node
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	coreV1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetScoringOptionsFromEnv(t *testing.T) {
+	t.Setenv("DRAIN_SCORE_WEIGHTS", "")
+	t.Setenv("DRAIN_SCORE_PRICES", "")
+	assert.Equal(t, map[string]float64{"age": 1}, GetScoringOptionsFromEnv().Weights)
+
+	t.Setenv("DRAIN_SCORE_WEIGHTS", "age=0.5,utilization=2,unknown=1")
+	t.Setenv("DRAIN_SCORE_PRICES", "m5.large=0.096")
+	opts := GetScoringOptionsFromEnv()
+	assert.Equal(t, map[string]float64{"age": 0.5, "utilization": 2}, opts.Weights)
+	assert.Equal(t, map[string]float64{"m5.large": 0.096}, opts.Prices)
+
+	t.Setenv("DRAIN_SCORE_WEIGHTS", "age")
+	assert.Equal(t, map[string]float64{"age": 1}, GetScoringOptionsFromEnv().Weights)
+}
+
+func TestScoreNodesDefaultKeepsAgeOrder(t *testing.T) {
+	nodes := []coreV1.Node{*newNode("np", 3), *newNode("np", 1), *newNode("np", 2)}
+
+	sorted, scores := scoreNodes(context.Background(), fake.NewSimpleClientset(), nodes, ScoringOptions{Weights: map[string]float64{"age": 1}})
+	assert.Equal(t, []string{"node-1", "node-2", "node-3"}, nodeNames(sorted))
+	assert.Equal(t, 1.0, scores["node-1"].total)
+	assert.Equal(t, map[string]float64{"age": 1}, scores["node-1"].breakdown)
+}
+
+func TestScoreNodesWeightsUtilizationAndPDB(t *testing.T) {
+	ctx := context.Background()
+	clientSet := fake.NewSimpleClientset()
+	nodes := []coreV1.Node{*newNode("np", 1), *newNode("np", 2), *newNode("np", 3)}
+	for i := range nodes {
+		nodes[i].Status.Allocatable = coreV1.ResourceList{
+			coreV1.ResourceCPU:    resource.MustParse("4"),
+			coreV1.ResourceMemory: resource.MustParse("8Gi"),
+		}
+	}
+
+	// node-1: 사용률 75%, PDB 보호 파드 1개 / node-2: 사용률 25% / node-3: 빈 노드
+	createScorePod(t, clientSet, "busy", "node-1", "3", map[string]string{"app": "api"})
+	createScorePod(t, clientSet, "light", "node-2", "1", map[string]string{"app": "batch"})
+	_, err := clientSet.PolicyV1().PodDisruptionBudgets("default").Create(ctx, &policyv1.PodDisruptionBudget{
+		ObjectMeta: metaV1.ObjectMeta{Name: "api", Namespace: "default"},
+		Spec:       policyv1.PodDisruptionBudgetSpec{Selector: &metaV1.LabelSelector{MatchLabels: map[string]string{"app": "api"}}},
+	}, metaV1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("PDB 생성 실패: %v", err)
+	}
+
+	sorted, scores := scoreNodes(ctx, clientSet, nodes, ScoringOptions{Weights: map[string]float64{"age": 0.1, "utilization": 1, "pdb": 1}})
+	assert.Equal(t, []string{"node-3", "node-2", "node-1"}, nodeNames(sorted))
+	assert.Equal(t, 0.25, scores["node-1"].breakdown["utilization"])
+	assert.Equal(t, 0.0, scores["node-1"].breakdown["pdb"])
+	assert.Equal(t, 1.0, scores["node-2"].breakdown["pdb"])
+	assert.Equal(t, 1.0, scores["node-3"].breakdown["utilization"])
+}
+
+func TestScoreNodesPriceAndZoneSkew(t *testing.T) {
+	nodes := []coreV1.Node{
+		*newZonedNode("np", 1, "a"),
+		*newZonedNode("np", 2, "b"),
+		*newZonedNode("np", 3, "b"),
+	}
+	nodes[0].Labels[instanceTypeLabel] = "m5.2xlarge"
+
+	sorted, scores := scoreNodes(context.Background(), fake.NewSimpleClientset(), nodes, ScoringOptions{
+		Weights: map[string]float64{"price": 1},
+		Prices:  map[string]float64{"m5.2xlarge": 0.4, "t3.large": 0.1},
+	})
+	assert.Equal(t, []string{"node-1", "node-2", "node-3"}, nodeNames(sorted))
+	assert.Equal(t, 0.25, scores["node-2"].total)
+
+	sorted, _ = scoreNodes(context.Background(), fake.NewSimpleClientset(), nodes, ScoringOptions{
+		Weights: map[string]float64{"zoneSkew": 1},
+	})
+	assert.Equal(t, []string{"node-2", "node-3", "node-1"}, nodeNames(sorted))
+}
+
+func TestNodeDrainRecordsScoreBreakdown(t *testing.T) {
+	setDefaultDrainEnv(t)
+	t.Setenv("DRAIN_SCORE_WEIGHTS", "age=1,pods=2")
+
+	clientSet := fake.NewSimpleClientset()
+	nodepoolName := "test-nodepool"
+	for i := 1; i <= 3; i++ {
+		if _, err := clientSet.CoreV1().Nodes().Create(context.Background(), newNode(nodepoolName, i), metaV1.CreateOptions{}); err != nil {
+			t.Fatalf("노드 생성 실패: %v", err)
+		}
+	}
+	createScorePod(t, clientSet, "busy", "node-1", "1", nil)
+
+	// lenNodes=3, memory=40,cpu=20 => 1대. node-1은 가장 오래됐지만 파드가 있어 빈 node-2가 먼저 선택됨
+	results, err := NodeDrain(context.Background(), clientSet, DrainDependencies{
+		AllocateRateProvider: fakeAllocateRateProvider{rates: map[string]int{"memory": 40, "cpu": 20}},
+		Notifier:             fakeNotifier{},
+	}, DrainConfig{
+		NodepoolName: nodepoolName,
+		Eviction:     testEvictionConfig(),
+	})
+	if err != nil {
+		t.Fatalf("NodeDrain 실패: %v", err)
+	}
+	assert.Len(t, results, 1)
+	assert.Equal(t, "node-2", results[0].NodeName)
+	assert.Contains(t, results[0].ScoreBreakdown, "age")
+	assert.Equal(t, 2.0, results[0].ScoreBreakdown["pods"])
+	assert.Greater(t, results[0].Score, 2.0)
+}
+
+func createScorePod(t *testing.T, clientSet *fake.Clientset, name string, nodeName string, cpu string, podLabels map[string]string) {
+	t.Helper()
+
+	p := &coreV1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: "default", Labels: podLabels},
+		Spec: coreV1.PodSpec{
+			NodeName: nodeName,
+			Containers: []coreV1.Container{{
+				Name: "app",
+				Resources: coreV1.ResourceRequirements{
+					Requests: coreV1.ResourceList{coreV1.ResourceCPU: resource.MustParse(cpu)},
+				},
+			}},
+		},
+		Status: coreV1.PodStatus{Phase: coreV1.PodRunning},
+	}
+	if _, err := clientSet.CoreV1().Pods("default").Create(context.Background(), p, metaV1.CreateOptions{}); err != nil {
+		t.Fatalf("파드 생성 실패: %v", err)
+	}
+}
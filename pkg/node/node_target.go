@@ -0,0 +1,151 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// podRequestProvider는 target 정책에 필요한 nodepool 파드 request(karpenter 메트릭)를 제공합니다.
+// karpenter.Client가 구현합니다. (memory는 GB, cpu는 vCPU 단위)
+type podRequestProvider interface {
+	GetKarpenterPodRequest(ctx context.Context, resourceType string) (float64, error)
+}
+
+// targetAllocateBudget은 target 정책에서 후보 제거 후 예상 사용률을 계산하기 위한 nodepool 상태입니다.
+type targetAllocateBudget struct {
+	targetRate  int
+	resources   []AllocateResource
+	requests    map[coreV1.ResourceName]float64 // nodepool 파드 request 합
+	allocatable map[coreV1.ResourceName]float64 // nodepool 노드 allocatable 합
+}
+
+// loadTargetAllocateBudget은 nodepool 노드 allocatable 합과 karpenter 파드 request 메트릭을 조회합니다.
+// Karpenter가 이미 중단 중인 노드는 곧 사라질 용량이므로 allocatable 합에서 제외합니다.
+func loadTargetAllocateBudget(ctx context.Context, clientSet kubernetes.Interface, deps DrainDependencies, nodepoolName string, disrupting map[string]string, opts DrainPolicyOptions) (*targetAllocateBudget, error) {
+	provider, ok := deps.AllocateRateProvider.(podRequestProvider)
+	if !ok {
+		return nil, fmt.Errorf("target 정책에는 파드 request 메트릭 조회가 필요합니다")
+	}
+
+	nodes, err := clientSet.CoreV1().Nodes().List(ctx, metaV1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", nodepoolLabel, nodepoolName),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	budget := &targetAllocateBudget{
+		targetRate:  opts.TargetAllocateRate,
+		resources:   opts.AllocateResources,
+		requests:    map[coreV1.ResourceName]float64{},
+		allocatable: map[coreV1.ResourceName]float64{},
+	}
+	for _, n := range nodes.Items {
+		if _, ok := disrupting[n.Name]; ok || karpenterDisruptingReason(n) != "" {
+			continue
+		}
+		for _, r := range budget.resources {
+			name := coreV1.ResourceName(r.Name)
+			budget.allocatable[name] += allocatableAmount(n, name)
+		}
+	}
+	for _, r := range budget.resources {
+		request, err := provider.GetKarpenterPodRequest(ctx, r.Name)
+		if err != nil {
+			return nil, fmt.Errorf("target 정책 %s request 조회 실패: %w", r.Name, err)
+		}
+		budget.requests[coreV1.ResourceName(r.Name)] = request
+		slog.Info("target 정책 리소스", "resource", r.Name, "request", request, "allocatable", budget.allocatable[coreV1.ResourceName(r.Name)])
+	}
+
+	slog.Info("target 정책 nodepool 상태", "targetAllocateRate", budget.targetRate, "currentAllocateRate", budget.projectedRate(nil))
+	return budget, nil
+}
+
+// allocatableAmount는 노드 allocatable을 karpenter 메트릭과 같은 단위(cpu: vCPU, memory/ephemeral-storage: GB, 그 외: 개수)로 반환합니다.
+func allocatableAmount(n coreV1.Node, name coreV1.ResourceName) float64 {
+	q, ok := n.Status.Allocatable[name]
+	if !ok {
+		return 0
+	}
+	switch name {
+	case coreV1.ResourceCPU:
+		return float64(q.MilliValue()) / 1000
+	case coreV1.ResourceMemory, coreV1.ResourceEphemeralStorage:
+		return float64(q.Value()) / (1000 * 1000 * 1000)
+	default:
+		return float64(q.Value())
+	}
+}
+
+// projectedRate는 removed 만큼의 allocatable을 제거했을 때 리소스별 예상 사용률(가중치 적용) 중 가장 큰 값(%)을 반환합니다.
+// 남는 allocatable이 없으면 100을 넘는 값으로 취급합니다.
+func (b *targetAllocateBudget) projectedRate(removed map[coreV1.ResourceName]float64) int {
+	rate := 0.0
+	for _, r := range b.resources {
+		name := coreV1.ResourceName(r.Name)
+		remaining := b.allocatable[name] - removed[name]
+		if remaining <= 0 {
+			if b.requests[name] > 0 {
+				return math.MaxInt32
+			}
+			continue
+		}
+		rate = math.Max(rate, b.requests[name]/remaining*100*r.Weight)
+	}
+	return int(math.Ceil(rate))
+}
+
+// admits는 이미 선택된 removed에 노드 n을 더 제거해도 예상 사용률이 목표 미만인지 확인합니다.
+func (b *targetAllocateBudget) admits(n coreV1.Node, removed map[coreV1.ResourceName]float64) (bool, int) {
+	next := make(map[coreV1.ResourceName]float64, len(b.resources))
+	for _, r := range b.resources {
+		name := coreV1.ResourceName(r.Name)
+		next[name] = removed[name] + allocatableAmount(n, name)
+	}
+	projected := b.projectedRate(next)
+	return projected < b.targetRate, projected
+}
+
+// orderBySize는 target 정책 후보를 같은 우선순위(비정상 노드, capacity-type) 안에서 allocatable이 작은 노드부터 정렬합니다.
+// 목표 사용률 안에서 가장 많은 노드를 고르려면 작은 노드부터 채워야 합니다. 큰 노드가 먼저 오면 작은 노드 여러 대가 쓸 수 있는 여유를 혼자 써 버립니다.
+func (b *targetAllocateBudget) orderBySize(candidates []drainCandidate) []drainCandidate {
+	ordered := append([]drainCandidate(nil), candidates...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if lessDrainPriority(ordered[i], ordered[j]) {
+			return true
+		}
+		if lessDrainPriority(ordered[j], ordered[i]) {
+			return false
+		}
+		return b.share(ordered[i].node) < b.share(ordered[j].node)
+	})
+	return ordered
+}
+
+// share는 노드 allocatable이 nodepool 전체에서 차지하는 비율(가중치 적용) 중 가장 큰 값입니다.
+func (b *targetAllocateBudget) share(n coreV1.Node) float64 {
+	share := 0.0
+	for _, r := range b.resources {
+		name := coreV1.ResourceName(r.Name)
+		if b.allocatable[name] <= 0 {
+			continue
+		}
+		share = math.Max(share, allocatableAmount(n, name)/b.allocatable[name]*r.Weight)
+	}
+	return share
+}
+
+func (b *targetAllocateBudget) remove(n coreV1.Node, removed map[coreV1.ResourceName]float64) {
+	for _, r := range b.resources {
+		name := coreV1.ResourceName(r.Name)
+		removed[name] += allocatableAmount(n, name)
+	}
+}
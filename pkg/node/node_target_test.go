@@ -0,0 +1,163 @@
+package node
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+type fakePodRequestProvider struct {
+	fakeAllocateRateProvider
+	requests map[string]float64
+}
+
+func (f fakePodRequestProvider) GetKarpenterPodRequest(ctx context.Context, resourceType string) (float64, error) {
+	return f.requests[resourceType], nil
+}
+
+func TestCalculateDrainNodeCountTargetPolicyAppliesCapsOnly(t *testing.T) {
+	opts := DrainPolicyOptions{Policy: DrainPolicyTarget}
+	assert.Equal(t, 10, CalculateDrainNodeCount(10, 95, opts, nil, 0))
+
+	opts.MaxDrainAbsolute = 3
+	assert.Equal(t, 3, CalculateDrainNodeCount(10, 95, opts, nil, 0))
+}
+
+func TestTargetAllocateBudgetAdmits(t *testing.T) {
+	budget := &targetAllocateBudget{
+		targetRate:  80,
+		resources:   defaultAllocateResources,
+		requests:    map[coreV1.ResourceName]float64{coreV1.ResourceCPU: 10, coreV1.ResourceMemory: 10},
+		allocatable: map[coreV1.ResourceName]float64{coreV1.ResourceCPU: 32, coreV1.ResourceMemory: 64},
+	}
+	assert.Equal(t, 32, budget.projectedRate(nil))
+
+	removed := map[coreV1.ResourceName]float64{}
+	large := *newSizedNode("np", 1, "16", "32Gi")
+	ok, projected := budget.admits(large, removed)
+	assert.True(t, ok)
+	assert.Equal(t, 63, projected)
+	budget.remove(large, removed)
+
+	ok, projected = budget.admits(*newSizedNode("np", 2, "8", "16Gi"), removed)
+	assert.False(t, ok)
+	assert.Equal(t, 125, projected)
+
+	ok, _ = budget.admits(*newSizedNode("np", 3, "2", "4Gi"), removed)
+	assert.True(t, ok)
+}
+
+func TestNodeDrainTargetPolicyUsesNodeAllocatable(t *testing.T) {
+	setDefaultDrainEnv(t)
+	t.Setenv("DRAIN_POLICY", "target")
+	t.Setenv("DRAIN_TARGET_ALLOCATE_RATE", "70")
+
+	clientSet := fake.NewSimpleClientset()
+	nodepoolName := "test-nodepool"
+	// 오래된 순: node-1(16vCPU), node-2(4vCPU), node-3(4vCPU), node-4(16vCPU) => 합계 40vCPU
+	for i, cpu := range []string{"16", "4", "4", "16"} {
+		node := newSizedNode(nodepoolName, i+1, cpu, "64Gi")
+		if _, err := clientSet.CoreV1().Nodes().Create(context.Background(), node, metaV1.CreateOptions{}); err != nil {
+			t.Fatalf("노드 생성 실패: %v", err)
+		}
+	}
+
+	// cpu request 16vCPU => 현재 40%. 작은 노드부터: node-2 제거 시 45%, node-3 제거 시 50%, 이후 16vCPU 제거 시 100%로 초과
+	// (점수 순서대로 node-1을 먼저 고르면 67%가 되어 작은 노드를 더 고를 수 없고 1대만 드레인됨)
+	results, err := NodeDrain(context.Background(), clientSet, DrainDependencies{
+		AllocateRateProvider: fakePodRequestProvider{
+			fakeAllocateRateProvider: fakeAllocateRateProvider{rates: map[string]int{"memory": 10, "cpu": 40}},
+			requests:                 map[string]float64{"cpu": 16, "memory": 10},
+		},
+		Notifier: fakeNotifier{},
+	}, DrainConfig{
+		NodepoolName: nodepoolName,
+		Eviction:     testEvictionConfig(),
+	})
+	if err != nil {
+		t.Fatalf("NodeDrain 실패: %v", err)
+	}
+	assert.Equal(t, []string{"node-2", "node-3"}, resultNodeNames(results))
+	assertNodeUnschedulable(t, clientSet, "node-1", false)
+	assertNodeUnschedulable(t, clientSet, "node-4", false)
+}
+
+func TestPickDrainCandidatesTargetPrefersSmallNodes(t *testing.T) {
+	budget := &targetAllocateBudget{
+		targetRate:  80,
+		resources:   []AllocateResource{{Name: "cpu", Weight: 1}},
+		requests:    map[coreV1.ResourceName]float64{coreV1.ResourceCPU: 20},
+		allocatable: map[coreV1.ResourceName]float64{coreV1.ResourceCPU: 48},
+	}
+	// 점수 순서: 16vCPU, 8vCPU, 4vCPU, 4vCPU, 16vCPU => 합계 48vCPU, 목표 80% 미만이려면 최대 22vCPU 제거 가능
+	candidates := []drainCandidate{
+		{node: *newSizedNode("np", 1, "16", "1Gi")},
+		{node: *newSizedNode("np", 2, "8", "1Gi")},
+		{node: *newSizedNode("np", 3, "4", "1Gi")},
+		{node: *newSizedNode("np", 4, "4", "1Gi")},
+		{node: *newSizedNode("np", 5, "16", "1Gi")},
+	}
+
+	picked := pickDrainCandidates(candidates, len(candidates), drainCandidateLimits{target: budget})
+
+	names := make([]string, 0, len(picked))
+	for _, c := range picked {
+		names = append(names, c.node.Name)
+	}
+	// 4+4+8=16vCPU 제거 => 20/32=63%. 16vCPU를 먼저 고르면 2대(16+4)만 가능
+	assert.Equal(t, []string{"node-3", "node-4", "node-2"}, names)
+	assert.Equal(t, 63, picked[2].projectedAllocateRate)
+}
+
+func TestTargetOrderBySizeKeepsPriorityGroups(t *testing.T) {
+	budget := &targetAllocateBudget{
+		resources:   []AllocateResource{{Name: "cpu", Weight: 1}},
+		allocatable: map[coreV1.ResourceName]float64{coreV1.ResourceCPU: 20},
+	}
+	candidates := []drainCandidate{
+		{node: *newSizedNode("np", 1, "16", "1Gi"), unhealthyCondition: "NotReady"},
+		{node: *newSizedNode("np", 2, "8", "1Gi")},
+		{node: *newSizedNode("np", 3, "4", "1Gi")},
+	}
+
+	ordered := budget.orderBySize(candidates)
+
+	assert.Equal(t, "node-1", ordered[0].node.Name)
+	assert.Equal(t, "node-3", ordered[1].node.Name)
+	assert.Equal(t, "node-2", ordered[2].node.Name)
+	assert.Equal(t, "node-1", candidates[0].node.Name)
+	assert.Equal(t, "node-2", candidates[1].node.Name)
+}
+
+func TestNodeDrainTargetPolicyRequiresPodRequestProvider(t *testing.T) {
+	setDefaultDrainEnv(t)
+	t.Setenv("DRAIN_POLICY", "target")
+
+	clientSet := fake.NewSimpleClientset()
+	if _, err := clientSet.CoreV1().Nodes().Create(context.Background(), newSizedNode("test-nodepool", 1, "4", "16Gi"), metaV1.CreateOptions{}); err != nil {
+		t.Fatalf("노드 생성 실패: %v", err)
+	}
+
+	_, err := NodeDrain(context.Background(), clientSet, DrainDependencies{
+		AllocateRateProvider: fakeAllocateRateProvider{rates: map[string]int{"memory": 10, "cpu": 10}},
+		Notifier:             fakeNotifier{},
+	}, DrainConfig{
+		NodepoolName: "test-nodepool",
+		Eviction:     testEvictionConfig(),
+	})
+	assert.Error(t, err)
+}
+
+func newSizedNode(nodepool string, order int, cpu string, memory string) *coreV1.Node {
+	node := newNode(nodepool, order)
+	node.Status.Allocatable = coreV1.ResourceList{
+		coreV1.ResourceCPU:    resource.MustParse(cpu),
+		coreV1.ResourceMemory: resource.MustParse(memory),
+	}
+	return node
+}
@@ -0,0 +1,96 @@
+package node
+
+import (
+	"math"
+
+	coreV1 "k8s.io/api/core/v1"
+)
+
+const zoneLabel = "topology.kubernetes.io/zone"
+
+func nodeZone(n coreV1.Node) string {
+	return n.Labels[zoneLabel]
+}
+
+// interleaveByZone은 같은 우선순위 구간 안에서 존을 번갈아 가며 후보를 재배치합니다.
+// 각 존 내부의 순서와 존이 처음 등장한 순서는 유지됩니다.
+func interleaveByZone(candidates []drainCandidate) []drainCandidate {
+	out := make([]drainCandidate, 0, len(candidates))
+	for start := 0; start < len(candidates); {
+		end := start + 1
+		for end < len(candidates) && sameDrainPriority(candidates[start], candidates[end]) {
+			end++
+		}
+		out = append(out, roundRobinByZone(candidates[start:end])...)
+		start = end
+	}
+	return out
+}
+
+func roundRobinByZone(candidates []drainCandidate) []drainCandidate {
+	var zones []string
+	byZone := map[string][]drainCandidate{}
+	for _, c := range candidates {
+		zone := nodeZone(c.node)
+		if _, ok := byZone[zone]; !ok {
+			zones = append(zones, zone)
+		}
+		byZone[zone] = append(byZone[zone], c)
+	}
+
+	out := make([]drainCandidate, 0, len(candidates))
+	for len(out) < len(candidates) {
+		for _, zone := range zones {
+			if len(byZone[zone]) == 0 {
+				continue
+			}
+			out = append(out, byZone[zone][0])
+			byZone[zone] = byZone[zone][1:]
+		}
+	}
+	return out
+}
+
+// sameDrainPriority는 두 후보가 같은 우선순위 구간(예: 비정상/정상, capacity-type)에 속하는지 판단합니다.
+func sameDrainPriority(a, b drainCandidate) bool {
+	return !lessDrainPriority(a, b) && !lessDrainPriority(b, a)
+}
+
+// zoneDrainCaps는 존별 최대 드레인 노드 수를 계산합니다. 존별 상한이 비활성이면 nil을 반환합니다.
+// nodes는 Karpenter가 중단 중인 노드를 포함한 nodepool 전체이며, 중단 중인 노드(disrupting)는 해당 존의 상한을 사용합니다.
+func zoneDrainCaps(nodes []coreV1.Node, disrupting map[string]string, opts DrainPolicyOptions) map[string]int {
+	if opts.MaxDrainPerZone <= 0 && opts.MaxDrainFractionPerZone <= 0 {
+		return nil
+	}
+
+	nodesByZone := map[string]int{}
+	disruptingByZone := map[string]int{}
+	for _, n := range nodes {
+		zone := nodeZone(n)
+		nodesByZone[zone]++
+		if disrupting[n.Name] != "" {
+			disruptingByZone[zone]++
+		}
+	}
+
+	caps := make(map[string]int, len(nodesByZone))
+	for zone, lenZoneNodes := range nodesByZone {
+		capValue := lenZoneNodes
+		if opts.MaxDrainFractionPerZone > 0 {
+			// 전체 상한과 동일하게 ceil로 계산
+			fCap := int(math.Ceil(float64(lenZoneNodes) * opts.MaxDrainFractionPerZone))
+			if fCap < capValue {
+				capValue = fCap
+			}
+		}
+		if opts.MaxDrainPerZone > 0 && opts.MaxDrainPerZone < capValue {
+			capValue = opts.MaxDrainPerZone
+		}
+		capValue -= disruptingByZone[zone]
+		if capValue < 0 {
+			capValue = 0
+		}
+		caps[zone] = capValue
+	}
+	return caps
+}
@@ -0,0 +1,102 @@
+package node
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestInterleaveByZoneKeepsPriorityGroups(t *testing.T) {
+	candidates := []drainCandidate{
+		{node: *newZonedNode("np", 1, "a"), unhealthyCondition: "NotReady"},
+		{node: *newZonedNode("np", 2, "a")},
+		{node: *newZonedNode("np", 3, "a")},
+		{node: *newZonedNode("np", 4, "b")},
+		{node: *newZonedNode("np", 5, "b")},
+		{node: *newZonedNode("np", 6, "c")},
+	}
+
+	got := interleaveByZone(candidates)
+	assert.Equal(t, []string{"node-1", "node-2", "node-4", "node-6", "node-3", "node-5"}, candidateNames(got))
+}
+
+func TestZoneDrainCaps(t *testing.T) {
+	nodes := []coreV1.Node{
+		*newZonedNode("np", 1, "a"),
+		*newZonedNode("np", 2, "a"),
+		*newZonedNode("np", 3, "a"),
+		*newZonedNode("np", 4, "b"),
+	}
+
+	assert.Nil(t, zoneDrainCaps(nodes, nil, DrainPolicyOptions{}))
+	assert.Equal(t, map[string]int{"a": 2, "b": 1}, zoneDrainCaps(nodes, nil, DrainPolicyOptions{MaxDrainFractionPerZone: 0.5}))
+	assert.Equal(t, map[string]int{"a": 1, "b": 1}, zoneDrainCaps(nodes, nil, DrainPolicyOptions{MaxDrainPerZone: 1, MaxDrainFractionPerZone: 0.5}))
+
+	// Karpenter가 중단 중인 노드는 해당 존의 상한을 사용
+	disrupting := map[string]string{"node-1": disruptingReasonTaint}
+	assert.Equal(t, map[string]int{"a": 1, "b": 1}, zoneDrainCaps(nodes, disrupting, DrainPolicyOptions{MaxDrainFractionPerZone: 0.5}))
+	assert.Equal(t, map[string]int{"a": 0, "b": 1}, zoneDrainCaps(nodes, disrupting, DrainPolicyOptions{MaxDrainPerZone: 1}))
+}
+
+func TestPickDrainCandidatesSkipsZonesAtCap(t *testing.T) {
+	candidates := []drainCandidate{
+		{node: *newZonedNode("np", 1, "a")},
+		{node: *newZonedNode("np", 2, "a")},
+		{node: *newZonedNode("np", 3, "b")},
+	}
+
+	got := pickDrainCandidates(candidates, 2, drainCandidateLimits{zoneCaps: map[string]int{"a": 1, "b": 1}})
+	assert.Equal(t, []string{"node-1", "node-3"}, candidateNames(got))
+
+	got = pickDrainCandidates(candidates, 3, drainCandidateLimits{zoneCaps: map[string]int{"a": 1, "b": 1}})
+	assert.Equal(t, []string{"node-1", "node-3"}, candidateNames(got))
+}
+
+func TestNodeDrainInterleavesZones(t *testing.T) {
+	setDefaultDrainEnv(t)
+	t.Setenv("DRAIN_MAX_PER_ZONE", "1")
+
+	clientSet := fake.NewSimpleClientset()
+	nodepoolName := "test-nodepool"
+	for i, zone := range []string{"a", "a", "b", "b"} {
+		node := newZonedNode(nodepoolName, i+1, zone)
+		if _, err := clientSet.CoreV1().Nodes().Create(context.Background(), node, metaV1.CreateOptions{}); err != nil {
+			t.Fatalf("노드 생성 실패: %v", err)
+		}
+	}
+
+	// lenNodes=4, max=20 => floor(3.16)=3 이지만 존별 최대 1대
+	results, err := NodeDrain(context.Background(), clientSet, DrainDependencies{
+		AllocateRateProvider: fakeAllocateRateProvider{rates: map[string]int{"memory": 20, "cpu": 20}},
+		Notifier:             fakeNotifier{},
+	}, DrainConfig{
+		NodepoolName: nodepoolName,
+		Eviction:     testEvictionConfig(),
+	})
+	if err != nil {
+		t.Fatalf("NodeDrain 실패: %v", err)
+	}
+	assert.Len(t, results, 2)
+	assert.Equal(t, "node-1", results[0].NodeName)
+	assert.Equal(t, "a", results[0].Zone)
+	assert.Equal(t, "node-3", results[1].NodeName)
+	assert.Equal(t, "b", results[1].Zone)
+}
+
+func newZonedNode(nodepool string, order int, zone string) *coreV1.Node {
+	node := newNode(nodepool, order)
+	node.Labels[zoneLabel] = zone
+	return node
+}
+
+func candidateNames(candidates []drainCandidate) []string {
+	names := make([]string, 0, len(candidates))
+	for _, c := range candidates {
+		names = append(names, c.node.Name)
+	}
+	return names
+}
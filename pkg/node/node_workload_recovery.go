@@ -0,0 +1,207 @@
+package node
+
+import (
+	"app/pkg/pod"
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	coreV1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type WorkloadRecoveryFailPolicy string
+
+const (
+	// WorkloadRecoveryFailStop은 타임아웃 시 남은 노드 드레인을 중단합니다.
+	WorkloadRecoveryFailStop WorkloadRecoveryFailPolicy = "stop"
+	// WorkloadRecoveryFailContinue는 타임아웃 시 경고만 남기고 다음 노드를 진행합니다.
+	WorkloadRecoveryFailContinue WorkloadRecoveryFailPolicy = "continue"
+	// WorkloadRecoveryFailError는 타임아웃 시 드레인을 실패로 종료합니다.
+	WorkloadRecoveryFailError WorkloadRecoveryFailPolicy = "fail"
+)
+
+// WorkloadRecoveryOptions는 점진적 드레인에서 다음 노드로 넘어가기 전 워크로드 복구 대기 옵션입니다.
+type WorkloadRecoveryOptions struct {
+	Enabled    bool
+	Timeout    time.Duration
+	Interval   time.Duration
+	FailPolicy WorkloadRecoveryFailPolicy
+}
+
+// GetWorkloadRecoveryOptionsFromEnv는 워크로드 복구 대기 관련 환경 변수를 파싱합니다.
+func GetWorkloadRecoveryOptionsFromEnv() WorkloadRecoveryOptions {
+	opts := WorkloadRecoveryOptions{
+		Enabled:    parseEnvBool("DRAIN_WAIT_WORKLOAD_RECOVERY", false),
+		Timeout:    parseEnvDuration("DRAIN_WORKLOAD_RECOVERY_TIMEOUT", 10*time.Minute),
+		Interval:   parseEnvDuration("DRAIN_WORKLOAD_RECOVERY_INTERVAL", 10*time.Second),
+		FailPolicy: WorkloadRecoveryFailStop,
+	}
+	if v := strings.TrimSpace(os.Getenv("DRAIN_WORKLOAD_RECOVERY_FAIL_POLICY")); v != "" {
+		switch WorkloadRecoveryFailPolicy(strings.ToLower(v)) {
+		case WorkloadRecoveryFailStop, WorkloadRecoveryFailContinue, WorkloadRecoveryFailError:
+			opts.FailPolicy = WorkloadRecoveryFailPolicy(strings.ToLower(v))
+		}
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = 10 * time.Second
+	}
+	return opts
+}
+
+// workloadRef는 드레인한 파드를 소유한 워크로드입니다. (Deployment, StatefulSet, ReplicaSet)
+type workloadRef struct {
+	kind      string
+	namespace string
+	name      string
+}
+
+func (w workloadRef) String() string {
+	return fmt.Sprintf("%s/%s/%s", w.kind, w.namespace, w.name)
+}
+
+// collectNodeWorkloads는 노드의 데몬셋 제외 파드를 소유한 워크로드를 반환합니다.
+// Deployment가 관리하는 ReplicaSet은 Deployment로 올려서 기록합니다.
+func collectNodeWorkloads(ctx context.Context, clientSet kubernetes.Interface, nodeName string) ([]workloadRef, error) {
+	pods, err := pod.GetNonCriticalPods(ctx, clientSet, nodeName)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[workloadRef]bool)
+	for _, p := range pods {
+		ref, ok := podWorkload(ctx, clientSet, p)
+		if ok {
+			seen[ref] = true
+		}
+	}
+
+	refs := make([]workloadRef, 0, len(seen))
+	for ref := range seen {
+		refs = append(refs, ref)
+	}
+	sort.Slice(refs, func(i, j int) bool { return refs[i].String() < refs[j].String() })
+	return refs, nil
+}
+
+func podWorkload(ctx context.Context, clientSet kubernetes.Interface, p coreV1.Pod) (workloadRef, bool) {
+	owner := metaV1.GetControllerOf(&p)
+	if owner == nil {
+		return workloadRef{}, false
+	}
+	switch owner.Kind {
+	case "StatefulSet":
+		return workloadRef{kind: "StatefulSet", namespace: p.Namespace, name: owner.Name}, true
+	case "ReplicaSet":
+		rs, err := clientSet.AppsV1().ReplicaSets(p.Namespace).Get(ctx, owner.Name, metaV1.GetOptions{})
+		if err == nil {
+			if rsOwner := metaV1.GetControllerOf(rs); rsOwner != nil && rsOwner.Kind == "Deployment" {
+				return workloadRef{kind: "Deployment", namespace: p.Namespace, name: rsOwner.Name}, true
+			}
+		}
+		return workloadRef{kind: "ReplicaSet", namespace: p.Namespace, name: owner.Name}, true
+	}
+	return workloadRef{}, false
+}
+
+// workloadAvailable은 워크로드의 desired replica가 모두 available인지 반환합니다. 삭제된 워크로드는 복구된 것으로 봅니다.
+func workloadAvailable(ctx context.Context, clientSet kubernetes.Interface, ref workloadRef) (bool, error) {
+	var desired, available int32
+	var err error
+	switch ref.kind {
+	case "Deployment":
+		d, getErr := clientSet.AppsV1().Deployments(ref.namespace).Get(ctx, ref.name, metaV1.GetOptions{})
+		if getErr == nil {
+			desired, available = desiredReplicas(d.Spec.Replicas), d.Status.AvailableReplicas
+			if d.Status.UnavailableReplicas > 0 {
+				return false, nil
+			}
+		}
+		err = getErr
+	case "StatefulSet":
+		s, getErr := clientSet.AppsV1().StatefulSets(ref.namespace).Get(ctx, ref.name, metaV1.GetOptions{})
+		if getErr == nil {
+			desired, available = desiredReplicas(s.Spec.Replicas), s.Status.AvailableReplicas
+		}
+		err = getErr
+	case "ReplicaSet":
+		rs, getErr := clientSet.AppsV1().ReplicaSets(ref.namespace).Get(ctx, ref.name, metaV1.GetOptions{})
+		if getErr == nil {
+			desired, available = desiredReplicas(rs.Spec.Replicas), rs.Status.AvailableReplicas
+		}
+		err = getErr
+	default:
+		return true, nil
+	}
+	if apierrors.IsNotFound(err) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return available >= desired, nil
+}
+
+// waitForWorkloadRecovery는 모든 워크로드가 available이 될 때까지 기다립니다. 타임아웃 시 아직 복구되지 않은 워크로드와 함께 오류를 반환합니다.
+func waitForWorkloadRecovery(ctx context.Context, clientSet kubernetes.Interface, refs []workloadRef, opts WorkloadRecoveryOptions) error {
+	if len(refs) == 0 {
+		return nil
+	}
+	slog.Info("워크로드 복구 대기 시작", "workloads", len(refs), "timeout", opts.Timeout.String())
+
+	waitCtx := ctx
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	ticker := time.NewTicker(opts.Interval)
+	defer ticker.Stop()
+
+	pending := refs
+	for {
+		notReady := unavailableWorkloads(waitCtx, clientSet, pending)
+		if len(notReady) == 0 {
+			slog.Info("워크로드 복구 완료", "workloads", len(refs))
+			return nil
+		}
+		pending = notReady
+		slog.Info("워크로드 복구 대기 중", "remaining", workloadNames(pending))
+
+		select {
+		case <-waitCtx.Done():
+			return fmt.Errorf("워크로드 복구 대기 타임아웃 (%s): %w", strings.Join(workloadNames(pending), ", "), waitCtx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// unavailableWorkloads는 아직 available이 아닌(또는 상태 조회에 실패한) 워크로드를 반환합니다.
+func unavailableWorkloads(ctx context.Context, clientSet kubernetes.Interface, refs []workloadRef) []workloadRef {
+	var notReady []workloadRef
+	for _, ref := range refs {
+		ok, err := workloadAvailable(ctx, clientSet, ref)
+		if err != nil {
+			slog.Warn("워크로드 상태 조회 실패(재시도)", "workload", ref.String(), "error", err)
+		}
+		if !ok {
+			notReady = append(notReady, ref)
+		}
+	}
+	return notReady
+}
+
+func workloadNames(refs []workloadRef) []string {
+	names := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		names = append(names, ref.String())
+	}
+	return names
+}
@@ -0,0 +1,132 @@
+package node
+
+import (
+	"app/pkg/pod"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	appsV1 "k8s.io/api/apps/v1"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func controllerRef(kind string, name string) []metaV1.OwnerReference {
+	controller := true
+	return []metaV1.OwnerReference{{Kind: kind, Name: name, Controller: &controller}}
+}
+
+func workloadObjects(availableReplicas int32) []runtime.Object {
+	return []runtime.Object{
+		&appsV1.Deployment{
+			ObjectMeta: metaV1.ObjectMeta{Name: "api", Namespace: "default"},
+			Spec:       appsV1.DeploymentSpec{Replicas: int32Ptr(2)},
+			Status:     appsV1.DeploymentStatus{AvailableReplicas: availableReplicas},
+		},
+		&appsV1.ReplicaSet{
+			ObjectMeta: metaV1.ObjectMeta{Name: "api-abc", Namespace: "default", OwnerReferences: controllerRef("Deployment", "api")},
+			Spec:       appsV1.ReplicaSetSpec{Replicas: int32Ptr(2)},
+		},
+		&appsV1.StatefulSet{
+			ObjectMeta: metaV1.ObjectMeta{Name: "db", Namespace: "default"},
+			Spec:       appsV1.StatefulSetSpec{Replicas: int32Ptr(1)},
+			Status:     appsV1.StatefulSetStatus{AvailableReplicas: 1},
+		},
+		&coreV1.Pod{
+			ObjectMeta: metaV1.ObjectMeta{Name: "api-abc-1", Namespace: "default", OwnerReferences: controllerRef("ReplicaSet", "api-abc")},
+			Spec:       coreV1.PodSpec{NodeName: "node-1"},
+			Status:     coreV1.PodStatus{Phase: coreV1.PodRunning},
+		},
+		&coreV1.Pod{
+			ObjectMeta: metaV1.ObjectMeta{Name: "db-0", Namespace: "default", OwnerReferences: controllerRef("StatefulSet", "db")},
+			Spec:       coreV1.PodSpec{NodeName: "node-1"},
+			Status:     coreV1.PodStatus{Phase: coreV1.PodRunning},
+		},
+		&coreV1.Pod{
+			ObjectMeta: metaV1.ObjectMeta{Name: "standalone", Namespace: "default"},
+			Spec:       coreV1.PodSpec{NodeName: "node-1"},
+			Status:     coreV1.PodStatus{Phase: coreV1.PodRunning},
+		},
+	}
+}
+
+func TestCollectNodeWorkloads(t *testing.T) {
+	clientSet := fake.NewSimpleClientset(workloadObjects(2)...)
+
+	refs, err := collectNodeWorkloads(context.Background(), clientSet, "node-1")
+	assert.NoError(t, err)
+	assert.Equal(t, []workloadRef{
+		{kind: "Deployment", namespace: "default", name: "api"},
+		{kind: "StatefulSet", namespace: "default", name: "db"},
+	}, refs)
+}
+
+func TestWaitForWorkloadRecovery(t *testing.T) {
+	opts := WorkloadRecoveryOptions{Enabled: true, Timeout: 50 * time.Millisecond, Interval: 10 * time.Millisecond}
+	refs := []workloadRef{
+		{kind: "Deployment", namespace: "default", name: "api"},
+		{kind: "StatefulSet", namespace: "default", name: "db"},
+		{kind: "ReplicaSet", namespace: "default", name: "deleted"},
+	}
+
+	err := waitForWorkloadRecovery(context.Background(), fake.NewSimpleClientset(workloadObjects(2)...), refs, opts)
+	assert.NoError(t, err)
+
+	err = waitForWorkloadRecovery(context.Background(), fake.NewSimpleClientset(workloadObjects(1)...), refs, opts)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Deployment/default/api")
+	assert.NotContains(t, err.Error(), "StatefulSet/default/db")
+}
+
+func TestNodeDrainWaitsForWorkloadRecovery(t *testing.T) {
+	tests := []struct {
+		name          string
+		available     int32
+		failPolicy    string
+		expectedDrain int
+		expectErr     bool
+	}{
+		{name: "복구되면 다음 노드 진행", available: 2, expectedDrain: 2},
+		{name: "복구 타임아웃 시 중단", available: 1, failPolicy: "stop", expectedDrain: 1},
+		{name: "복구 타임아웃 무시", available: 1, failPolicy: "continue", expectedDrain: 2},
+		{name: "복구 타임아웃 시 실패", available: 1, failPolicy: "fail", expectedDrain: 1, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			setDefaultDrainEnv(t)
+			t.Setenv("DRAIN_PROGRESSIVE", "true")
+			t.Setenv("DRAIN_WAIT_WORKLOAD_RECOVERY", "true")
+			t.Setenv("DRAIN_WORKLOAD_RECOVERY_TIMEOUT", "50ms")
+			t.Setenv("DRAIN_WORKLOAD_RECOVERY_INTERVAL", "10ms")
+			t.Setenv("DRAIN_WORKLOAD_RECOVERY_FAIL_POLICY", tt.failPolicy)
+
+			nodepoolName := "test-nodepool"
+			objects := workloadObjects(tt.available)
+			for i := 1; i <= 4; i++ {
+				objects = append(objects, newNode(nodepoolName, i))
+			}
+			clientSet := fake.NewSimpleClientset(objects...)
+
+			evictionCfg := testEvictionConfig()
+			evictionCfg.EvictionMode = pod.EvictionModeDelete
+			results, err := NodeDrain(context.Background(), clientSet, DrainDependencies{
+				AllocateRateProvider: fakeAllocateRateProvider{rates: map[string]int{"memory": 30, "cpu": 25}},
+				Notifier:             fakeNotifier{},
+			}, DrainConfig{
+				NodepoolName: nodepoolName,
+				Eviction:     evictionCfg,
+			})
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Len(t, results, tt.expectedDrain)
+		})
+	}
+}
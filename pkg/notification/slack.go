@@ -10,6 +10,7 @@ import (
 	"log/slog"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -148,6 +149,72 @@ func (s *SlackNotifier) SendKarpenterAllocateRate(ctx context.Context, memoryAll
 	return s.sendSlackMessage(ctx, message)
 }
 
+// SendKarpenterAllocateRates sends allocation rates of every configured resource.
+func (s *SlackNotifier) SendKarpenterAllocateRates(ctx context.Context, rates []types.ResourceAllocateRate) error {
+	if s.webhookURL == "" {
+		return nil
+	}
+	return s.sendSlackMessage(ctx, formatAllocateRatesMessage(s.clusterName, s.nodepoolName, rates))
+}
+
+func formatAllocateRatesMessage(clusterName string, nodepoolName string, rates []types.ResourceAllocateRate) string {
+	message := fmt.Sprintf("🔄 %s Nodepool(%s) 의 현재 Karpenter Allocate Rate\n\n", clusterName, nodepoolName)
+	for _, r := range rates {
+		message += fmt.Sprintf("• %s AllocateRate: %d%%\n", r.Resource, r.AllocateRate)
+	}
+	return message
+}
+
+// SendDrainBudget sends rolling drain budget status.
+func (s *SlackNotifier) SendDrainBudget(ctx context.Context, status types.DrainBudgetStatus) error {
+	if s.webhookURL == "" {
+		return nil
+	}
+	return s.sendSlackMessage(ctx, formatDrainBudgetMessage(s.clusterName, status))
+}
+
+func formatDrainBudgetMessage(clusterName string, status types.DrainBudgetStatus) string {
+	message := fmt.Sprintf("🧮 %s Nodepool(%s) 의 드레인 예산\n\n", clusterName, status.NodepoolName)
+	message += fmt.Sprintf("• MaxNodes: %d (최근 %s)\n", status.MaxNodes, status.Window)
+	message += fmt.Sprintf("• DrainedInWindow: %d\n", status.DrainedInWindow)
+	message += fmt.Sprintf("• Remaining: %d\n", status.Remaining)
+	return message
+}
+
+// SendDrainSafetyBlocked sends the named safety check that blocked the drain.
+func (s *SlackNotifier) SendDrainSafetyBlocked(ctx context.Context, result types.SafetyCheckResult) error {
+	if s.webhookURL == "" {
+		return nil
+	}
+	message := fmt.Sprintf("⛔ %s Nodepool(%s) 드레인이 안전 조건에 의해 중단되었습니다\n\n", s.clusterName, s.nodepoolName)
+	message += fmt.Sprintf("• SafetyCheck: %s\n", formatSafetyCheckResult(result))
+	message += fmt.Sprintf("• Query: %s\n", result.Query)
+	return s.sendSlackMessage(ctx, message)
+}
+
+// SendDrainCanaryFailed sends the canary soak failure that stopped the drain.
+func (s *SlackNotifier) SendDrainCanaryFailed(ctx context.Context, result types.DrainCanaryResult) error {
+	if s.webhookURL == "" {
+		return nil
+	}
+	message := fmt.Sprintf("🐤 %s Nodepool(%s) canary 노드 점검 실패로 드레인이 중단되었습니다\n\n", s.clusterName, s.nodepoolName)
+	message += fmt.Sprintf("• CanaryNode: %s\n", result.NodeName)
+	message += fmt.Sprintf("• Soak: %s\n", result.Soak)
+	message += fmt.Sprintf("• Reason: %s\n", result.Reason)
+	if result.Check != nil {
+		message += fmt.Sprintf("• SafetyCheck: %s\n", formatSafetyCheckResult(*result.Check))
+	}
+	return s.sendSlackMessage(ctx, message)
+}
+
+// formatSafetyCheckResult는 "이름 (observed 비교 threshold)" 형식의 안전 조건 요약입니다.
+func formatSafetyCheckResult(result types.SafetyCheckResult) string {
+	if result.Error != "" {
+		return fmt.Sprintf("%s (query failed: %s)", result.Name, result.Error)
+	}
+	return fmt.Sprintf("%s (observed %s %s %s)", result.Name, strconv.FormatFloat(result.Observed, 'f', -1, 64), result.Comparator, strconv.FormatFloat(result.Threshold, 'f', -1, 64))
+}
+
 func (s *SlackNotifier) formatNodeDrainMessage(results []types.NodeDrainResult) string {
 	if len(results) == 0 {
 		return fmt.Sprintf("ℹ️ 드레인 대상 노드가 없습니다. (클러스터: %s, Nodepool: %s)", s.clusterName, s.nodepoolName)
@@ -169,6 +236,9 @@ func (s *SlackNotifier) formatNodeDrainMessage(results []types.NodeDrainResult)
 			result.DurationSeconds,
 			status,
 		)
+		if result.UnhealthyCondition != "" {
+			message += fmt.Sprintf("  비정상 condition: %s\n", result.UnhealthyCondition)
+		}
 		if result.FailureReason != "" {
 			message += fmt.Sprintf("  실패 사유: %s\n", result.FailureReason)
 		}
@@ -193,6 +263,15 @@ func formatNodeDrainSummaryBlock(summary types.NodeDrainSummary) string {
 	if summary.StoppedBySafety {
 		message += fmt.Sprintf("• StoppedBySafety: true (%s)\n", summary.StopSafetyReason)
 	}
+	if summary.StopSafetyCheck != nil {
+		message += fmt.Sprintf("• StopSafetyCheck: %s\n", formatSafetyCheckResult(*summary.StopSafetyCheck))
+	}
+	if summary.StoppedByCircuitBreaker != "" {
+		message += fmt.Sprintf("• StoppedByCircuitBreaker: %s (%s)\n", summary.StoppedByCircuitBreaker, summary.CircuitBreakerReason)
+	}
+	if summary.StoppedByCanary != "" {
+		message += fmt.Sprintf("• StoppedByCanary: %s (%s)\n", summary.StoppedByCanary, summary.CanaryFailureReason)
+	}
 	if len(summary.TopErrorReasons) > 0 {
 		message += fmt.Sprintf("• TopErrorReasons: %s\n", strings.Join(summary.TopErrorReasons, ", "))
 	}
@@ -264,6 +343,21 @@ func SendNodeDrainErrorWithSummary(err error, summary types.NodeDrainSummary) er
 	return NewEnvSlackNotifier().SendNodeDrainErrorWithSummary(context.Background(), err, summary)
 }
 
+// SendDrainBudget sends rolling drain budget status using environment based notifier.
+func SendDrainBudget(status types.DrainBudgetStatus) error {
+	return NewEnvSlackNotifier().SendDrainBudget(context.Background(), status)
+}
+
+// SendDrainSafetyBlocked sends the blocking safety check using environment based notifier.
+func SendDrainSafetyBlocked(result types.SafetyCheckResult) error {
+	return NewEnvSlackNotifier().SendDrainSafetyBlocked(context.Background(), result)
+}
+
+// SendDrainCanaryFailed sends the canary soak failure using environment based notifier.
+func SendDrainCanaryFailed(result types.DrainCanaryResult) error {
+	return NewEnvSlackNotifier().SendDrainCanaryFailed(context.Background(), result)
+}
+
 // SendNodeCount sends node count using environment based notifier.
 func SendNodeCount(nodeCount int) error {
 	return NewEnvSlackNotifier().SendNodeCount(context.Background(), nodeCount)
@@ -148,3 +148,146 @@ func TestSlackContextTimeout(t *testing.T) {
 		t.Fatal("expected timeout error")
 	}
 }
+
+func TestSendDrainBudget(t *testing.T) {
+	var body string
+	notifier := NewSlackNotifier(SlackConfig{
+		WebhookURL:   "https://example.com/webhook",
+		ClusterName:  "test-cluster",
+		NodepoolName: "test-pool",
+		HTTPClient: &http.Client{
+			Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				raw, _ := io.ReadAll(req.Body)
+				body = string(raw)
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader("ok")),
+					Header:     make(http.Header),
+				}, nil
+			}),
+		},
+	})
+
+	err := notifier.SendDrainBudget(context.Background(), types.DrainBudgetStatus{
+		NodepoolName:    "test-pool",
+		MaxNodes:        10,
+		Window:          "24h0m0s",
+		DrainedInWindow: 7,
+		Remaining:       3,
+	})
+	if err != nil {
+		t.Fatalf("SendDrainBudget failed: %v", err)
+	}
+	if !strings.Contains(body, "Remaining: 3") || !strings.Contains(body, "DrainedInWindow: 7") {
+		t.Fatalf("unexpected message: %s", body)
+	}
+}
+
+func TestFormatAllocateRatesMessage(t *testing.T) {
+	message := formatAllocateRatesMessage("test-cluster", "gpu-pool", []types.ResourceAllocateRate{
+		{Resource: "memory", AllocateRate: 40},
+		{Resource: "nvidia.com/gpu", AllocateRate: 92},
+	})
+	if !strings.Contains(message, "memory AllocateRate: 40%") || !strings.Contains(message, "nvidia.com/gpu AllocateRate: 92%") {
+		t.Fatalf("unexpected message: %s", message)
+	}
+}
+
+func TestSendDrainSafetyBlocked(t *testing.T) {
+	var body string
+	notifier := NewSlackNotifier(SlackConfig{
+		WebhookURL:   "https://example.com/webhook",
+		ClusterName:  "test-cluster",
+		NodepoolName: "test-pool",
+		HTTPClient: &http.Client{
+			Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				raw, _ := io.ReadAll(req.Body)
+				body = string(raw)
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader("ok")),
+					Header:     make(http.Header),
+				}, nil
+			}),
+		},
+	})
+
+	err := notifier.SendDrainSafetyBlocked(context.Background(), types.SafetyCheckResult{
+		Name:       "pending-pods",
+		Query:      "sum(kube_pod_status_phase)",
+		Comparator: ">=",
+		Threshold:  3,
+		Observed:   7,
+		Blocked:    true,
+	})
+	if err != nil {
+		t.Fatalf("SendDrainSafetyBlocked failed: %v", err)
+	}
+	if !strings.Contains(body, "SafetyCheck: pending-pods (observed 7 ") || !strings.Contains(body, "= 3)") {
+		t.Fatalf("unexpected message: %s", body)
+	}
+}
+
+func TestSendDrainCanaryFailed(t *testing.T) {
+	var body string
+	notifier := NewSlackNotifier(SlackConfig{
+		WebhookURL:   "https://example.com/webhook",
+		ClusterName:  "test-cluster",
+		NodepoolName: "test-pool",
+		HTTPClient: &http.Client{
+			Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				raw, _ := io.ReadAll(req.Body)
+				body = string(raw)
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader("ok")),
+					Header:     make(http.Header),
+				}, nil
+			}),
+		},
+	})
+
+	err := notifier.SendDrainCanaryFailed(context.Background(), types.DrainCanaryResult{
+		NodeName: "node-1",
+		Soak:     "5m0s",
+		Reason:   "workloads not recovered",
+	})
+	if err != nil {
+		t.Fatalf("SendDrainCanaryFailed failed: %v", err)
+	}
+	if !strings.Contains(body, "CanaryNode: node-1") || !strings.Contains(body, "Reason: workloads not recovered") {
+		t.Fatalf("unexpected message: %s", body)
+	}
+}
+
+func TestFormatNodeDrainSummaryBlockCircuitBreaker(t *testing.T) {
+	message := formatNodeDrainSummaryBlock(types.NodeDrainSummary{
+		TargetNodepool:          "test-pool",
+		StoppedByCircuitBreaker: "force-deletes",
+		CircuitBreakerReason:    "force deletes 6 > 5",
+	})
+	if !strings.Contains(message, "StoppedByCircuitBreaker: force-deletes (force deletes 6 > 5)") {
+		t.Fatalf("unexpected message: %s", message)
+	}
+
+	message = formatNodeDrainSummaryBlock(types.NodeDrainSummary{TargetNodepool: "test-pool"})
+	if strings.Contains(message, "StoppedByCircuitBreaker") {
+		t.Fatalf("unexpected message: %s", message)
+	}
+}
+
+func TestFormatNodeDrainSummaryBlockCanary(t *testing.T) {
+	message := formatNodeDrainSummaryBlock(types.NodeDrainSummary{
+		TargetNodepool:      "test-pool",
+		StoppedByCanary:     "node-1",
+		CanaryFailureReason: "workloads not recovered",
+	})
+	if !strings.Contains(message, "StoppedByCanary: node-1 (workloads not recovered)") {
+		t.Fatalf("unexpected message: %s", message)
+	}
+
+	message = formatNodeDrainSummaryBlock(types.NodeDrainSummary{TargetNodepool: "test-pool"})
+	if strings.Contains(message, "StoppedByCanary") {
+		t.Fatalf("unexpected message: %s", message)
+	}
+}
@@ -25,6 +25,12 @@ func GetEvictionConfigFromEnv() *EvictionConfig {
 		}
 	}
 
+	if v := strings.TrimSpace(os.Getenv("POD_FORCE_ON_PDB_BLOCK")); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.ForceOnPDBBlock = b
+		}
+	}
+
 	if v := strings.TrimSpace(os.Getenv("POD_FORCE_PROBLEM_PODS")); v != "" {
 		if b, err := strconv.ParseBool(v); err == nil {
 			cfg.ForceProblemPods = b
@@ -73,6 +79,22 @@ func GetEvictionConfigFromEnv() *EvictionConfig {
 		}
 	}
 
+	if v := strings.TrimSpace(os.Getenv("POD_GRACE_PERIOD_MIN")); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.GracePeriodMin = d
+		}
+	}
+
+	if v := strings.TrimSpace(os.Getenv("POD_GRACE_PERIOD_MAX")); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.GracePeriodMax = d
+		}
+	}
+
+	if v := strings.TrimSpace(os.Getenv("POD_GRACE_PERIOD_NAMESPACES")); v != "" {
+		cfg.GracePeriodNamespaces = parseGracePeriodNamespaces(v)
+	}
+
 	// 안전 클램프
 	if cfg.MaxConcurrentEvictions <= 0 {
 		cfg.MaxConcurrentEvictions = 1
@@ -83,6 +105,12 @@ func GetEvictionConfigFromEnv() *EvictionConfig {
 	if cfg.PDBTokenMaxInFlight <= 0 {
 		cfg.PDBTokenMaxInFlight = 1
 	}
+	if cfg.GracePeriodMin < 0 {
+		cfg.GracePeriodMin = 0
+	}
+	if cfg.GracePeriodMax < 0 {
+		cfg.GracePeriodMax = 0
+	}
 
 	return cfg
 }
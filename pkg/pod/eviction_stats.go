@@ -0,0 +1,147 @@
+package pod
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// EvictionStats는 한 번의 실행(여러 노드)에 걸친 eviction 결과 집계입니다.
+// 여러 노드를 동시에 드레인해도 안전하며, nil이면 집계하지 않습니다.
+type EvictionStats struct {
+	attempted        atomic.Int64
+	failed           atomic.Int64
+	forceDeleted     atomic.Int64
+	pdbBlocked       atomic.Int64
+	evicted          atomic.Int64
+	deleted          atomic.Int64
+	forcedByFallback atomic.Int64
+	problemForced    atomic.Int64
+
+	pdbBlockedPods sync.Map // namespace/name -> struct{}, PDB 차단된 파드 (재시도와 무관하게 한 번만 셈)
+}
+
+// Attempted는 제거를 시도한 파드 수입니다.
+func (s *EvictionStats) Attempted() int64 {
+	if s == nil {
+		return 0
+	}
+	return s.attempted.Load()
+}
+
+// Failed는 재시도 후에도 제거에 실패한 파드 수입니다.
+func (s *EvictionStats) Failed() int64 {
+	if s == nil {
+		return 0
+	}
+	return s.failed.Load()
+}
+
+// ForceDeleted는 grace period 0으로 강제 삭제한 파드 수입니다. (문제 상태 파드, --pod-force 폴백)
+func (s *EvictionStats) ForceDeleted() int64 {
+	if s == nil {
+		return 0
+	}
+	return s.forceDeleted.Load()
+}
+
+// PDBBlocked는 PDB 차단(429 또는 사전 PDB 확인)으로 재시도한 횟수입니다. 실패(Failed)와 따로 집계하며 circuit breaker가 사용합니다.
+func (s *EvictionStats) PDBBlocked() int64 {
+	if s == nil {
+		return 0
+	}
+	return s.pdbBlocked.Load()
+}
+
+// PDBBlockedPods는 한 번 이상 PDB에 차단된 파드 수입니다. (재시도 횟수는 PDBBlocked)
+func (s *EvictionStats) PDBBlockedPods() int64 {
+	if s == nil {
+		return 0
+	}
+	count := int64(0)
+	s.pdbBlockedPods.Range(func(key, value any) bool {
+		count++
+		return true
+	})
+	return count
+}
+
+// Evicted는 eviction API로 제거를 마친 파드 수입니다.
+func (s *EvictionStats) Evicted() int64 {
+	if s == nil {
+		return 0
+	}
+	return s.evicted.Load()
+}
+
+// Deleted는 delete 모드 또는 eviction API 미지원 fallback으로 삭제를 마친 파드 수입니다.
+func (s *EvictionStats) Deleted() int64 {
+	if s == nil {
+		return 0
+	}
+	return s.deleted.Load()
+}
+
+// ForcedByFallback는 eviction 실패 후 강제 삭제(--pod-force)로 제거한 파드 수입니다.
+func (s *EvictionStats) ForcedByFallback() int64 {
+	if s == nil {
+		return 0
+	}
+	return s.forcedByFallback.Load()
+}
+
+// ProblemForced는 문제 상태라 eviction 없이 바로 강제 삭제한 파드 수입니다.
+func (s *EvictionStats) ProblemForced() int64 {
+	if s == nil {
+		return 0
+	}
+	return s.problemForced.Load()
+}
+
+func (s *EvictionStats) recordAttempt() {
+	if s != nil {
+		s.attempted.Add(1)
+	}
+}
+
+func (s *EvictionStats) recordFailure() {
+	if s != nil {
+		s.failed.Add(1)
+	}
+}
+
+func (s *EvictionStats) recordForceDelete() {
+	if s != nil {
+		s.forceDeleted.Add(1)
+	}
+}
+
+func (s *EvictionStats) recordPDBBlocked(namespace string, name string) {
+	if s != nil {
+		s.pdbBlocked.Add(1)
+		s.pdbBlockedPods.Store(namespace+"/"+name, struct{}{})
+	}
+}
+
+func (s *EvictionStats) recordRemoval(removal podRemoval) {
+	if s == nil {
+		return
+	}
+	switch removal {
+	case podRemovalEvicted:
+		s.evicted.Add(1)
+	case podRemovalDeleted:
+		s.deleted.Add(1)
+	}
+}
+
+func (s *EvictionStats) recordForcedByFallback() {
+	if s != nil {
+		s.forcedByFallback.Add(1)
+	}
+}
+
+func (s *EvictionStats) recordProblemForced() {
+	if s != nil {
+		s.problemForced.Add(1)
+	}
+}
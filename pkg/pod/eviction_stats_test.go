@@ -0,0 +1,54 @@
+package pod
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestEvictionStats(t *testing.T) {
+	var nilStats *EvictionStats
+	assert.Equal(t, int64(0), nilStats.Attempted())
+	nilStats.recordAttempt()
+
+	client := fake.NewSimpleClientset(
+		&coreV1.Pod{ObjectMeta: metaV1.ObjectMeta{Name: "ok", Namespace: "default"}, Spec: coreV1.PodSpec{NodeName: "node-1"}},
+		&coreV1.Pod{ObjectMeta: metaV1.ObjectMeta{Name: "broken", Namespace: "default"}, Spec: coreV1.PodSpec{NodeName: "node-1"}},
+		&coreV1.Pod{
+			ObjectMeta: metaV1.ObjectMeta{Name: "crashloop", Namespace: "default"},
+			Spec:       coreV1.PodSpec{NodeName: "node-1"},
+			Status: coreV1.PodStatus{ContainerStatuses: []coreV1.ContainerStatus{{
+				State: coreV1.ContainerState{Waiting: &coreV1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+			}}},
+		},
+	)
+	client.PrependReactor("delete", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.(k8stesting.DeleteAction).GetName() == "broken" {
+			return true, nil, errors.New("delete failed")
+		}
+		return false, nil, nil
+	})
+
+	cfg := DefaultEvictionConfig()
+	cfg.EvictionMode = EvictionModeDelete
+	cfg.MaxRetries = 1
+	cfg.PDBToken = false
+	cfg.Stats = &EvictionStats{}
+
+	err := EvictPods(context.Background(), client, "node-1", cfg)
+	assert.Error(t, err)
+	assert.Equal(t, int64(3), cfg.Stats.Attempted())
+	assert.Equal(t, int64(1), cfg.Stats.Failed())
+	assert.Equal(t, int64(1), cfg.Stats.ForceDeleted())
+	assert.Equal(t, int64(1), cfg.Stats.Deleted())
+	assert.Equal(t, int64(0), cfg.Stats.Evicted())
+	assert.Equal(t, int64(1), cfg.Stats.ProblemForced())
+	assert.Equal(t, int64(0), cfg.Stats.ForcedByFallback())
+}
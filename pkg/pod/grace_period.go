@@ -0,0 +1,116 @@
+package pod
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	coreV1 "k8s.io/api/core/v1"
+)
+
+const (
+	// GracePeriodAnnotation은 파드별 grace period override annotation입니다. 값은 duration("5m") 또는 초("300")입니다.
+	GracePeriodAnnotation = "node-drain/grace-period"
+
+	// defaultTerminationGracePeriod는 파드 spec에 값이 없을 때 쿠버네티스가 쓰는 기본값입니다.
+	defaultTerminationGracePeriod = 30 * time.Second
+	// podDeletionTimeoutBaseGrace는 PodDeletionTimeout이 기준으로 삼는 grace period(기존 고정값)입니다.
+	podDeletionTimeoutBaseGrace = 60 * time.Second
+)
+
+// effectiveGracePeriod는 파드에 적용할 grace period를 정합니다.
+// 우선순위는 annotation > namespace override > 파드 spec(terminationGracePeriodSeconds)입니다.
+// GracePeriodMax 상한은 override를 포함한 모든 값에 적용하고, GracePeriodMin 하한은 spec 값에만 적용합니다. (override로 짧게 줄이는 것은 허용)
+func effectiveGracePeriod(pod coreV1.Pod, cfg *EvictionConfig) time.Duration {
+	if v, ok := pod.Annotations[GracePeriodAnnotation]; ok {
+		if d, err := parseGracePeriod(v); err == nil {
+			return clampGracePeriodMax(d, cfg)
+		}
+		slog.Warn("grace period annotation 파싱 실패(무시)", "pod", pod.Name, "value", v)
+	}
+	if d, ok := cfg.GracePeriodNamespaces[pod.Namespace]; ok {
+		return clampGracePeriodMax(d, cfg)
+	}
+
+	grace := defaultTerminationGracePeriod
+	if pod.Spec.TerminationGracePeriodSeconds != nil {
+		grace = time.Duration(*pod.Spec.TerminationGracePeriodSeconds) * time.Second
+	}
+	if cfg.GracePeriodMin > 0 && grace < cfg.GracePeriodMin {
+		grace = cfg.GracePeriodMin
+	}
+	return clampGracePeriodMax(grace, cfg)
+}
+
+func clampGracePeriodMax(grace time.Duration, cfg *EvictionConfig) time.Duration {
+	if cfg.GracePeriodMax > 0 && grace > cfg.GracePeriodMax {
+		return cfg.GracePeriodMax
+	}
+	return grace
+}
+
+// podDeletionTimeout은 삭제 대기 타임아웃을 grace period에 맞춰 늘립니다.
+// PodDeletionTimeout은 grace period 60초 기준 값이므로, 그보다 긴 grace period는 초과분만큼 더 기다립니다.
+func podDeletionTimeout(pod coreV1.Pod, cfg *EvictionConfig) time.Duration {
+	timeout := cfg.PodDeletionTimeout
+	if extra := effectiveGracePeriod(pod, cfg) - podDeletionTimeoutBaseGrace; extra > 0 {
+		timeout += extra
+	}
+	return timeout
+}
+
+// MaxGracePeriod는 pods 중 가장 긴 grace period를 반환합니다.
+// 노드 단위 타임아웃(EvictionTimeout, NodeTerminationTimeout)을 이만큼 늘려, grace period가 긴 파드가 정상 종료하는 도중에 끊기지 않게 합니다.
+func MaxGracePeriod(pods []coreV1.Pod, cfg *EvictionConfig) time.Duration {
+	cfg = normalizeEvictionConfig(cfg)
+	longest := time.Duration(0)
+	for _, p := range pods {
+		if grace := effectiveGracePeriod(p, cfg); grace > longest {
+			longest = grace
+		}
+	}
+	return longest
+}
+
+func parseGracePeriod(v string) (time.Duration, error) {
+	v = strings.TrimSpace(v)
+	d, err := time.ParseDuration(v)
+	if seconds, intErr := strconv.ParseInt(v, 10, 64); intErr == nil {
+		d, err = time.Duration(seconds)*time.Second, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("음수 grace period: %s", v)
+	}
+	return d, nil
+}
+
+// parseGracePeriodNamespaces는 "ns=5m,ns2=30s" 형식의 namespace별 grace period를 파싱합니다. 잘못된 항목은 무시합니다.
+func parseGracePeriodNamespaces(v string) map[string]time.Duration {
+	result := map[string]time.Duration{}
+	for _, item := range strings.Split(v, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		ns, value, ok := strings.Cut(item, "=")
+		if !ok {
+			slog.Warn("namespace grace period 항목 무시", "item", item)
+			continue
+		}
+		d, err := parseGracePeriod(value)
+		if err != nil {
+			slog.Warn("namespace grace period 항목 무시", "item", item, "error", err)
+			continue
+		}
+		result[strings.TrimSpace(ns)] = d
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
@@ -0,0 +1,140 @@
+package pod
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func gracePod(namespace string, graceSeconds *int64, annotations map[string]string) coreV1.Pod {
+	return coreV1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{Name: "api-0", Namespace: namespace, Annotations: annotations},
+		Spec:       coreV1.PodSpec{TerminationGracePeriodSeconds: graceSeconds},
+		Status:     coreV1.PodStatus{Phase: coreV1.PodRunning},
+	}
+}
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+func TestEffectiveGracePeriod(t *testing.T) {
+	bounded := &EvictionConfig{
+		GracePeriodMin:        10 * time.Second,
+		GracePeriodMax:        2 * time.Minute,
+		GracePeriodNamespaces: map[string]time.Duration{"batch": 15 * time.Minute, "ingress": 90 * time.Second},
+	}
+
+	tests := []struct {
+		name     string
+		pod      coreV1.Pod
+		cfg      *EvictionConfig
+		expected time.Duration
+	}{
+		{name: "spec 값 사용", pod: gracePod("default", int64Ptr(300), nil), cfg: &EvictionConfig{}, expected: 5 * time.Minute},
+		{name: "spec 없으면 쿠버네티스 기본값", pod: gracePod("default", nil, nil), cfg: &EvictionConfig{}, expected: 30 * time.Second},
+		{name: "하한 적용", pod: gracePod("default", int64Ptr(5), nil), cfg: bounded, expected: 10 * time.Second},
+		{name: "상한 적용", pod: gracePod("default", int64Ptr(300), nil), cfg: bounded, expected: 2 * time.Minute},
+		{name: "namespace override도 상한 적용", pod: gracePod("batch", int64Ptr(5), nil), cfg: bounded, expected: 2 * time.Minute},
+		{name: "namespace override", pod: gracePod("ingress", int64Ptr(5), nil), cfg: bounded, expected: 90 * time.Second},
+		{name: "상한이 없으면 namespace override 그대로", pod: gracePod("batch", int64Ptr(5), nil), cfg: &EvictionConfig{GracePeriodNamespaces: bounded.GracePeriodNamespaces}, expected: 15 * time.Minute},
+		{name: "annotation override 우선", pod: gracePod("batch", nil, map[string]string{GracePeriodAnnotation: "45"}), cfg: bounded, expected: 45 * time.Second},
+		{name: "annotation override는 하한 미적용", pod: gracePod("default", nil, map[string]string{GracePeriodAnnotation: "3"}), cfg: bounded, expected: 3 * time.Second},
+		{name: "annotation override도 상한 적용", pod: gracePod("default", nil, map[string]string{GracePeriodAnnotation: "5m"}), cfg: bounded, expected: 2 * time.Minute},
+		{name: "annotation duration 형식", pod: gracePod("default", nil, map[string]string{GracePeriodAnnotation: "5m"}), cfg: &EvictionConfig{}, expected: 5 * time.Minute},
+		{name: "잘못된 annotation은 무시", pod: gracePod("default", int64Ptr(60), map[string]string{GracePeriodAnnotation: "-1"}), cfg: bounded, expected: time.Minute},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, effectiveGracePeriod(tt.pod, tt.cfg))
+		})
+	}
+}
+
+func TestPodDeletionTimeoutScalesWithGracePeriod(t *testing.T) {
+	cfg := &EvictionConfig{PodDeletionTimeout: 2 * time.Minute}
+	assert.Equal(t, 2*time.Minute, podDeletionTimeout(gracePod("default", int64Ptr(5), nil), cfg))
+	assert.Equal(t, 2*time.Minute, podDeletionTimeout(gracePod("default", nil, nil), cfg))
+	assert.Equal(t, 6*time.Minute, podDeletionTimeout(gracePod("default", int64Ptr(300), nil), cfg))
+}
+
+func TestParseGracePeriodNamespaces(t *testing.T) {
+	assert.Equal(t, map[string]time.Duration{
+		"batch":   10 * time.Minute,
+		"ingress": 90 * time.Second,
+	}, parseGracePeriodNamespaces(" batch=10m, ingress=90 ,broken, bad=-1s"))
+	assert.Nil(t, parseGracePeriodNamespaces(""))
+}
+
+func TestEvictPodUsesEffectiveGracePeriod(t *testing.T) {
+	p := gracePod("default", int64Ptr(300), nil)
+	client := fake.NewSimpleClientset(&p)
+
+	cfg := DefaultEvictionConfig()
+	cfg.EvictionMode = EvictionModeDelete
+	cfg.GracePeriodMax = 3 * time.Minute
+	_, err := evictPod(context.Background(), client, p, cfg)
+	assert.NoError(t, err)
+
+	var grace *int64
+	for _, action := range client.Actions() {
+		if del, ok := action.(k8stesting.DeleteAction); ok {
+			grace = del.GetDeleteOptions().GracePeriodSeconds
+		}
+	}
+	if assert.NotNil(t, grace) {
+		assert.Equal(t, int64(180), *grace)
+	}
+}
+
+func TestMaxGracePeriod(t *testing.T) {
+	cfg := &EvictionConfig{GracePeriodMax: 2 * time.Minute}
+	assert.Equal(t, time.Duration(0), MaxGracePeriod(nil, cfg))
+	assert.Equal(t, 2*time.Minute, MaxGracePeriod([]coreV1.Pod{
+		gracePod("default", int64Ptr(5), nil),
+		gracePod("default", int64Ptr(600), nil),
+	}, cfg))
+	assert.Equal(t, 2*time.Minute, MaxGracePeriod([]coreV1.Pod{
+		gracePod("default", nil, map[string]string{GracePeriodAnnotation: "10m"}),
+	}, cfg))
+	assert.Equal(t, 10*time.Minute, MaxGracePeriod([]coreV1.Pod{
+		gracePod("default", nil, map[string]string{GracePeriodAnnotation: "10m"}),
+	}, &EvictionConfig{}))
+}
+
+func TestEvictPodsExtendsEvictionTimeoutByGracePeriod(t *testing.T) {
+	p := gracePod("default", int64Ptr(2), nil)
+	p.Spec.NodeName = "node-1"
+	client := fake.NewSimpleClientset(&p)
+	// eviction 후 파드가 grace period 동안 종료 중이다가 EvictionTimeout이 지난 뒤 사라짐
+	client.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "eviction" {
+			return false, nil, nil
+		}
+		go func() {
+			time.Sleep(200 * time.Millisecond)
+			_ = client.Tracker().Delete(coreV1.SchemeGroupVersion.WithResource("pods"), p.Namespace, p.Name)
+		}()
+		return true, nil, nil
+	})
+	client.PrependReactor("delete", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, nil
+	})
+
+	cfg := DefaultEvictionConfig()
+	cfg.EvictionTimeout = 20 * time.Millisecond
+	cfg.RetryBackoffDuration = time.Millisecond
+	cfg.CheckInterval = 10 * time.Millisecond
+	cfg.PodDeletionTimeout = time.Second
+	cfg.PDBToken = false
+	assert.NoError(t, EvictPods(context.Background(), client, "node-1", cfg))
+}
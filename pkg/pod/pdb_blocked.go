@@ -0,0 +1,34 @@
+package pod
+
+import (
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// PDBBlockedError는 PDB가 disruption을 허용하지 않아 eviction이 거부된 경우입니다.
+// eviction API의 429(TooManyRequests) 응답과 사전 PDB 확인 실패가 여기에 해당하며, 실제 실패와 따로 집계합니다.
+type PDBBlockedError struct {
+	RetryAfter time.Duration // 0이면 RetryBackoffDuration 후 재시도
+	Err        error
+}
+
+func (e *PDBBlockedError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *PDBBlockedError) Unwrap() error {
+	return e.Err
+}
+
+// classifyEvictionError는 eviction API의 429 응답을 PDBBlockedError로 바꾸고 Retry-After를 담습니다.
+func classifyEvictionError(err error) error {
+	if !apierrors.IsTooManyRequests(err) {
+		return err
+	}
+	blocked := &PDBBlockedError{Err: err}
+	if seconds, ok := apierrors.SuggestsClientDelay(err); ok && seconds > 0 {
+		blocked.RetryAfter = time.Duration(seconds) * time.Second
+	}
+	return blocked
+}
@@ -0,0 +1,185 @@
+package pod
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	coreV1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	policyv1listers "k8s.io/client-go/listers/policy/v1"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// rejectEvictions는 처음 n번의 eviction 요청을 429로 거부합니다. n이 음수면 항상 거부합니다.
+func rejectEvictions(client *fake.Clientset, n int, retryAfterSeconds int) *int {
+	calls := 0
+	client.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "eviction" {
+			return false, nil, nil
+		}
+		calls++
+		if n < 0 || calls <= n {
+			return true, nil, apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", retryAfterSeconds)
+		}
+		return true, nil, nil
+	})
+	return &calls
+}
+
+func countPodDeletes(client *fake.Clientset) int {
+	count := 0
+	for _, action := range client.Actions() {
+		if action.GetVerb() == "delete" && action.GetResource().Resource == "pods" {
+			count++
+		}
+	}
+	return count
+}
+
+func TestClassifyEvictionError(t *testing.T) {
+	var blocked *PDBBlockedError
+	err := classifyEvictionError(apierrors.NewTooManyRequests("pdb", 7))
+	if assert.True(t, errors.As(err, &blocked)) {
+		assert.Equal(t, 7*time.Second, blocked.RetryAfter)
+	}
+
+	err = classifyEvictionError(apierrors.NewTooManyRequests("pdb", 0))
+	if assert.True(t, errors.As(err, &blocked)) {
+		assert.Equal(t, time.Duration(0), blocked.RetryAfter)
+	}
+
+	other := errors.New("internal error")
+	assert.Equal(t, other, classifyEvictionError(other))
+}
+
+func TestEvictPodWithRetryPDBBlocked(t *testing.T) {
+	tests := []struct {
+		name            string
+		forceOnPDBBlock bool
+		expectErr       bool
+		expectedDeletes int
+	}{
+		{name: "--force여도 PDB 차단은 강제 삭제하지 않음", expectErr: true, expectedDeletes: 0},
+		{name: "--force-on-pdb-block이면 마지막 재시도에서 강제 삭제", forceOnPDBBlock: true, expectedDeletes: 1},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			resetPDBCacheForTest()
+			target := nodePod("api-0", "node-1", "a")
+			client := fake.NewSimpleClientset(target)
+			calls := rejectEvictions(client, -1, 0)
+
+			stats := &EvictionStats{}
+			cfg := &EvictionConfig{
+				MaxRetries:           3,
+				RetryBackoffDuration: time.Millisecond,
+				PodDeletionTimeout:   time.Second,
+				CheckInterval:        10 * time.Millisecond,
+				EvictionMode:         EvictionModeEvict,
+				Force:                true,
+				ForceOnPDBBlock:      tt.forceOnPDBBlock,
+				Stats:                stats,
+			}
+			err := evictPodWithRetry(context.Background(), client, *target, cfg)
+			if tt.expectErr {
+				var blocked *PDBBlockedError
+				assert.True(t, errors.As(err, &blocked))
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, 3, *calls)
+			assert.Equal(t, int64(3), stats.PDBBlocked())
+			assert.Equal(t, int64(1), stats.PDBBlockedPods())
+			assert.Equal(t, tt.expectedDeletes, countPodDeletes(client))
+		})
+	}
+}
+
+func TestEvictPodWithRetryHonorsRetryAfter(t *testing.T) {
+	resetPDBCacheForTest()
+	target := nodePod("api-0", "node-1", "a")
+	client := fake.NewSimpleClientset(target)
+	calls := rejectEvictions(client, 1, 1)
+
+	cfg := &EvictionConfig{
+		MaxRetries:           2,
+		RetryBackoffDuration: time.Millisecond,
+		PodDeletionTimeout:   time.Second,
+		CheckInterval:        10 * time.Millisecond,
+		EvictionMode:         EvictionModeEvict,
+	}
+	start := time.Now()
+	assert.NoError(t, evictPodWithRetry(context.Background(), client, *target, cfg))
+	assert.GreaterOrEqual(t, time.Since(start), time.Second)
+	assert.Equal(t, 2, *calls)
+}
+
+func TestEvictPodsCountsPDBBlockedSeparately(t *testing.T) {
+	resetPDBCacheForTest()
+	client := fake.NewSimpleClientset(nodePod("api-0", "node-1", "a"), nodePod("api-1", "node-1", "b"))
+	rejectEvictions(client, -1, 0)
+
+	stats := &EvictionStats{}
+	cfg := DefaultEvictionConfig()
+	cfg.MaxRetries = 2
+	cfg.RetryBackoffDuration = time.Millisecond
+	cfg.ForceProblemPods = false
+	cfg.Stats = stats
+	assert.Error(t, EvictPods(context.Background(), client, "node-1", cfg))
+	assert.Equal(t, int64(2), stats.Attempted())
+	assert.Equal(t, int64(0), stats.Failed())
+	// 재시도 횟수와 차단된 파드 수를 따로 셈
+	assert.Equal(t, int64(4), stats.PDBBlocked())
+	assert.Equal(t, int64(2), stats.PDBBlockedPods())
+}
+
+// failingPDBLister는 항상 조회에 실패하는 PDB lister입니다.
+type failingPDBLister struct{}
+
+func (failingPDBLister) List(selector labels.Selector) ([]*policyv1.PodDisruptionBudget, error) {
+	return nil, errors.New("informer unavailable")
+}
+
+func (l failingPDBLister) PodDisruptionBudgets(namespace string) policyv1listers.PodDisruptionBudgetNamespaceLister {
+	return l
+}
+
+func (failingPDBLister) Get(name string) (*policyv1.PodDisruptionBudget, error) {
+	return nil, errors.New("informer unavailable")
+}
+
+func (failingPDBLister) GetPodPodDisruptionBudgets(pod *coreV1.Pod) ([]*policyv1.PodDisruptionBudget, error) {
+	return nil, errors.New("informer unavailable")
+}
+
+func TestEvictPodsPDBLookupFailureIsNotForced(t *testing.T) {
+	client := fake.NewSimpleClientset(nodePod("api-0", "node-1", "a"))
+
+	stats := &EvictionStats{}
+	cfg := DefaultEvictionConfig()
+	cfg.MaxRetries = 2
+	cfg.RetryBackoffDuration = time.Millisecond
+	cfg.ForceProblemPods = false
+	cfg.Force = true
+	cfg.ForceOnPDBBlock = true
+	cfg.PDBLister = failingPDBLister{}
+	cfg.Stats = stats
+
+	err := EvictPods(context.Background(), client, "node-1", cfg)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "PDB 조회 실패")
+	assert.Equal(t, 0, countPodDeletes(client))
+	assert.Equal(t, 0, countEvictionActions(client))
+	assert.Equal(t, int64(1), stats.Failed())
+	assert.Equal(t, int64(0), stats.PDBBlocked())
+	assert.Equal(t, int64(0), stats.ForceDeleted())
+}
@@ -0,0 +1,38 @@
+package pod
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	policyv1listers "k8s.io/client-go/listers/policy/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// StartPDBInformer는 PodDisruptionBudget informer를 시작하고 캐시가 동기화되면 lister를 반환합니다.
+// informer는 watch로 status(DisruptionsAllowed)를 바로 반영하며, ctx가 끝나면 멈춥니다.
+func StartPDBInformer(ctx context.Context, clientSet kubernetes.Interface, syncTimeout time.Duration) (policyv1listers.PodDisruptionBudgetLister, error) {
+	factory := informers.NewSharedInformerFactory(clientSet, 0)
+	pdbInformer := factory.Policy().V1().PodDisruptionBudgets()
+	lister := pdbInformer.Lister()
+	factory.Start(ctx.Done())
+
+	syncCtx, cancel := context.WithTimeout(ctx, syncTimeout)
+	defer cancel()
+	if !cache.WaitForCacheSync(syncCtx.Done(), pdbInformer.Informer().HasSynced) {
+		return nil, fmt.Errorf("PDB informer 캐시 동기화 실패: %w", syncCtx.Err())
+	}
+	return lister, nil
+}
+
+// listPDBs는 lister가 있으면 informer 캐시를, 없으면 namespace별 TTL 캐시(globalPDBCache)를 사용합니다.
+func listPDBs(ctx context.Context, clientSet kubernetes.Interface, lister policyv1listers.PodDisruptionBudgetLister, namespace string) ([]*policyv1.PodDisruptionBudget, error) {
+	if lister != nil {
+		return lister.PodDisruptionBudgets(namespace).List(labels.Everything())
+	}
+	return getPDBsWithCache(ctx, clientSet, namespace)
+}
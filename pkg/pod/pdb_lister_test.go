@@ -0,0 +1,59 @@
+package pod
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestStartPDBInformerReflectsLiveStatus(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pdb := newTestPDB("default", "api-pdb")
+	pdb.Status.DisruptionsAllowed = 0
+	client := fake.NewSimpleClientset(pdb)
+
+	lister, err := StartPDBInformer(ctx, client, 5*time.Second)
+	if err != nil {
+		t.Fatalf("PDB informer 시작 실패: %v", err)
+	}
+
+	target := coreV1.Pod{ObjectMeta: metaV1.ObjectMeta{Name: "api-0", Namespace: "default", Labels: map[string]string{"app": "test"}}}
+	assert.Error(t, checkPDB(ctx, client, lister, target))
+	keys, err := getMatchingPDBKeys(ctx, client, lister, &target)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"default/api-pdb"}, keys)
+
+	// status 변경이 TTL 없이 바로 반영됩니다.
+	updated := pdb.DeepCopy()
+	updated.Status.DisruptionsAllowed = 1
+	if _, err := client.PolicyV1().PodDisruptionBudgets("default").UpdateStatus(ctx, updated, metaV1.UpdateOptions{}); err != nil {
+		t.Fatalf("PDB status 갱신 실패: %v", err)
+	}
+	assert.Eventually(t, func() bool {
+		return checkPDB(ctx, client, lister, target) == nil
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestStartPDBInformerSyncTimeout(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("list", "poddisruptionbudgets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("forbidden")
+	})
+
+	lister, err := StartPDBInformer(ctx, client, 100*time.Millisecond)
+	assert.Error(t, err)
+	assert.Nil(t, lister)
+}
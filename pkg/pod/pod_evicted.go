@@ -2,6 +2,7 @@ package pod
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"strings"
@@ -14,6 +15,7 @@ import (
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes"
+	policyv1listers "k8s.io/client-go/listers/policy/v1"
 )
 
 type EvictionMode string
@@ -37,11 +39,28 @@ type EvictionConfig struct {
 	NodeTerminationCheckTick time.Duration
 	PostEvictionNodeDelay    time.Duration
 
+	// 파드 grace period는 기본적으로 파드 spec(terminationGracePeriodSeconds)을 따릅니다.
+	// GracePeriodMin은 spec 값의 하한, GracePeriodMax는 override를 포함한 상한(0이면 없음)이고, GracePeriodNamespaces는 namespace별 override입니다.
+	GracePeriodMin        time.Duration
+	GracePeriodMax        time.Duration
+	GracePeriodNamespaces map[string]time.Duration
+
 	EvictionMode        EvictionMode
 	Force               bool
+	ForceOnPDBBlock     bool // Force일 때 PDB 차단(429)도 마지막 재시도에서 강제 삭제할지 여부
 	ForceProblemPods    bool
 	PDBToken            bool
 	PDBTokenMaxInFlight int
+
+	// EvictionSemaphore를 지정하면 여러 노드를 동시에 드레인할 때 MaxConcurrentEvictions 제한을 공유합니다.
+	// nil이면 EvictPods 호출마다 새 세마포어를 만듭니다.
+	EvictionSemaphore chan struct{}
+
+	// Stats를 지정하면 실행 전체의 eviction 시도/실패/제거 방식별 수를 집계합니다. (circuit breaker 판단, 드레인 요약용)
+	Stats *EvictionStats
+
+	// PDBLister를 지정하면 PDB를 informer 캐시에서 조회합니다. nil이면 namespace별 TTL 캐시를 사용합니다.
+	PDBLister policyv1listers.PodDisruptionBudgetLister
 }
 
 type pdbCache struct {
@@ -87,12 +106,6 @@ func EvictPods(ctx context.Context, clientSet kubernetes.Interface, nodeName str
 		ctx = context.Background()
 	}
 
-	if cfg.EvictionTimeout > 0 {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(ctx, cfg.EvictionTimeout)
-		defer cancel()
-	}
-
 	slog.Info("노드에서 pod evict 시작", "nodeName", nodeName)
 
 	pods, err := GetNonCriticalPods(ctx, clientSet, nodeName)
@@ -100,6 +113,14 @@ func EvictPods(ctx context.Context, clientSet kubernetes.Interface, nodeName str
 		return fmt.Errorf("노드 %s 데몬셋 제외 파드 조회 실패: %w", nodeName, err)
 	}
 
+	// EvictionTimeout은 노드에서 가장 긴 grace period만큼 늘립니다.
+	if cfg.EvictionTimeout > 0 {
+		timeout := cfg.EvictionTimeout + MaxGracePeriod(pods, cfg)
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
 	var normalPods []coreV1.Pod
 	var problemPods []coreV1.Pod
 	for _, p := range pods {
@@ -111,7 +132,11 @@ func EvictPods(ctx context.Context, clientSet kubernetes.Interface, nodeName str
 		normalPods = append(normalPods, p)
 	}
 
-	semaphore := make(chan struct{}, cfg.MaxConcurrentEvictions)
+	// 공유 세마포어가 없거나 용량이 0이면(아무도 획득할 수 없음) 이 노드 전용 세마포어를 씁니다.
+	semaphore := cfg.EvictionSemaphore
+	if cap(semaphore) == 0 {
+		semaphore = make(chan struct{}, cfg.MaxConcurrentEvictions)
+	}
 	var wg sync.WaitGroup
 	errChan := make(chan error, len(normalPods))
 
@@ -128,7 +153,13 @@ func EvictPods(ctx context.Context, clientSet kubernetes.Interface, nodeName str
 			}
 			defer func() { <-semaphore }()
 
+			cfg.Stats.recordAttempt()
 			if evictErr := evictPodWithRetry(ctx, clientSet, p, cfg); evictErr != nil {
+				// PDB 차단은 PDBBlocked로 따로 집계하므로 실패로 세지 않습니다.
+				var blocked *PDBBlockedError
+				if ctx.Err() == nil && !errors.As(evictErr, &blocked) {
+					cfg.Stats.recordFailure()
+				}
 				errChan <- fmt.Errorf("파드 %s eviction 실패: %w", p.Name, evictErr)
 			}
 		}()
@@ -148,12 +179,17 @@ func EvictPods(ctx context.Context, clientSet kubernetes.Interface, nodeName str
 		slog.Info("문제 상태의 파드 강제 제거 시작", "nodeName", nodeName, "count", len(problemPods))
 		for _, p := range problemPods {
 			gracePeriod := int64(0)
+			cfg.Stats.recordAttempt()
+			cfg.Stats.recordForceDelete()
 			delErr := clientSet.CoreV1().Pods(p.Namespace).Delete(ctx, p.Name, metaV1.DeleteOptions{
 				GracePeriodSeconds: &gracePeriod,
 			})
 			if delErr != nil && !apierrors.IsNotFound(delErr) {
+				cfg.Stats.recordFailure()
 				errs = append(errs, fmt.Errorf("문제 파드 %s 강제 제거 실패: %w", p.Name, delErr))
+				continue
 			}
+			cfg.Stats.recordProblemForced()
 		}
 	}
 
@@ -214,7 +250,7 @@ func evictPodWithRetry(ctx context.Context, clientSet kubernetes.Interface, pod
 	if cfg.PDBToken {
 		podObj, err := clientSet.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metaV1.GetOptions{})
 		if err == nil && podObj != nil {
-			pdbKeys, keyErr := getMatchingPDBKeys(ctx, clientSet, podObj)
+			pdbKeys, keyErr := getMatchingPDBKeys(ctx, clientSet, cfg.PDBLister, podObj)
 			if keyErr != nil {
 				slog.Warn("PDB 키 계산 실패(토큰 미적용)", "pod", pod.Name, "error", keyErr)
 			} else {
@@ -224,32 +260,61 @@ func evictPodWithRetry(ctx context.Context, clientSet kubernetes.Interface, pod
 		}
 	}
 
+	var retryAfter time.Duration
 	for retry := 0; retry < cfg.MaxRetries; retry++ {
 		if retry > 0 {
+			// PDB 차단 응답에 Retry-After가 있으면 그만큼 기다립니다.
+			backoff := cfg.RetryBackoffDuration
+			if retryAfter > 0 {
+				backoff = retryAfter
+			}
 			select {
 			case <-ctx.Done():
 				return ctx.Err()
-			case <-time.After(cfg.RetryBackoffDuration):
+			case <-time.After(backoff):
 			}
 		}
+		retryAfter = 0
+		lastRetry := retry == cfg.MaxRetries-1
 
 		if cfg.EvictionMode != EvictionModeDelete {
-			if err := checkPDB(ctx, clientSet, pod); err != nil {
+			if err := checkPDB(ctx, clientSet, cfg.PDBLister, pod); err != nil {
 				lastErr = err
+				// PDB 조회 실패 등은 PDB 차단이 아니므로 강제 삭제하지 않고 일반 실패로 재시도합니다.
+				var blocked *PDBBlockedError
+				if !errors.As(err, &blocked) {
+					slog.Warn("PDB 확인 실패, 재시도 예정", "pod", pod.Name, "retry", retry+1, "error", err)
+					continue
+				}
+				cfg.Stats.recordPDBBlocked(pod.Namespace, pod.Name)
+				if cfg.Force && cfg.ForceOnPDBBlock && lastRetry {
+					return forceDeleteAfterEvictionFailure(ctx, clientSet, pod, cfg, err)
+				}
 				slog.Warn("PDB 체크 실패, 재시도 예정", "pod", pod.Name, "retry", retry+1, "error", err)
 				continue
 			}
 		}
 
-		if err := evictPod(ctx, clientSet, pod, cfg); err != nil {
+		removal, err := evictPod(ctx, clientSet, pod, cfg)
+		if err != nil {
 			lastErr = err
+			var blocked *PDBBlockedError
+			if errors.As(err, &blocked) {
+				// PDB 차단(429)은 기본적으로 강제 삭제하지 않고 Retry-After 후 재시도합니다.
+				cfg.Stats.recordPDBBlocked(pod.Namespace, pod.Name)
+				retryAfter = blocked.RetryAfter
+				if cfg.EvictionMode == EvictionModeEvict && cfg.Force && cfg.ForceOnPDBBlock && lastRetry {
+					return forceDeleteAfterEvictionFailure(ctx, clientSet, pod, cfg, err)
+				}
+				slog.Warn("PDB에 의해 eviction 차단, 재시도 예정", "pod", pod.Name, "retry", retry+1, "retryAfter", retryAfter.String(), "error", err)
+				continue
+			}
 			if cfg.EvictionMode == EvictionModeEvict && cfg.Force {
-				slog.Warn("eviction 실패로 delete 강제 전환", "pod", pod.Name, "retry", retry+1, "error", err)
-				forceErr := fallbackDeletePod(ctx, clientSet, pod, int64(0), metaV1.DeletePropagationBackground)
+				forceErr := forceDeleteAfterEvictionFailure(ctx, clientSet, pod, cfg, err)
 				if forceErr == nil {
 					return nil
 				}
-				lastErr = fmt.Errorf("eviction 실패(%v), delete 강제 전환 실패(%w)", err, forceErr)
+				lastErr = forceErr
 			}
 			slog.Warn("Pod eviction 실패, 재시도 예정", "pod", pod.Name, "retry", retry+1, "error", err)
 			continue
@@ -261,14 +326,15 @@ func evictPodWithRetry(ctx context.Context, clientSet kubernetes.Interface, pod
 			continue
 		}
 
+		cfg.Stats.recordRemoval(removal)
 		return nil
 	}
 
 	return fmt.Errorf("최대 재시도 횟수 초과: %w", lastErr)
 }
 
-func checkPDB(ctx context.Context, clientSet kubernetes.Interface, pod coreV1.Pod) error {
-	pdbs, err := getPDBsWithCache(ctx, clientSet, pod.Namespace)
+func checkPDB(ctx context.Context, clientSet kubernetes.Interface, lister policyv1listers.PodDisruptionBudgetLister, pod coreV1.Pod) error {
+	pdbs, err := listPDBs(ctx, clientSet, lister, pod.Namespace)
 	if err != nil {
 		return fmt.Errorf("PDB 조회 실패: %w", err)
 	}
@@ -280,7 +346,7 @@ func checkPDB(ctx context.Context, clientSet kubernetes.Interface, pod coreV1.Po
 			continue
 		}
 		if selector.Matches(labels.Set(pod.Labels)) && pdb.Status.DisruptionsAllowed < 1 {
-			return fmt.Errorf("PDB %s 에 의해 eviction 제한됨 (허용 disruption: %d)", pdb.Name, pdb.Status.DisruptionsAllowed)
+			return &PDBBlockedError{Err: fmt.Errorf("PDB %s 에 의해 eviction 제한됨 (허용 disruption: %d)", pdb.Name, pdb.Status.DisruptionsAllowed)}
 		}
 	}
 
@@ -322,17 +388,26 @@ func getPDBsWithCache(ctx context.Context, clientSet kubernetes.Interface, names
 	return pdbs, nil
 }
 
-func evictPod(ctx context.Context, clientSet kubernetes.Interface, pod coreV1.Pod, cfg *EvictionConfig) error {
+// podRemoval은 evictPod가 파드를 어떤 방식으로 제거했는지 나타냅니다. (요약 집계용)
+type podRemoval int
+
+const (
+	podRemovalNone    podRemoval = iota // 이미 제거된 파드
+	podRemovalEvicted                   // eviction API
+	podRemovalDeleted                   // delete 모드 또는 eviction API 미지원 fallback
+)
+
+func evictPod(ctx context.Context, clientSet kubernetes.Interface, pod coreV1.Pod, cfg *EvictionConfig) (podRemoval, error) {
 	podObj, err := clientSet.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metaV1.GetOptions{})
 	if apierrors.IsNotFound(err) {
 		slog.Info("파드가 이미 제거됨", "pod", pod.Name)
-		return nil
+		return podRemovalNone, nil
 	}
 	if err != nil {
-		return fmt.Errorf("파드 상태 조회 실패: %w", err)
+		return podRemovalNone, fmt.Errorf("파드 상태 조회 실패: %w", err)
 	}
 
-	gracePeriod := int64(60)
+	gracePeriod := int64(effectiveGracePeriod(*podObj, cfg).Seconds())
 	if isPodInProblemState(podObj) {
 		gracePeriod = int64(0)
 		slog.Info("문제 상태 파드 강제 삭제", "pod", pod.Name, "status", podObj.Status.Phase)
@@ -341,7 +416,7 @@ func evictPod(ctx context.Context, clientSet kubernetes.Interface, pod coreV1.Po
 	propagationPolicy := metaV1.DeletePropagationOrphan
 
 	if cfg.EvictionMode == EvictionModeDelete {
-		return fallbackDeletePod(ctx, clientSet, pod, gracePeriod, propagationPolicy)
+		return podRemovalDeleted, fallbackDeletePod(ctx, clientSet, pod, gracePeriod, propagationPolicy)
 	}
 
 	eviction := &policyv1.Eviction{
@@ -355,17 +430,17 @@ func evictPod(ctx context.Context, clientSet kubernetes.Interface, pod coreV1.Po
 		},
 	}
 
-	slog.Info("파드 eviction 시작", "pod", pod.Name)
+	slog.Info("파드 eviction 시작", "pod", pod.Name, "gracePeriodSeconds", gracePeriod)
 	if err = clientSet.CoreV1().Pods(pod.Namespace).EvictV1(ctx, eviction); err != nil {
 		if apierrors.IsNotFound(err) {
 			slog.Info("파드가 이미 제거됨", "pod", pod.Name)
-			return nil
+			return podRemovalNone, nil
 		}
 
 		if shouldFallbackToDelete(err) {
-			return fallbackDeletePod(ctx, clientSet, pod, gracePeriod, propagationPolicy)
+			return podRemovalDeleted, fallbackDeletePod(ctx, clientSet, pod, gracePeriod, propagationPolicy)
 		}
-		return err
+		return podRemovalNone, classifyEvictionError(err)
 	}
 
 	// Eviction accepted after PDB checks; issue a best-effort delete so fake clients and
@@ -377,6 +452,17 @@ func evictPod(ctx context.Context, clientSet kubernetes.Interface, pod coreV1.Po
 	if deleteErr != nil && !apierrors.IsNotFound(deleteErr) {
 		slog.Warn("eviction 이후 delete 보정 실패", "pod", pod.Name, "error", deleteErr)
 	}
+	return podRemovalEvicted, nil
+}
+
+// forceDeleteAfterEvictionFailure는 eviction 실패 후 grace period 0으로 강제 삭제합니다.
+func forceDeleteAfterEvictionFailure(ctx context.Context, clientSet kubernetes.Interface, pod coreV1.Pod, cfg *EvictionConfig, evictErr error) error {
+	slog.Warn("eviction 실패로 delete 강제 전환", "pod", pod.Name, "error", evictErr)
+	cfg.Stats.recordForceDelete()
+	if err := fallbackDeletePod(ctx, clientSet, pod, int64(0), metaV1.DeletePropagationBackground); err != nil {
+		return fmt.Errorf("eviction 실패(%v), delete 강제 전환 실패(%w)", evictErr, err)
+	}
+	cfg.Stats.recordForcedByFallback()
 	return nil
 }
 
@@ -405,8 +491,8 @@ func fallbackDeletePod(ctx context.Context, clientSet kubernetes.Interface, pod
 	return err
 }
 
-func getMatchingPDBKeys(ctx context.Context, clientSet kubernetes.Interface, podObj *coreV1.Pod) ([]string, error) {
-	pdbs, err := getPDBsWithCache(ctx, clientSet, podObj.Namespace)
+func getMatchingPDBKeys(ctx context.Context, clientSet kubernetes.Interface, lister policyv1listers.PodDisruptionBudgetLister, podObj *coreV1.Pod) ([]string, error) {
+	pdbs, err := listPDBs(ctx, clientSet, lister, podObj.Namespace)
 	if err != nil {
 		return nil, err
 	}
@@ -447,47 +533,11 @@ func waitForPodDeletion(ctx context.Context, clientSet kubernetes.Interface, pod
 		timeoutMultiplier = 1.5
 	}
 
-	timeout := time.Duration(float64(cfg.PodDeletionTimeout) * timeoutMultiplier)
+	timeout := time.Duration(float64(podDeletionTimeout(pod, cfg)) * timeoutMultiplier)
 	timeoutTimer := time.NewTimer(timeout)
 	defer timeoutTimer.Stop()
 
-	ticker := time.NewTicker(cfg.CheckInterval)
-	defer ticker.Stop()
-
-	deleted, err := podDeletionObserved(ctx, clientSet, pod)
-	if deleted {
-		slog.Info("파드가 이미 제거됨", "pod", pod.Name)
-		return nil
-	}
-	if err != nil {
-		slog.Warn("파드 상태 확인 중 오류 발생, 계속 진행", "pod", pod.Name, "error", err)
-	}
-
-	for {
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		case <-timeoutTimer.C:
-			deleted, err = podDeletionObserved(ctx, clientSet, pod)
-			if deleted {
-				return nil
-			}
-			if err != nil && isRateLimitError(err) {
-				slog.Warn("rate limit으로 인해 파드 상태 확인 불가, 파드 삭제로 간주", "pod", pod.Name, "error", err)
-				return nil
-			}
-			return fmt.Errorf("파드 삭제 타임아웃: %s", pod.Name)
-		case <-ticker.C:
-			deleted, err = podDeletionObserved(ctx, clientSet, pod)
-			if deleted {
-				slog.Info("파드 eviction 완료", "pod", pod.Name)
-				return nil
-			}
-			if err != nil {
-				slog.Warn("파드 상태 확인 중 일시적 오류", "pod", pod.Name, "error", err)
-			}
-		}
-	}
+	return watchPodDeletion(ctx, clientSet, pod, cfg, timeoutTimer.C)
 }
 
 func podDeletionObserved(ctx context.Context, clientSet kubernetes.Interface, pod coreV1.Pod) (bool, error) {
@@ -70,6 +70,62 @@ func TestEvictPods(t *testing.T) {
 	}
 }
 
+func TestEvictPodsUsesSharedSemaphore(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	_, err := client.CoreV1().Pods("default").Create(context.Background(), &coreV1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{Name: "pod-1", Namespace: "default"},
+		Spec:       coreV1.PodSpec{NodeName: "node-1"},
+	}, metaV1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("파드 생성 실패: %v", err)
+	}
+
+	// 다른 노드가 공유 세마포어를 모두 점유하고 있으면 eviction을 시작하지 못함
+	cfg := DefaultEvictionConfig()
+	cfg.MaxConcurrentEvictions = 1
+	cfg.EvictionSemaphore = make(chan struct{}, 1)
+	cfg.EvictionSemaphore <- struct{}{}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_ = EvictPods(ctx, client, "node-1", cfg)
+	assert.Equal(t, 0, countEvictionActions(client))
+
+	// 세마포어가 반환되면 정상 진행
+	<-cfg.EvictionSemaphore
+	assert.NoError(t, EvictPods(context.Background(), client, "node-1", cfg))
+	assert.Equal(t, 1, countEvictionActions(client))
+}
+
+func TestEvictPodsIgnoresZeroCapacitySemaphore(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	_, err := client.CoreV1().Pods("default").Create(context.Background(), &coreV1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{Name: "pod-1", Namespace: "default"},
+		Spec:       coreV1.PodSpec{NodeName: "node-1"},
+	}, metaV1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("파드 생성 실패: %v", err)
+	}
+
+	cfg := DefaultEvictionConfig()
+	cfg.EvictionSemaphore = make(chan struct{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	assert.NoError(t, EvictPods(ctx, client, "node-1", cfg))
+	assert.Equal(t, 1, countEvictionActions(client))
+}
+
+func countEvictionActions(client *fake.Clientset) int {
+	count := 0
+	for _, action := range client.Actions() {
+		if action.GetVerb() == "create" && action.GetSubresource() == "eviction" {
+			count++
+		}
+	}
+	return count
+}
+
 // 별도의 PDB 테스트 케이스
 func TestPodWithPDBEviction(t *testing.T) {
 	tests := []struct {
@@ -133,7 +189,7 @@ func TestPodWithPDBEviction(t *testing.T) {
 				t.Fatalf("PDB 생성 실패: %v", err)
 			}
 
-			err = checkPDB(context.Background(), client, pod)
+			err = checkPDB(context.Background(), client, nil, pod)
 			if (err != nil) != tt.expectedErr {
 				t.Fatalf("checkPDB() error = %v, expectedErr=%v", err, tt.expectedErr)
 			}
@@ -216,7 +272,7 @@ func TestEvictPod(t *testing.T) {
 			}
 
 			// evictPod 테스트
-			err := evictPod(context.Background(), client, tt.pod, DefaultEvictionConfig())
+			_, err := evictPod(context.Background(), client, tt.pod, DefaultEvictionConfig())
 
 			if (err != nil) != tt.expectedError {
 				t.Errorf("evictPod() error = %v, expectedError %v", err, tt.expectedError)
@@ -659,7 +715,7 @@ func TestEvictProblemPods(t *testing.T) {
 	assert.True(t, isPodInProblemState(problemPod))
 
 	// evictPod 함수 테스트 (강제 삭제 옵션 사용)
-	err = evictPod(context.Background(), client, *problemPod, DefaultEvictionConfig())
+	_, err = evictPod(context.Background(), client, *problemPod, DefaultEvictionConfig())
 	assert.NoError(t, err)
 }
 
@@ -676,7 +732,7 @@ func TestEvictPodReturnsErrorOnGetFailure(t *testing.T) {
 		},
 	}
 
-	err := evictPod(context.Background(), client, pod, DefaultEvictionConfig())
+	_, err := evictPod(context.Background(), client, pod, DefaultEvictionConfig())
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "파드 상태 조회 실패")
 }
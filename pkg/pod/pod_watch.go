@@ -0,0 +1,226 @@
+package pod
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+)
+
+// watchPodDeletion은 파드 하나를 watch로 지켜보다가 삭제(또는 같은 이름의 새 UID 재생성)를 관찰하면 바로 반환합니다.
+// watch가 끊기면 다시 연결하고, watch를 시작할 수 없으면 CheckInterval 주기 폴링으로 대체합니다.
+func watchPodDeletion(ctx context.Context, clientSet kubernetes.Interface, pod coreV1.Pod, cfg *EvictionConfig, timeout <-chan time.Time) error {
+	for {
+		w, err := clientSet.CoreV1().Pods(pod.Namespace).Watch(ctx, metaV1.ListOptions{
+			FieldSelector: fields.OneTermEqualSelector("metadata.name", pod.Name).String(),
+		})
+		if err != nil {
+			slog.Warn("파드 watch 시작 실패, 폴링으로 확인", "pod", pod.Name, "error", err)
+			return pollPodDeletion(ctx, clientSet, pod, cfg, timeout)
+		}
+
+		// watch를 연 뒤에 현재 상태를 확인해야 그 사이의 삭제를 놓치지 않습니다.
+		deleted, err := podDeletionObserved(ctx, clientSet, pod)
+		if deleted {
+			w.Stop()
+			slog.Info("파드가 이미 제거됨", "pod", pod.Name)
+			return nil
+		}
+		if err != nil {
+			slog.Warn("파드 상태 확인 중 오류 발생, 계속 진행", "pod", pod.Name, "error", err)
+		}
+
+		done, err := waitPodDeletionEvent(ctx, clientSet, w, pod, timeout)
+		w.Stop()
+		if done {
+			return err
+		}
+		slog.Info("파드 watch 재연결", "pod", pod.Name)
+	}
+}
+
+// waitPodDeletionEvent는 watch 이벤트로 삭제를 기다립니다. watch가 끊기면 done=false를 반환합니다.
+func waitPodDeletionEvent(ctx context.Context, clientSet kubernetes.Interface, w watch.Interface, pod coreV1.Pod, timeout <-chan time.Time) (bool, error) {
+	for {
+		select {
+		case <-ctx.Done():
+			return true, ctx.Err()
+		case <-timeout:
+			return true, podDeletionTimedOut(ctx, clientSet, pod)
+		case event, ok := <-w.ResultChan():
+			if !ok || event.Type == watch.Error {
+				return false, nil
+			}
+			if podDeletedByEvent(event, pod) {
+				slog.Info("파드 eviction 완료", "pod", pod.Name)
+				return true, nil
+			}
+		}
+	}
+}
+
+func podDeletedByEvent(event watch.Event, pod coreV1.Pod) bool {
+	p, ok := event.Object.(*coreV1.Pod)
+	if !ok || p.Namespace != pod.Namespace || p.Name != pod.Name {
+		return false
+	}
+	switch event.Type {
+	case watch.Deleted:
+		return true
+	case watch.Added, watch.Modified:
+		if pod.UID != "" && p.UID != pod.UID {
+			slog.Info("파드가 새 UID로 재생성됨", "pod", pod.Name, "oldUID", pod.UID, "newUID", p.UID)
+			return true
+		}
+	}
+	return false
+}
+
+// pollPodDeletion은 watch를 쓸 수 없을 때 CheckInterval마다 Get으로 삭제를 확인합니다.
+func pollPodDeletion(ctx context.Context, clientSet kubernetes.Interface, pod coreV1.Pod, cfg *EvictionConfig, timeout <-chan time.Time) error {
+	ticker := time.NewTicker(cfg.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timeout:
+			return podDeletionTimedOut(ctx, clientSet, pod)
+		case <-ticker.C:
+			deleted, err := podDeletionObserved(ctx, clientSet, pod)
+			if deleted {
+				slog.Info("파드 eviction 완료", "pod", pod.Name)
+				return nil
+			}
+			if err != nil {
+				slog.Warn("파드 상태 확인 중 일시적 오류", "pod", pod.Name, "error", err)
+			}
+		}
+	}
+}
+
+// podDeletionTimedOut은 타임아웃 시점에 마지막으로 한 번 더 확인합니다. rate limit으로 확인할 수 없으면 삭제로 간주합니다.
+func podDeletionTimedOut(ctx context.Context, clientSet kubernetes.Interface, pod coreV1.Pod) error {
+	deleted, err := podDeletionObserved(ctx, clientSet, pod)
+	if deleted {
+		return nil
+	}
+	if err != nil && isRateLimitError(err) {
+		slog.Warn("rate limit으로 인해 파드 상태 확인 불가, 파드 삭제로 간주", "pod", pod.Name, "error", err)
+		return nil
+	}
+	return fmt.Errorf("파드 삭제 타임아웃: %s", pod.Name)
+}
+
+// WaitForNonCriticalPodsTerminated는 노드의 DaemonSet 제외 파드가 모두 종료될 때까지 spec.nodeName으로 필터한 watch로 기다립니다.
+// watch가 끊기면 목록을 다시 조회해 이어서 기다리고, watch를 시작할 수 없으면 pollInterval 주기 목록 조회로 대체합니다.
+// 타임아웃은 ctx로 제어합니다.
+func WaitForNonCriticalPodsTerminated(ctx context.Context, clientSet kubernetes.Interface, nodeName string, pollInterval time.Duration) error {
+	for {
+		w, err := clientSet.CoreV1().Pods(metaV1.NamespaceAll).Watch(ctx, metaV1.ListOptions{
+			FieldSelector: fields.OneTermEqualSelector("spec.nodeName", nodeName).String(),
+		})
+		if err != nil {
+			slog.Warn("노드 파드 watch 시작 실패, 폴링으로 확인", "nodeName", nodeName, "error", err)
+			return pollNonCriticalPodsTerminated(ctx, clientSet, nodeName, pollInterval)
+		}
+
+		// watch를 연 뒤에 목록을 조회해야 그 사이의 삭제를 놓치지 않습니다.
+		pods, err := GetNonCriticalPods(ctx, clientSet, nodeName)
+		if err != nil {
+			w.Stop()
+			return err
+		}
+		remaining := make(map[string]struct{}, len(pods))
+		for _, p := range nodeActivePods(pods, nodeName) {
+			remaining[p.Namespace+"/"+p.Name] = struct{}{}
+		}
+		if len(remaining) > 0 {
+			slog.Info("Pod 종료 대기 중", "nodeName", nodeName, "remainingPods", len(remaining))
+		}
+
+		closed := false
+		for !closed && len(remaining) > 0 {
+			closed, err = waitNodePodEvent(ctx, w, nodeName, remaining)
+			if err != nil {
+				w.Stop()
+				return err
+			}
+		}
+		w.Stop()
+		if len(remaining) == 0 {
+			slog.Info("데몬셋 제외 모든 Pod 종료 완료", "nodeName", nodeName)
+			return nil
+		}
+		slog.Info("노드 파드 watch 재연결", "nodeName", nodeName)
+	}
+}
+
+// waitNodePodEvent는 watch 이벤트 하나를 받아 remaining을 갱신합니다. watch가 끊기면 closed=true를 반환합니다.
+func waitNodePodEvent(ctx context.Context, w watch.Interface, nodeName string, remaining map[string]struct{}) (bool, error) {
+	select {
+	case <-ctx.Done():
+		return false, ctx.Err()
+	case event, ok := <-w.ResultChan():
+		if !ok || event.Type == watch.Error {
+			return true, nil
+		}
+		p, ok := event.Object.(*coreV1.Pod)
+		if !ok || p.Spec.NodeName != nodeName {
+			return false, nil
+		}
+		key := p.Namespace + "/" + p.Name
+		if event.Type == watch.Deleted || !isActiveNonCriticalPod(*p) {
+			delete(remaining, key)
+		} else {
+			remaining[key] = struct{}{}
+		}
+		return false, nil
+	}
+}
+
+func pollNonCriticalPodsTerminated(ctx context.Context, clientSet kubernetes.Interface, nodeName string, pollInterval time.Duration) error {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			pods, err := GetNonCriticalPods(ctx, clientSet, nodeName)
+			if err != nil {
+				return err
+			}
+			pods = nodeActivePods(pods, nodeName)
+			if len(pods) == 0 {
+				slog.Info("데몬셋 제외 모든 Pod 종료 완료", "nodeName", nodeName)
+				return nil
+			}
+			slog.Info("Pod 종료 대기 중", "nodeName", nodeName, "remainingPods", len(pods))
+		}
+	}
+}
+
+// nodeActivePods는 목록 조회 결과를 이벤트 처리와 같은 기준(노드, 종료 여부)으로 한 번 더 거릅니다.
+func nodeActivePods(pods []coreV1.Pod, nodeName string) []coreV1.Pod {
+	active := make([]coreV1.Pod, 0, len(pods))
+	for _, p := range pods {
+		if p.Spec.NodeName == nodeName && isActiveNonCriticalPod(p) {
+			active = append(active, p)
+		}
+	}
+	return active
+}
+
+// isActiveNonCriticalPod는 GetNonCriticalPods와 같은 기준(종료되지 않은, DaemonSet이 아닌 파드)으로 판단합니다.
+func isActiveNonCriticalPod(p coreV1.Pod) bool {
+	return p.Status.Phase != coreV1.PodSucceeded && p.Status.Phase != coreV1.PodFailed && !isManagedByDaemonSet(p)
+}
@@ -0,0 +1,129 @@
+package pod
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func nodePod(name string, nodeName string, uid types.UID) *coreV1.Pod {
+	return &coreV1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: "default", UID: uid},
+		Spec:       coreV1.PodSpec{NodeName: nodeName},
+		Status:     coreV1.PodStatus{Phase: coreV1.PodRunning},
+	}
+}
+
+func failWatch(client *fake.Clientset) {
+	client.PrependWatchReactor("pods", func(action k8stesting.Action) (bool, watch.Interface, error) {
+		return true, nil, errors.New("watch forbidden")
+	})
+}
+
+func TestWaitForPodDeletionUsesWatch(t *testing.T) {
+	// 폴링 주기가 길어도 watch로 삭제를 바로 관찰합니다.
+	cfg := &EvictionConfig{PodDeletionTimeout: 5 * time.Second, CheckInterval: time.Hour}
+
+	tests := []struct {
+		name   string
+		mutate func(client *fake.Clientset)
+	}{
+		{
+			name: "삭제 이벤트",
+			mutate: func(client *fake.Clientset) {
+				_ = client.CoreV1().Pods("default").Delete(context.Background(), "api-0", metaV1.DeleteOptions{})
+			},
+		},
+		{
+			name: "같은 이름의 새 UID로 재생성",
+			mutate: func(client *fake.Clientset) {
+				_ = client.Tracker().Delete(coreV1.SchemeGroupVersion.WithResource("pods"), "default", "api-0")
+				_ = client.Tracker().Add(nodePod("api-0", "node-2", "new-uid"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			target := nodePod("api-0", "node-1", "old-uid")
+			client := fake.NewSimpleClientset(target)
+			go func() {
+				time.Sleep(50 * time.Millisecond)
+				tt.mutate(client)
+			}()
+
+			start := time.Now()
+			assert.NoError(t, waitForPodDeletion(context.Background(), client, *target, cfg))
+			assert.Less(t, time.Since(start), 2*time.Second)
+		})
+	}
+}
+
+func TestWaitForPodDeletionFallsBackToPolling(t *testing.T) {
+	target := nodePod("api-0", "node-1", "old-uid")
+	client := fake.NewSimpleClientset(target)
+	failWatch(client)
+	go func() {
+		time.Sleep(30 * time.Millisecond)
+		_ = client.CoreV1().Pods("default").Delete(context.Background(), "api-0", metaV1.DeleteOptions{})
+	}()
+
+	cfg := &EvictionConfig{PodDeletionTimeout: 2 * time.Second, CheckInterval: 10 * time.Millisecond}
+	assert.NoError(t, waitForPodDeletion(context.Background(), client, *target, cfg))
+
+	// 타임아웃은 그대로 동작합니다.
+	client = fake.NewSimpleClientset(target)
+	failWatch(client)
+	cfg.PodDeletionTimeout = 50 * time.Millisecond
+	err := waitForPodDeletion(context.Background(), client, *target, cfg)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "파드 삭제 타임아웃")
+}
+
+func TestWaitForNonCriticalPodsTerminated(t *testing.T) {
+	daemon := nodePod("ds-0", "node-1", "ds")
+	daemon.OwnerReferences = []metaV1.OwnerReference{{Kind: "DaemonSet", Name: "ds"}}
+	objects := []*coreV1.Pod{
+		nodePod("api-0", "node-1", "a"),
+		nodePod("job-0", "node-1", "b"),
+		nodePod("other-0", "node-2", "c"),
+		daemon,
+	}
+
+	for _, watchFails := range []bool{false, true} {
+		client := fake.NewSimpleClientset()
+		for _, p := range objects {
+			_ = client.Tracker().Add(p)
+		}
+		if watchFails {
+			failWatch(client)
+		}
+		go func() {
+			time.Sleep(30 * time.Millisecond)
+			_ = client.CoreV1().Pods("default").Delete(context.Background(), "api-0", metaV1.DeleteOptions{})
+			done := nodePod("job-0", "node-1", "b")
+			done.Status.Phase = coreV1.PodSucceeded
+			_, _ = client.CoreV1().Pods("default").UpdateStatus(context.Background(), done, metaV1.UpdateOptions{})
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		assert.NoError(t, WaitForNonCriticalPodsTerminated(ctx, client, "node-1", 10*time.Millisecond), "watchFails=%v", watchFails)
+		cancel()
+	}
+
+	// 남은 파드가 있으면 ctx 타임아웃까지 기다립니다.
+	client := fake.NewSimpleClientset(nodePod("api-0", "node-1", "a"))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, WaitForNonCriticalPodsTerminated(ctx, client, "node-1", time.Hour), context.DeadlineExceeded)
+}
@@ -5,11 +5,20 @@ type NodeDrainResult struct {
 	NodeName        string `json:"node_name"`
 	InstanceType    string `json:"instance_type"`
 	NodepoolName    string `json:"nodepool_name"`
+	Zone            string `json:"zone,omitempty"`
+	CapacityType    string `json:"capacity_type,omitempty"`
 	Age             string `json:"age"`
 	StartedAt       string `json:"started_at"`
 	DurationSeconds int64  `json:"duration_seconds"`
 	Success         bool   `json:"success"`
 	FailureReason   string `json:"failure_reason,omitempty"`
+
+	// UnhealthyCondition은 비정상 노드 우선 드레인 모드에서 노드가 선택된 condition입니다.
+	UnhealthyCondition string `json:"unhealthy_condition,omitempty"`
+
+	// Score는 드레인 후보 종합 점수이며, ScoreBreakdown은 신호별 가중 점수입니다.
+	Score          float64            `json:"score,omitempty"`
+	ScoreBreakdown map[string]float64 `json:"score_breakdown,omitempty"`
 }
 
 type NodeDrainSummary struct {
@@ -26,8 +35,50 @@ type NodeDrainSummary struct {
 	ForcedByFallback  int `json:"forced_by_fallback"`
 	ProblemPodsForced int `json:"problem_pods_forced"`
 
-	StoppedBySafety  bool   `json:"stopped_by_safety"`
-	StopSafetyReason string `json:"stop_safety_reason"`
+	StoppedBySafety  bool               `json:"stopped_by_safety"`
+	StopSafetyReason string             `json:"stop_safety_reason"`
+	StopSafetyCheck  *SafetyCheckResult `json:"stop_safety_check,omitempty"`
+
+	StoppedByCircuitBreaker string `json:"stopped_by_circuit_breaker,omitempty"`
+	CircuitBreakerReason    string `json:"circuit_breaker_reason,omitempty"`
+	StoppedByCanary         string `json:"stopped_by_canary,omitempty"`
+	CanaryFailureReason     string `json:"canary_failure_reason,omitempty"`
 
 	TopErrorReasons []string `json:"top_error_reasons"`
 }
+
+// ResourceAllocateRate는 리소스 하나의 Karpenter allocate rate(%)입니다.
+type ResourceAllocateRate struct {
+	Resource     string `json:"resource"`
+	AllocateRate int    `json:"allocate_rate"`
+}
+
+// DrainBudgetStatus는 여러 실행에 걸친 nodepool별 드레인 예산 현황입니다.
+type DrainBudgetStatus struct {
+	NodepoolName    string `json:"nodepool_name"`
+	MaxNodes        int    `json:"max_nodes"`
+	Window          string `json:"window"`
+	DrainedInWindow int    `json:"drained_in_window"`
+	Remaining       int    `json:"remaining"`
+}
+
+// SafetyCheckResult는 이름이 있는 안전 조건 하나의 평가 결과입니다.
+type SafetyCheckResult struct {
+	Name       string  `json:"name"`
+	Query      string  `json:"query"`
+	Comparator string  `json:"comparator"`
+	Threshold  float64 `json:"threshold"`
+	Observed   float64 `json:"observed"`
+	NoData     bool    `json:"no_data,omitempty"`
+	Blocked    bool    `json:"blocked"`
+	Error      string  `json:"error,omitempty"`
+}
+
+// DrainCanaryResult는 첫 노드(canary) 드레인 후 soak 기간 동안의 점검 결과입니다.
+type DrainCanaryResult struct {
+	NodeName string             `json:"node_name"`
+	Soak     string             `json:"soak"`
+	Passed   bool               `json:"passed"`
+	Reason   string             `json:"reason,omitempty"`
+	Check    *SafetyCheckResult `json:"check,omitempty"`
+}